@@ -1,13 +1,26 @@
 package configs
 
 import (
-    _ "embed"
+    "embed"
 )
 
 var (
-    //go:embed decks/default/ingredients.json
-    DefaultIngredientsJSON []byte
+    //go:embed achievements.json
+    AchievementsJSON []byte
 
-    //go:embed decks/default/recipes.json
-    DefaultRecipesJSON []byte
+    // Decks of cards, one directory each named after its theme
+    //go:embed decks
+    DecksFS embed.FS
+
+    // Scripted lessons of the tutorial, one file each
+    //go:embed tutorials/*.json
+    TutorialsFS embed.FS
+
+    // Preset tables of the puzzle mode, one file each
+    //go:embed puzzles/*.json
+    PuzzlesFS embed.FS
+
+    // Chapters of the campaign, played in the order of their file names
+    //go:embed campaign/*.json
+    CampaignFS embed.FS
 )
\ No newline at end of file
@@ -1,21 +1,49 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
 	"github.com/thanhfphan/ebitengj2025/internal/game"
 )
 
 func main() {
+	botCmd := flag.String("bot", "", "command line of an external bot playing the bot seats of local matches, the daily challenge keeps its own bots")
+	botTimeout := flag.Duration("bot-timeout", ai.DefaultExternalTimeout, "how long the external bot may think about a turn")
+	botName := flag.String("bot-name", "", `registered bot or tuned profile playing the bot seats of local matches, such as "heuristic"`)
+	profiles := flag.String("profiles", ai.DefaultProfileDir, "directory of tuned heuristic bot profiles, seated by their name")
+	flag.Parse()
+
+	if _, err := ai.LoadProfiles(*profiles); err != nil {
+		log.Printf("Error loading bot profiles: %v", err)
+	}
+
 	game, err := game.New()
 	if err != nil {
 		log.Fatalf("Game init error: %v", err)
 	}
+	if *botName != "" {
+		if _, err := ai.New(*botName); err != nil {
+			log.Fatal(err)
+		}
+		game.NewBot = func() ai.Bot {
+			bot, _ := ai.New(*botName)
+			return bot
+		}
+	}
+	if command := strings.Fields(*botCmd); len(command) > 0 {
+		game.NewBot = func() ai.Bot {
+			return ai.NewExternalBot(command, *botTimeout)
+		}
+	}
 
 	ebiten.SetWindowTitle("Food Cards")
 	ebiten.SetWindowSize(1280, 720)
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true) // Save the match in progress before quitting
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatalf("Game error: %v", err)
 	}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/thanhfphan/ebitengj2025/internal/netplay"
+)
+
+func main() {
+	cfg := netplay.DefaultConfig()
+	addr := flag.String("addr", ":7777", "address of the websocket endpoint, served at /ws")
+	tcpAddr := flag.String("tcp", "", "optional address for clients speaking line delimited JSON over TCP")
+	flag.IntVar(&cfg.MaxRooms, "max-rooms", cfg.MaxRooms, "rooms open at the same time")
+	flag.DurationVar(&cfg.BotDelay, "bot-delay", cfg.BotDelay, "pause before a bot plays")
+	flag.DurationVar(&cfg.PingInterval, "ping-interval", cfg.PingInterval, "time between two pings, a client silent for three of them is disconnected")
+	flag.DurationVar(&cfg.ReclaimTimeout, "reclaim-timeout", cfg.ReclaimTimeout, "how long a player who lost connection may take their seat back from the bot")
+	flag.Func("origin", "web page origin, such as https://example.com, allowed to connect besides the server's own host (repeatable)", func(v string) error {
+		cfg.Origins = append(cfg.Origins, v)
+		return nil
+	})
+	flag.Parse()
+
+	server, err := netplay.NewServer(cfg)
+	if err != nil {
+		log.Fatalf("Server init error: %v", err)
+	}
+
+	if *tcpAddr != "" {
+		l, err := net.Listen("tcp", *tcpAddr)
+		if err != nil {
+			log.Fatalf("Listen error: %v", err)
+		}
+		log.Printf("Accepting TCP clients on %s", l.Addr())
+		go func() {
+			if err := server.Serve(l); err != nil {
+				log.Printf("TCP server error: %v", err)
+			}
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/ws", server)
+	httpServer := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		log.Printf("Accepting websocket clients on ws://%s/ws", *addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("HTTP server error: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	<-ctx.Done()
+
+	log.Println("Shutting down")
+	server.Close()
+	httpServer.Close()
+}
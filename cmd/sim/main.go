@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/gym"
+	"github.com/thanhfphan/ebitengj2025/internal/sim"
+)
+
+// botFlags collects a repeated flag such as -bot
+type botFlags []string
+
+func (f *botFlags) String() string     { return strings.Join(*f, ", ") }
+func (f *botFlags) Set(v string) error { *f = append(*f, v); return nil }
+
+type stats struct {
+	name      string
+	wins      int
+	positions int
+	dishes    int
+	score     int
+}
+
+func main() {
+	var specs botFlags
+	flag.Var(&specs, "bot", `a seat, the name of a registered bot such as "easy" or name=command for an external bot. Repeat for each seat, four easy bots by default`)
+	matches := flag.Int("matches", 100, "matches to play")
+	seed := flag.Int64("seed", 1, "seed of the first match, the next ones count up")
+	deck := flag.String("deck", "default", "deck to play with")
+	ruleset := flag.String("ruleset", card.RulesetStandard, "ruleset to play with")
+	profiles := flag.String("profiles", ai.DefaultProfileDir, "directory of tuned heuristic bot profiles, seated by their name")
+	var policies botFlags
+	flag.Var(&policies, "policy", "a trained policy as name=path, which -bot can then seat by name. Repeatable")
+	timeout := flag.Duration("bot-timeout", ai.DefaultExternalTimeout, "how long an external bot may think about a turn")
+	flag.Parse()
+
+	if _, err := ai.LoadProfiles(*profiles); err != nil {
+		log.Fatal(err)
+	}
+	for _, spec := range policies {
+		if err := gym.RegisterPolicySpec(spec); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(specs) == 0 {
+		specs = botFlags{"easy", "easy", "easy", "easy"}
+	}
+
+	seats := make([]sim.Seat, len(specs))
+	all := make([]*stats, len(specs))
+	for i, spec := range specs {
+		name, bot, err := ai.NewFromSpec(spec, *timeout)
+		if err != nil {
+			log.Fatalf("Bot %d: %v", i+1, err)
+		}
+		seats[i] = sim.Seat{Name: fmt.Sprintf("%d %s", i+1, name), Bot: bot}
+		all[i] = &stats{name: seats[i].Name}
+	}
+	defer func() {
+		for _, s := range seats {
+			if c, ok := s.Bot.(io.Closer); ok {
+				_ = c.Close()
+			}
+		}
+	}()
+
+	for m := range *matches {
+		// Rotate the seating so nobody always plays first
+		shift := m % len(seats)
+		rotated := append(append([]sim.Seat{}, seats[shift:]...), seats[:shift]...)
+		res, err := sim.Play(sim.Config{Deck: *deck, Ruleset: *ruleset, Seed: *seed + int64(m)}, rotated)
+		if err != nil {
+			log.Fatalf("Match %d: %v", m+1, err)
+		}
+		for i := range rotated {
+			s := all[(i+shift)%len(seats)]
+			if res.Positions[i] == 1 {
+				s.wins++
+			}
+			s.positions += res.Positions[i]
+			s.dishes += res.Dishes[i]
+			s.score += res.Scores[i]
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Seat\tWins\tAvg position\tDishes/match\tScore/match")
+	n := float64(*matches)
+	for _, s := range all {
+		fmt.Fprintf(w, "%s\t%d\t%.2f\t%.2f\t%.2f\n", s.name, s.wins, float64(s.positions)/n, float64(s.dishes)/n, float64(s.score)/n)
+	}
+	w.Flush()
+}
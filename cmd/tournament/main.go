@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/gym"
+	"github.com/thanhfphan/ebitengj2025/internal/tournament"
+)
+
+// botFlags collects a repeated flag such as -bot
+type botFlags []string
+
+func (f *botFlags) String() string     { return strings.Join(*f, ", ") }
+func (f *botFlags) Set(v string) error { *f = append(*f, v); return nil }
+
+func main() {
+	var specs botFlags
+	var cfg tournament.Config
+	flag.Var(&specs, "bot", `an entrant, the name of a registered bot such as "easy" or name=command for an external bot. Repeat for each entrant`)
+	flag.StringVar(&cfg.Format, "format", tournament.RoundRobin, "round-robin or swiss")
+	flag.IntVar(&cfg.TableSize, "table", 0, "players per match, up to four by default")
+	flag.IntVar(&cfg.Rounds, "rounds", 5, "rounds of a swiss tournament")
+	flag.IntVar(&cfg.Seeds, "seeds", 10, "deals each table plays, from every seat")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the first deal, the next ones count up")
+	flag.StringVar(&cfg.Deck, "deck", "default", "deck to play with")
+	flag.StringVar(&cfg.Ruleset, "ruleset", card.RulesetStandard, "ruleset to play with")
+	flag.Float64Var(&cfg.K, "k", tournament.DefaultK, "Elo K factor")
+	profiles := flag.String("profiles", ai.DefaultProfileDir, "directory of tuned heuristic bot profiles, seated by their name")
+	var policies botFlags
+	flag.Var(&policies, "policy", "a trained policy as name=path, which -bot can then seat by name. Repeatable")
+	timeout := flag.Duration("bot-timeout", ai.DefaultExternalTimeout, "how long an external bot may think about a turn")
+	out := flag.String("out", "", "also write the leaderboard to this JSON file")
+	flag.Parse()
+
+	if _, err := ai.LoadProfiles(*profiles); err != nil {
+		log.Fatal(err)
+	}
+	for _, spec := range policies {
+		if err := gym.RegisterPolicySpec(spec); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(specs) < 2 {
+		log.Fatalf("At least two -bot entrants are needed, registered bots are %s", strings.Join(ai.Registered(), ", "))
+	}
+
+	entrants := make([]tournament.Entrant, 0, len(specs))
+	seen := make(map[string]int)
+	for _, spec := range specs {
+		name, bot, err := ai.NewFromSpec(spec, *timeout)
+		if err != nil {
+			log.Fatalf("Bot %q: %v", spec, err)
+		}
+		// The same bot may enter twice, as a control
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s %d", name, seen[name])
+		}
+		entrants = append(entrants, tournament.Entrant{Name: name, Bot: bot})
+	}
+	defer func() {
+		for _, e := range entrants {
+			if c, ok := e.Bot.(io.Closer); ok {
+				_ = c.Close()
+			}
+		}
+	}()
+
+	board, err := tournament.Run(cfg, entrants, func(played int) {
+		if played%100 == 0 {
+			fmt.Fprintf(os.Stderr, "%d matches played\n", played)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Tournament error: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tBot\tRating\tMatches\tWins\tAvg position\tDishes/match")
+	for i, s := range board {
+		fmt.Fprintf(w, "%d\t%s\t%.0f\t%d\t%d\t%.2f\t%.2f\n", i+1, s.Name, s.Rating, s.Matches, s.Wins, s.AvgPos, float64(s.Dishes)/float64(max(s.Matches, 1)))
+	}
+	w.Flush()
+
+	if *out != "" {
+		data, err := json.MarshalIndent(board, "", "  ")
+		if err != nil {
+			log.Fatalf("Encode leaderboard: %v", err)
+		}
+		if err := os.WriteFile(*out, data, 0o644); err != nil {
+			log.Fatalf("Write leaderboard: %v", err)
+		}
+	}
+}
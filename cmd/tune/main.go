@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/tune"
+)
+
+// nameFlags collects the repeated -opponent flag
+type nameFlags []string
+
+func (f *nameFlags) String() string     { return strings.Join(*f, ", ") }
+func (f *nameFlags) Set(v string) error { *f = append(*f, v); return nil }
+
+func main() {
+	var cfg tune.Config
+	var opponents nameFlags
+	flag.Var(&opponents, "opponent", `a registered bot of the opponent pool, such as "easy" or a profile. Repeatable, easy and heuristic by default`)
+	flag.IntVar(&cfg.Population, "population", 16, "candidates per generation")
+	flag.IntVar(&cfg.Generations, "generations", 20, "generations to evolve")
+	flag.IntVar(&cfg.Matches, "matches", 40, "matches each candidate plays per generation")
+	flag.IntVar(&cfg.TableSize, "table", 4, "players per match")
+	flag.IntVar(&cfg.Elite, "elite", 2, "best candidates kept unchanged")
+	flag.Float64Var(&cfg.Sigma, "sigma", 0.5, "standard deviation of mutations")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the search and the deals")
+	flag.StringVar(&cfg.Deck, "deck", "default", "deck to play with")
+	flag.StringVar(&cfg.Ruleset, "ruleset", card.RulesetStandard, "ruleset to play with")
+	flag.IntVar(&cfg.Workers, "workers", 0, "matches played in parallel, one per CPU by default")
+	profiles := flag.String("profiles", ai.DefaultProfileDir, "directory of profiles, to start from or play against")
+	from := flag.String("from", "", "profile to start from, the default heuristic weights otherwise")
+	name := flag.String("name", "tuned", "name of the tuned profile")
+	out := flag.String("out", "", "profile file to write, <profiles>/<name>.json by default")
+	flag.Parse()
+	cfg.Opponents = opponents
+	if ai.Builtin(*name) {
+		log.Fatalf("Profile name %q is taken by a built-in bot", *name)
+	}
+
+	if _, err := ai.LoadProfiles(*profiles); err != nil {
+		log.Fatal(err)
+	}
+
+	start := ai.DefaultWeights
+	if *from != "" {
+		p, err := ai.LoadProfile(filepath.Join(*profiles, *from+".json"))
+		if err != nil {
+			log.Fatalf("Start profile: %v", err)
+		}
+		start = p.Weights
+	}
+
+	best, fitness, err := tune.Run(cfg, start, func(g tune.Generation) {
+		fmt.Fprintf(os.Stderr, "Generation %d: best %.3f, mean %.3f\n", g.Index+1, g.BestFitness, g.MeanFitness)
+	})
+	if err != nil {
+		log.Fatalf("Tuning error: %v", err)
+	}
+
+	path := *out
+	if path == "" {
+		path = filepath.Join(*profiles, *name+".json")
+	}
+	if err := ai.SaveProfile(path, &ai.Profile{Name: *name, Weights: best, Fitness: fitness}); err != nil {
+		log.Fatalf("Write profile: %v", err)
+	}
+	fmt.Printf("Beat %.1f%% of opponents, wrote %s\n", fitness*100, path)
+}
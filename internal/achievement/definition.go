@@ -0,0 +1,97 @@
+package achievement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/assets/configs"
+)
+
+// Definition describes an achievement. Decks may add their own in deck.json,
+// those only make progress in matches played with that deck.
+type Definition struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Hidden      bool   `json:"hidden,omitempty"` // Description is only shown once unlocked
+	Deck        string `json:"deck,omitempty"`   // Set for deck specific achievements
+	Goal        Goal   `json:"goal"`
+}
+
+// Goal is what the local player must do to unlock an achievement
+type Goal struct {
+	Type   string `json:"type"`
+	Target int    `json:"target,omitempty"` // Times to reach, 1 when unset
+	Count  int    `json:"count,omitempty"`  // Dishes needed in one play for GoalDishesInOnePlay
+	Recipe string `json:"recipe,omitempty"` // Only count this recipe for GoalDishesMade
+}
+
+// Goal types
+const (
+	GoalDishesMade        = "dishes_made"
+	GoalDistinctRecipes   = "distinct_recipes" // Target 0 means every recipe of the deck
+	GoalWins              = "wins"
+	GoalMatchesPlayed     = "matches_played"
+	GoalWinWithoutPassing = "win_without_passing"
+	GoalDishesInOnePlay   = "dishes_in_one_play"
+)
+
+// LoadDefinitions reads the achievements every deck shares
+func LoadDefinitions() ([]Definition, error) {
+	var file struct {
+		Achievements []Definition `json:"achievements"`
+	}
+	if err := json.Unmarshal(configs.AchievementsJSON, &file); err != nil {
+		return nil, fmt.Errorf("achievements.json: %w", err)
+	}
+	if err := Validate(file.Achievements); err != nil {
+		return nil, fmt.Errorf("achievements.json: %w", err)
+	}
+	return file.Achievements, nil
+}
+
+// Validate checks a list of definitions and reports every problem found
+func Validate(defs []Definition) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	ids := make(map[string]bool)
+	for _, d := range defs {
+		if d.ID == "" {
+			fail("achievement %q has no id", d.Name)
+			continue
+		}
+		if ids[d.ID] {
+			fail("duplicate achievement %s", d.ID)
+		}
+		ids[d.ID] = true
+		if d.Name == "" {
+			fail("achievement %s has no name", d.ID)
+		}
+		if d.Goal.Target < 0 {
+			fail("achievement %s: target cannot be negative", d.ID)
+		}
+
+		switch d.Goal.Type {
+		case GoalDishesMade, GoalWins, GoalMatchesPlayed, GoalWinWithoutPassing:
+		case GoalDistinctRecipes:
+			if d.Goal.Target == 0 && d.Deck == "" {
+				fail("achievement %s: every recipe needs a deck", d.ID)
+			}
+		case GoalDishesInOnePlay:
+			if d.Goal.Count < 2 {
+				fail("achievement %s: %s needs a count of at least 2", d.ID, d.Goal.Type)
+			}
+		default:
+			fail("achievement %s: unknown goal %q", d.ID, d.Goal.Type)
+		}
+		if d.Goal.Recipe != "" && d.Goal.Type != GoalDishesMade {
+			fail("achievement %s: recipe only works with %s", d.ID, GoalDishesMade)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,202 @@
+package achievement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the achievement progress
+const Key = "achievements.json"
+
+// Progress of one achievement
+type Progress struct {
+	Count      int       `json:"count"`
+	Recipes    []string  `json:"recipes,omitempty"` // Recipes made, for GoalDistinctRecipes
+	Unlocked   bool      `json:"unlocked"`
+	UnlockedAt time.Time `json:"unlocked_at,omitzero"`
+}
+
+// MatchState is what the tracker remembers about the match being played
+type MatchState struct {
+	PlayerID string `json:"player_id"`
+	Deck     string `json:"deck"`
+	Passed   bool   `json:"passed"`
+}
+
+// Tracker follows the engine events of the local player and unlocks
+// achievements
+type Tracker struct {
+	Definitions []Definition
+	Progress    map[string]*Progress // Definition ID -> progress
+	Match       MatchState
+
+	deckRecipes map[string][]string // Deck -> recipe IDs, for GoalDistinctRecipes
+}
+
+func NewTracker(defs []Definition) *Tracker {
+	return &Tracker{
+		Definitions: defs,
+		Progress:    make(map[string]*Progress),
+		deckRecipes: make(map[string][]string),
+	}
+}
+
+// AddDeck registers the achievements and recipes of a deck
+func (t *Tracker) AddDeck(deck string, defs []Definition, recipeIDs []string) {
+	if _, ok := t.deckRecipes[deck]; ok {
+		return
+	}
+	t.deckRecipes[deck] = recipeIDs
+	for _, d := range defs {
+		d.Deck = deck
+		t.Definitions = append(t.Definitions, d)
+	}
+}
+
+// StartMatch resets what is remembered about the current match
+func (t *Tracker) StartMatch(playerID, deck string) {
+	t.Match = MatchState{PlayerID: playerID, Deck: deck}
+}
+
+// ResumeMatch picks a saved match back up. What was remembered about it is
+// kept when it was saved with the match, older saves start it afresh.
+func (t *Tracker) ResumeMatch(playerID, deck string, saved *MatchState) {
+	t.StartMatch(playerID, deck)
+	if saved != nil && saved.PlayerID == playerID {
+		t.Match = *saved
+	}
+}
+
+func (t *Tracker) Get(id string) *Progress {
+	p, ok := t.Progress[id]
+	if !ok {
+		p = &Progress{}
+		t.Progress[id] = p
+	}
+	return p
+}
+
+// Target returns how many times the goal of an achievement must be reached
+func (t *Tracker) Target(d Definition) int {
+	if d.Goal.Type == GoalDistinctRecipes && d.Goal.Target == 0 {
+		return len(t.deckRecipes[d.Deck])
+	}
+	return max(d.Goal.Target, 1)
+}
+
+// Handle updates the progress with an engine event and returns the
+// achievements it unlocked
+func (t *Tracker) Handle(e entity.Event) []Definition {
+	if t.Match.PlayerID == "" {
+		return nil
+	}
+	if e.PlayerID != "" && e.PlayerID != t.Match.PlayerID {
+		return nil
+	}
+	if e.Type == entity.EventPass {
+		t.Match.Passed = true
+	}
+
+	var unlocked []Definition
+	for _, d := range t.Definitions {
+		if d.Deck != "" && d.Deck != t.Match.Deck {
+			continue
+		}
+		p := t.Get(d.ID)
+		if p.Unlocked || !t.advance(d, p, e) {
+			continue
+		}
+
+		if p.Count >= t.Target(d) {
+			p.Unlocked = true
+			p.UnlockedAt = time.Now()
+			unlocked = append(unlocked, d)
+		}
+	}
+	return unlocked
+}
+
+// advance reports whether the event made progress towards the goal
+func (t *Tracker) advance(d Definition, p *Progress, e entity.Event) bool {
+	g := d.Goal
+	switch {
+	case g.Type == GoalDishesMade && e.Type == entity.EventDishMade:
+		if g.Recipe != "" && g.Recipe != e.RecipeID {
+			return false
+		}
+	case g.Type == GoalDistinctRecipes && e.Type == entity.EventDishMade:
+		if slices.Contains(p.Recipes, e.RecipeID) {
+			return false
+		}
+		p.Recipes = append(p.Recipes, e.RecipeID)
+	case g.Type == GoalWins && e.Type == entity.EventFinish:
+		if e.Position != 1 {
+			return false
+		}
+	case g.Type == GoalWinWithoutPassing && e.Type == entity.EventFinish:
+		if e.Position != 1 || t.Match.Passed {
+			return false
+		}
+	case g.Type == GoalMatchesPlayed && e.Type == entity.EventMatchOver:
+	case g.Type == GoalDishesInOnePlay && e.Type == entity.EventPlayResolved:
+		if e.Count < g.Count {
+			return false
+		}
+	default:
+		return false
+	}
+
+	p.Count++
+	return true
+}
+
+// Unlocked returns how many achievements are unlocked
+func (t *Tracker) Unlocked() int {
+	n := 0
+	for _, d := range t.Definitions {
+		if t.Get(d.ID).Unlocked {
+			n++
+		}
+	}
+	return n
+}
+
+type savedTracker struct {
+	Progress map[string]*Progress `json:"progress"`
+	Match    MatchState           `json:"match"`
+}
+
+// Load reads the saved progress, a missing save leaves the tracker empty
+func (t *Tracker) Load(store storage.Store) error {
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved savedTracker
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("read achievements: %w", err)
+	}
+	if saved.Progress != nil {
+		t.Progress = saved.Progress
+	}
+	t.Match = saved.Match
+	return nil
+}
+
+func (t *Tracker) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(savedTracker{Progress: t.Progress, Match: t.Match}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
@@ -0,0 +1,48 @@
+package achievement
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+func TestResumeMatch(t *testing.T) {
+	defs := []Definition{
+		{ID: "win", Name: "Win", Goal: Goal{Type: GoalWins}},
+		{ID: "clean_win", Name: "Clean Win", Goal: Goal{Type: GoalWinWithoutPassing}},
+	}
+
+	tests := []struct {
+		name  string
+		last  MatchState  // What the tracker held before resuming
+		saved *MatchState // Saved with the match
+		want  []string    // Unlocked by winning the resumed match
+	}{
+		{name: "no match before", saved: &MatchState{PlayerID: "me", Deck: "default"}, want: []string{"win", "clean_win"}},
+		{name: "another match before", last: MatchState{PlayerID: "daily"}, saved: &MatchState{PlayerID: "me", Deck: "default"}, want: []string{"win", "clean_win"}},
+		{name: "passed before saving", last: MatchState{PlayerID: "daily"}, saved: &MatchState{PlayerID: "me", Deck: "default", Passed: true}, want: []string{"win"}},
+		{name: "older save", last: MatchState{PlayerID: "daily", Passed: true}, want: []string{"win", "clean_win"}},
+		{name: "save of another player", saved: &MatchState{PlayerID: "other", Passed: true}, want: []string{"win", "clean_win"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTracker(defs)
+			tr.Match = tt.last
+
+			tr.ResumeMatch("me", "default", tt.saved)
+			var got []string
+			for _, d := range tr.Handle(entity.Event{Type: entity.EventFinish, PlayerID: "me", Position: 1}) {
+				got = append(got, d.ID)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unlocked %v, want %v", got, tt.want)
+			}
+			if tr.Match.PlayerID != "me" || tr.Match.Deck != "default" {
+				t.Errorf("match state %+v, want the resumed match", tr.Match)
+			}
+		})
+	}
+}
@@ -1,23 +1,81 @@
 package ai
 
 import (
+	"encoding/json"
+	"slices"
+	"strings"
+
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
 )
 
 type Bot interface {
 	PlayTurn(g GameLike, botID string) error
 }
 
+// Poller is implemented by bots that think outside the process, such as an
+// external program. PollTurn never blocks: it starts the turn or checks on
+// it, and reports whether the turn was played. The game polls such bots on
+// every frame instead of calling PlayTurn, which waits for the answer.
+type Poller interface {
+	PollTurn(g GameLike, botID string) (bool, error)
+}
+
+// Memorizer is implemented by bots that keep state between turns, so that
+// state can be saved together with the match
+type Memorizer interface {
+	SaveMemory() (json.RawMessage, error)
+	LoadMemory(data json.RawMessage) error
+}
+
+// Seeder is implemented by bots whose choices come from a random generator,
+// seeding it makes them play a deal the same way every time
+type Seeder interface {
+	Seed(seed int64)
+}
+
 type GameLike interface {
 	GetPlayerState(id string) *PlayerState
 	PlayCard(playerID string, cardID string) error
+	PlayCardWithTarget(playerID string, cardID string, target entity.Target) error
+	// LegalTargets returns the targets a card can be played on, an empty
+	// result means the card cannot be played right now
+	LegalTargets(playerID string, cardID string) []entity.Target
 	Pass(playerID string)
 }
 
+// EngineSource is implemented by games that can hand out their rules engine,
+// for bots that need to see more of the match than their own hand
+type EngineSource interface {
+	RulesEngine() *rules.Engine
+}
+
 type PlayerState struct {
 	ID       string
 	Hand     map[string]*entity.Card
+	Order    []string // IDs of the hand in the order the player holds them
 	IsBot    bool
 	Passed   bool
 	Finished bool
 }
+
+// Cards returns the hand in the order the player holds it, so bots seeded
+// alike choose alike. Without an order the cards are sorted by name.
+func (p *PlayerState) Cards() []*entity.Card {
+	cards := make([]*entity.Card, 0, len(p.Hand))
+	for _, id := range p.Order {
+		if c, ok := p.Hand[id]; ok {
+			cards = append(cards, c)
+		}
+	}
+	if len(cards) == len(p.Hand) {
+		return cards
+	}
+
+	cards = cards[:0]
+	for _, c := range p.Hand {
+		cards = append(cards, c)
+	}
+	slices.SortStableFunc(cards, func(a, b *entity.Card) int { return strings.Compare(a.Name, b.Name) })
+	return cards
+}
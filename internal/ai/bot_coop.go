@@ -0,0 +1,242 @@
+package ai
+
+import (
+	"encoding/json"
+	"math"
+	mrand "math/rand"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+var (
+	_ Bot       = (*CoopBot)(nil)
+	_ Memorizer = (*CoopBot)(nil)
+	_ Seeder    = (*CoopBot)(nil)
+)
+
+// CoopBot plays for the table in a co-op match. It cooks what it can, lays
+// the recipes the table is likely to fill and holds the cards nobody needs
+// yet. When it has nothing useful to play it points out the ingredient most
+// recipes ask for, and it counts the ingredients others pointed out. It
+// only looks at its own hand, the table and the signals. On games that do
+// not hand out the rules engine it plays a random card.
+type CoopBot struct {
+	rand   *mrand.Rand
+	source *rng.Source
+}
+
+func NewCoopBot() *CoopBot {
+	rand, source := rng.New(rng.RandomSeed())
+
+	return &CoopBot{
+		rand:   rand,
+		source: source,
+	}
+}
+
+// SaveMemory implements Memorizer. The only state is the random generator
+// breaking ties.
+func (b *CoopBot) SaveMemory() (json.RawMessage, error) {
+	return json.Marshal(b.source.State())
+}
+
+// LoadMemory implements Memorizer.
+func (b *CoopBot) LoadMemory(data json.RawMessage) error {
+	var st rng.State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	b.source.Restore(st)
+	return nil
+}
+
+// Seed implements Seeder.
+func (b *CoopBot) Seed(seed int64) {
+	b.source.Seed(seed)
+}
+
+func (b *CoopBot) PlayTurn(g GameLike, botID string) error {
+	player := g.GetPlayerState(botID)
+	if player == nil || player.Finished || len(player.Hand) == 0 {
+		return nil
+	}
+
+	var e *rules.Engine
+	if src, ok := g.(EngineSource); ok {
+		e = src.RulesEngine()
+	}
+
+	var best []move
+	bestScore := math.Inf(-1)
+	for _, c := range player.Cards() {
+		for _, t := range g.LegalTargets(player.ID, c.ID) {
+			score := 0.0
+			if e != nil {
+				score = b.score(e, botID, c)
+			}
+			switch {
+			case score > bestScore:
+				best, bestScore = []move{{card: c, target: t}}, score
+			case score == bestScore:
+				best = append(best, move{card: c, target: t})
+			}
+		}
+	}
+	if len(best) == 0 {
+		g.Pass(player.ID)
+		return nil
+	}
+
+	// Nothing worth playing: tell the table what it holds, or wait for the
+	// others while they can still play
+	if e != nil && bestScore <= 0 {
+		if c := b.signal(e, botID, player); c != nil {
+			return e.Signal(botID, c.ID)
+		}
+		if othersCanPlay(e, botID) {
+			g.Pass(player.ID)
+			return nil
+		}
+	}
+
+	m := best[b.rand.Intn(len(best))]
+	return g.PlayCardWithTarget(player.ID, m.card.ID, m.target)
+}
+
+// score values a card for the table, a card worth nothing now scores 0 or
+// less. A dish is worth less when it takes ingredients another recipe needs,
+// recipes are laid early so the ingredients that follow have somewhere to go.
+func (b *CoopBot) score(e *rules.Engine, botID string, c *entity.Card) float64 {
+	if c.Type == entity.CardTypeAction {
+		return -1
+	}
+	if r := e.Cards.CompletingRecipe(c); r != nil {
+		return 10 - 8*float64(taken(e, botID, c, r))
+	}
+	if c.Type == entity.CardTypeRecipe {
+		return 3*coverage(e, botID, c) + 0.5
+	}
+	if c.Type == entity.CardTypeWildcard {
+		return -2 // Kept for the dish it completes
+	}
+	if p := progress(e, c); p > 0 {
+		return 1 + 4*p
+	}
+	return -1
+}
+
+// coverage returns the share of a recipe's requirements that are on the
+// table, in the bot's hand or pointed out by the others
+func coverage(e *rules.Engine, botID string, r *entity.Card) float64 {
+	var known []*entity.Card
+	for _, tc := range e.Cards.TableStack.GetAllCardsInOrder() {
+		if tc.IsIngredient() {
+			known = append(known, tc)
+		}
+	}
+	for _, p := range e.Players {
+		if p.ID == botID {
+			for _, c := range p.Hand {
+				if c.IsIngredient() {
+					known = append(known, c)
+				}
+			}
+		} else if c := e.SignalledCard(p.ID); c != nil {
+			known = append(known, c)
+		}
+	}
+
+	filled := 0
+	for _, idx := range card.MatchRequirements(r.Requirements, known) {
+		if idx >= 0 {
+			filled++
+		}
+	}
+	if len(r.Requirements) == 0 {
+		return 0
+	}
+	return float64(filled) / float64(len(r.Requirements))
+}
+
+// signal picks the ingredient to point out, the one most recipes not cooked
+// yet ask for. It returns nil when the table has no signals left or the
+// bot's last signal still stands.
+func (b *CoopBot) signal(e *rules.Engine, botID string, player *PlayerState) *entity.Card {
+	if e.Coop == nil || e.Coop.Signals == 0 || e.SignalledCard(botID) != nil {
+		return nil
+	}
+
+	var best *entity.Card
+	bestCount := 0
+	for _, c := range player.Cards() {
+		if c.Type != entity.CardTypeIngredient {
+			continue
+		}
+		n := 0
+		for _, r := range e.Cards.Deck {
+			if r.Type == entity.CardTypeRecipe && !slices.Contains(e.Cards.DiscardPile, r) && requires(r, c) {
+				n++
+			}
+		}
+		if n > bestCount {
+			best, bestCount = c, n
+		}
+	}
+	return best
+}
+
+// requires reports whether a recipe has a requirement the ingredient fills
+func requires(r, c *entity.Card) bool {
+	for _, idx := range card.MatchRequirements(r.Requirements, []*entity.Card{c}) {
+		if idx >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// taken counts the ingredients a dish of recipe r, completed by playing c,
+// would use for a tag while another recipe in sight asks for exactly them
+func taken(e *rules.Engine, botID string, c, r *entity.Card) int {
+	ingredients := []*entity.Card{}
+	if c.IsIngredient() {
+		ingredients = append(ingredients, c)
+	}
+	var others []*entity.Card
+	for _, tc := range e.Cards.TableStack.GetAllCardsInReverseOrder() {
+		switch {
+		case tc.IsIngredient():
+			ingredients = append(ingredients, tc)
+		case tc.Type == entity.CardTypeRecipe && tc.ID != r.ID:
+			others = append(others, tc)
+		}
+	}
+	if p := e.GetPlayer(botID); p != nil {
+		for _, hc := range p.Hand {
+			if hc.Type == entity.CardTypeRecipe && hc.ID != r.ID {
+				others = append(others, hc)
+			}
+		}
+	}
+
+	n := 0
+	for i, idx := range card.MatchRequirements(r.Requirements, ingredients) {
+		if idx < 0 || !r.Requirements[i].IsTag() {
+			continue
+		}
+		ing := ingredients[idx]
+		for _, o := range others {
+			if slices.ContainsFunc(o.Requirements, func(req entity.Requirement) bool {
+				return req.IngredientID != "" && req.IngredientID == ing.IngredientID
+			}) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
@@ -1,29 +1,58 @@
 package ai
 
 import (
-	crand "crypto/rand"
-	"encoding/binary"
-	"math/rand"
+	"encoding/json"
 	mrand "math/rand"
 
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
 )
 
-var _ Bot = (*EasyBot)(nil)
+var (
+	_ Bot       = (*EasyBot)(nil)
+	_ Memorizer = (*EasyBot)(nil)
+	_ Seeder    = (*EasyBot)(nil)
+)
 
 type EasyBot struct {
-	rand *mrand.Rand
+	rand   *mrand.Rand
+	source *rng.Source
 }
 
 func NewEasyBot() *EasyBot {
-	var seed int64
-	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	rand, source := rng.New(rng.RandomSeed())
 
 	return &EasyBot{
-		rand: mrand.New(mrand.NewSource(seed)),
+		rand:   rand,
+		source: source,
 	}
 }
 
+// SaveMemory implements Memorizer. The only state is the random generator.
+func (b *EasyBot) SaveMemory() (json.RawMessage, error) {
+	return json.Marshal(b.source.State())
+}
+
+// LoadMemory implements Memorizer.
+func (b *EasyBot) LoadMemory(data json.RawMessage) error {
+	var st rng.State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	b.source.Restore(st)
+	return nil
+}
+
+// Seed implements Seeder.
+func (b *EasyBot) Seed(seed int64) {
+	b.source.Seed(seed)
+}
+
+type move struct {
+	card   *entity.Card
+	target entity.Target
+}
+
 func (b *EasyBot) PlayTurn(g GameLike, botID string) error {
 	player := g.GetPlayerState(botID)
 	if player == nil || player.Finished {
@@ -34,16 +63,18 @@ func (b *EasyBot) PlayTurn(g GameLike, botID string) error {
 		return nil
 	}
 
-	idx := rand.Intn(len(player.Hand)) // pick random card
-	var card *entity.Card
-	i := 0
-	for _, c := range player.Hand {
-		if i == idx {
-			card = c
-			break
+	var moves []move
+	for _, c := range player.Cards() {
+		for _, t := range g.LegalTargets(player.ID, c.ID) {
+			moves = append(moves, move{card: c, target: t})
 		}
-		i++
 	}
 
-	return g.PlayCard(player.ID, card.ID)
+	if len(moves) == 0 {
+		g.Pass(player.ID)
+		return nil
+	}
+
+	m := moves[b.rand.Intn(len(moves))] // pick random move
+	return g.PlayCardWithTarget(player.ID, m.card.ID, m.target)
 }
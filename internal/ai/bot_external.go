@@ -0,0 +1,409 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+var (
+	_ Bot       = (*ExternalBot)(nil)
+	_ Poller    = (*ExternalBot)(nil)
+	_ io.Closer = (*ExternalBot)(nil)
+)
+
+// ExternalProtocolVersion is sent with every turn, bots can refuse versions
+// they do not know
+const ExternalProtocolVersion = 1
+
+// DefaultExternalTimeout is how long an external bot may think about a turn
+const DefaultExternalTimeout = 2 * time.Second
+
+var (
+	errExternalTimeout = errors.New("no answer in time")
+	errNoAnswerYet     = errors.New("no answer yet")
+)
+
+// ExternalBot lets a separate process play a seat, so bots can be written in
+// any language. The process gets one JSON object per line on stdin for each
+// of its turns and answers with one JSON line on stdout:
+//
+//	-> {"type":"turn","version":1,"turn":3,"seat":"...","hand":[...],"actions":[{"pass":true},{"card_id":"..."}],
+//	    "players":[...],"table":[...],"finished_order":[...],"dishes_made":2}
+//	<- {"turn":3,"action":1}
+//
+// Besides its hand the process sees what any seat at the table sees: the
+// players with their hand counts and scores, and the table with who put each
+// card there. The action is an index into the legal actions. A turn without an answer
+// in time is played by an EasyBot, a process that exits, writes garbage or
+// picks an action that does not exist is stopped and the EasyBot plays the
+// rest of the match. Stderr is passed through for debugging.
+type ExternalBot struct {
+	Command []string
+	Timeout time.Duration
+
+	fallback *EasyBot
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	lines    chan []byte // Lines read from stdout, closed once it ends
+	turn     int
+	pending  *externalTurn // Turn sent and not answered yet
+	err      error         // Why the process was given up, the fallback plays from then on
+}
+
+// externalTurn is a turn sent to the process
+type externalTurn struct {
+	turn     int
+	actions  []ExternalAction
+	deadline time.Time
+}
+
+// externalAnswer is the action the process chose for a turn, or why there
+// is none
+type externalAnswer struct {
+	action ExternalAction
+	err    error
+}
+
+// ExternalRequest is sent to the process on each of its turns
+type ExternalRequest struct {
+	Type    string           `json:"type"`
+	Version int              `json:"version"`
+	Turn    int              `json:"turn"` // Echoed in the answer, answers to older turns are ignored
+	Seat    string           `json:"seat"`
+	Hand    []ExternalCard   `json:"hand"`
+	Actions []ExternalAction `json:"actions"`
+
+	// The public state of the match, left out when the game does not share
+	// its rules engine
+	Players       []ExternalPlayer    `json:"players,omitempty"` // In turn order, the bot included
+	Table         []ExternalTableCard `json:"table,omitempty"`   // Oldest to newest
+	FinishedOrder []string            `json:"finished_order,omitempty"`
+	DishesMade    int                 `json:"dishes_made,omitempty"`
+}
+
+// ExternalPlayer is a seat as everyone at the table sees it
+type ExternalPlayer struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Cards    int    `json:"cards"` // Cards in hand
+	Passed   bool   `json:"passed"`
+	Finished bool   `json:"finished"`
+	Score    int    `json:"score"`
+}
+
+// ExternalTableCard is a card on the table and the player who put it there
+type ExternalTableCard struct {
+	Card     ExternalCard `json:"card"`
+	PlayerID string       `json:"player_id"`
+}
+
+type ExternalCard struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"` // ingredient, recipe, wildcard or action
+	IngredientID string   `json:"ingredient_id,omitempty"`
+	RecipeID     string   `json:"recipe_id,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// ExternalAction is a legal move, a pass or a card with its target
+type ExternalAction struct {
+	Pass         bool   `json:"pass,omitempty"`
+	CardID       string `json:"card_id,omitempty"`
+	TargetPlayer string `json:"target_player,omitempty"`
+	TargetCard   string `json:"target_card,omitempty"`
+}
+
+// ExternalReply is the answer of the process
+type ExternalReply struct {
+	Turn   int `json:"turn"`
+	Action int `json:"action"`
+}
+
+// NewExternalBot returns a bot played by the given command, the process is
+// started on the first turn
+func NewExternalBot(command []string, timeout time.Duration) *ExternalBot {
+	if timeout <= 0 {
+		timeout = DefaultExternalTimeout
+	}
+	return &ExternalBot{
+		Command:  command,
+		Timeout:  timeout,
+		fallback: NewEasyBot(),
+	}
+}
+
+func (b *ExternalBot) PlayTurn(g GameLike, botID string) error {
+	player := g.GetPlayerState(botID)
+	if player == nil || player.Finished || len(player.Hand) == 0 {
+		return nil
+	}
+	if b.err != nil {
+		return b.fallback.PlayTurn(g, botID)
+	}
+
+	if b.pending == nil {
+		if err := b.send(g, player); err != nil {
+			b.fail(err)
+			return b.fallback.PlayTurn(g, botID)
+		}
+	}
+	return b.play(g, botID, b.receive(true))
+}
+
+// PollTurn implements Poller. The first call sends the turn to the process,
+// the next ones play it once the answer is in or the time is up.
+func (b *ExternalBot) PollTurn(g GameLike, botID string) (bool, error) {
+	player := g.GetPlayerState(botID)
+	if player == nil || player.Finished || len(player.Hand) == 0 {
+		return true, nil
+	}
+	if b.err != nil {
+		return true, b.fallback.PlayTurn(g, botID)
+	}
+
+	if b.pending == nil {
+		if err := b.send(g, player); err != nil {
+			b.fail(err)
+			return true, b.fallback.PlayTurn(g, botID)
+		}
+		return false, nil
+	}
+
+	answer := b.receive(false)
+	if errors.Is(answer.err, errNoAnswerYet) {
+		return false, nil
+	}
+	return true, b.play(g, botID, answer)
+}
+
+// play makes the move the process chose, the fallback plays when there is
+// no valid answer
+func (b *ExternalBot) play(g GameLike, botID string, answer externalAnswer) error {
+	if answer.err != nil {
+		if errors.Is(answer.err, errExternalTimeout) {
+			fmt.Println("External bot", b.Command, "did not answer in time, playing for it this turn")
+		} else {
+			b.fail(answer.err)
+		}
+		return b.fallback.PlayTurn(g, botID)
+	}
+
+	a := answer.action
+	if a.Pass {
+		g.Pass(botID)
+		return nil
+	}
+	return g.PlayCardWithTarget(botID, a.CardID, entity.Target{PlayerID: a.TargetPlayer, CardID: a.TargetCard})
+}
+
+func toExternalCard(c *entity.Card) ExternalCard {
+	kinds := map[entity.CartType]string{
+		entity.CardTypeIngredient: "ingredient",
+		entity.CardTypeRecipe:     "recipe",
+		entity.CardTypeWildcard:   "wildcard",
+		entity.CardTypeAction:     "action",
+	}
+	return ExternalCard{
+		ID:           c.ID,
+		Name:         c.Name,
+		Kind:         kinds[c.Type],
+		IngredientID: c.IngredientID,
+		RecipeID:     c.RecipeID,
+		Tags:         c.Tags,
+	}
+}
+
+// send writes the turn of the player to the process, starting it first if
+// needed. The answer is awaited by receive.
+func (b *ExternalBot) send(g GameLike, player *PlayerState) error {
+	if b.cmd == nil {
+		if err := b.start(); err != nil {
+			return err
+		}
+	}
+
+	b.turn++
+	req := newExternalRequest(g, player, b.turn)
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err := b.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write turn: %w", err)
+	}
+	b.pending = &externalTurn{
+		turn:     b.turn,
+		actions:  req.Actions,
+		deadline: time.Now().Add(b.Timeout),
+	}
+	return nil
+}
+
+// newExternalRequest describes a turn of the player, the legal actions
+// start with a pass
+func newExternalRequest(g GameLike, player *PlayerState, turn int) ExternalRequest {
+	ids := make([]string, 0, len(player.Hand))
+	for id := range player.Hand {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	req := ExternalRequest{
+		Type:    "turn",
+		Version: ExternalProtocolVersion,
+		Turn:    turn,
+		Seat:    player.ID,
+		Hand:    make([]ExternalCard, 0, len(ids)),
+		Actions: []ExternalAction{{Pass: true}},
+	}
+	for _, id := range ids {
+		req.Hand = append(req.Hand, toExternalCard(player.Hand[id]))
+		for _, t := range g.LegalTargets(player.ID, id) {
+			req.Actions = append(req.Actions, ExternalAction{CardID: id, TargetPlayer: t.PlayerID, TargetCard: t.CardID})
+		}
+	}
+
+	src, ok := g.(EngineSource)
+	if !ok {
+		return req
+	}
+	e := src.RulesEngine()
+	for _, p := range e.Players {
+		ep := ExternalPlayer{ID: p.ID, Name: p.Name, Cards: len(p.Hand), Score: e.Score(p.ID)}
+		if t := e.Turns.GetPlayerByID(p.ID); t != nil {
+			ep.Passed, ep.Finished = t.Passed, t.Finished
+		}
+		req.Players = append(req.Players, ep)
+	}
+	for _, entry := range e.Cards.TableStack.GetEntriesInOrder() {
+		req.Table = append(req.Table, ExternalTableCard{Card: toExternalCard(entry.Card), PlayerID: entry.PlayerID})
+	}
+	req.FinishedOrder = slices.Clone(e.Turns.FinishedOrder())
+	req.DishesMade = e.DishesMade
+	return req
+}
+
+// receive reads the answer to the pending turn. Without blocking it returns
+// errNoAnswerYet while the process still has time. Any other result ends
+// the turn.
+func (b *ExternalBot) receive(block bool) externalAnswer {
+	p := b.pending
+	var timeout <-chan time.Time
+	if block {
+		timer := time.NewTimer(time.Until(p.deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		var line []byte
+		var ok bool
+		if block {
+			select {
+			case line, ok = <-b.lines:
+			case <-timeout:
+				b.pending = nil
+				return externalAnswer{err: errExternalTimeout}
+			}
+		} else {
+			select {
+			case line, ok = <-b.lines:
+			default:
+				if time.Now().Before(p.deadline) {
+					return externalAnswer{err: errNoAnswerYet}
+				}
+				b.pending = nil
+				return externalAnswer{err: errExternalTimeout}
+			}
+		}
+
+		if !ok {
+			b.pending = nil
+			return externalAnswer{err: errors.New("the process exited")}
+		}
+		var reply ExternalReply
+		if err := json.Unmarshal(line, &reply); err != nil {
+			b.pending = nil
+			return externalAnswer{err: fmt.Errorf("invalid answer %q: %w", line, err)}
+		}
+		if reply.Turn < p.turn {
+			continue // Late answer to a turn we already played
+		}
+		b.pending = nil
+		if reply.Action < 0 || reply.Action >= len(p.actions) {
+			return externalAnswer{err: fmt.Errorf("action %d out of range, %d legal actions", reply.Action, len(p.actions))}
+		}
+		return externalAnswer{action: p.actions[reply.Action]}
+	}
+}
+
+func (b *ExternalBot) start() error {
+	if len(b.Command) == 0 {
+		return errors.New("no command")
+	}
+
+	cmd := exec.Command(b.Command[0], b.Command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	lines := make(chan []byte, 16)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(stdout)
+		sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for sc.Scan() {
+			lines <- slices.Clone(sc.Bytes())
+		}
+	}()
+
+	b.cmd = cmd
+	b.stdin = stdin
+	b.lines = lines
+	return nil
+}
+
+// fail stops the process for good, the fallback plays its turns from now on
+func (b *ExternalBot) fail(err error) {
+	fmt.Println("External bot", b.Command, "failed, an easy bot takes over:", err)
+	b.err = err
+	_ = b.Close()
+}
+
+// Close stops the process. Its output is read to the end before waiting
+// for it, as os/exec requires.
+func (b *ExternalBot) Close() error {
+	if b.cmd == nil {
+		return nil
+	}
+	cmd := b.cmd
+	b.cmd = nil
+	b.pending = nil
+	_ = b.stdin.Close()
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+	for range b.lines {
+	}
+	_ = cmd.Wait()
+	return nil
+}
@@ -0,0 +1,216 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// helperEnv makes the test binary play an external bot instead of running
+// the tests: "pass" passes every turn, "silent" never answers and "garbage"
+// answers with something that is not JSON
+const helperEnv = "EXTERNAL_BOT_HELPER"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(helperEnv); mode != "" {
+		runHelperBot(mode)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func runHelperBot(mode string) {
+	sc := bufio.NewScanner(os.Stdin)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var req ExternalRequest
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			os.Exit(1)
+		}
+		switch mode {
+		case "pass":
+			fmt.Printf(`{"turn":%d,"action":0}`+"\n", req.Turn)
+		case "garbage":
+			fmt.Println("not json")
+		}
+	}
+}
+
+func newHelperBot(t *testing.T, mode string, timeout time.Duration) *ExternalBot {
+	t.Helper()
+	t.Setenv(helperEnv, mode)
+	b := NewExternalBot([]string{os.Args[0]}, timeout)
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+func newTestMatch(t *testing.T) (*EngineGame, string) {
+	t.Helper()
+	cards := card.NewManager()
+	cards.SetRandState(rng.State{Seed: 1})
+	if err := cards.LoadDeck("default"); err != nil {
+		t.Fatal(err)
+	}
+	e := rules.NewEngine(cards, rules.NewTurnManager())
+	e.Start([]*entity.Player{
+		entity.NewPlayer("A", entity.TypeBot),
+		entity.NewPlayer("B", entity.TypeBot),
+	})
+	return &EngineGame{Engine: e}, e.Turns.Current().ID
+}
+
+func TestExternalBotPollTurn(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		timeout time.Duration
+		passed  bool // The pass of the process was played, not a move of the fallback
+		failed  bool // The process was given up
+	}{
+		{name: "answer", mode: "pass", timeout: 5 * time.Second, passed: true},
+		{name: "no answer in time", mode: "silent", timeout: 100 * time.Millisecond},
+		{name: "invalid answer", mode: "garbage", timeout: 5 * time.Second, failed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, seat := newTestMatch(t)
+			b := newHelperBot(t, tt.mode, tt.timeout)
+			var passes int
+			g.Engine.OnEvent = func(ev entity.Event) {
+				if ev.Type == entity.EventPass && ev.PlayerID == seat {
+					passes++
+				}
+			}
+
+			done, err := b.PollTurn(g, seat)
+			if err != nil || done {
+				t.Fatalf("first poll = %v, %v, want the turn sent and not played", done, err)
+			}
+
+			start := time.Now()
+			for !done {
+				if time.Since(start) > 5*time.Second {
+					t.Fatal("the turn was never played")
+				}
+				if done, err = b.PollTurn(g, seat); err != nil {
+					t.Fatalf("poll: %v", err)
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			if tt.passed && passes != 1 {
+				t.Errorf("passes = %d, want the pass of the process", passes)
+			}
+			if got := b.err != nil; got != tt.failed {
+				t.Errorf("failed = %v (%v), want %v", got, b.err, tt.failed)
+			}
+		})
+	}
+}
+
+func TestExternalBotPlayTurnWaitsForAnswer(t *testing.T) {
+	g, seat := newTestMatch(t)
+	b := newHelperBot(t, "pass", 5*time.Second)
+
+	if err := b.PlayTurn(g, seat); err != nil {
+		t.Fatalf("PlayTurn: %v", err)
+	}
+	if current := g.Engine.Turns.Current().ID; current == seat {
+		t.Error("the bot still has the turn, want its pass played")
+	}
+	if b.err != nil {
+		t.Errorf("process given up: %v", b.err)
+	}
+}
+
+func TestExternalBotClose(t *testing.T) {
+	g, seat := newTestMatch(t)
+	b := newHelperBot(t, "silent", 5*time.Second)
+	if _, err := b.PollTurn(g, seat); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = b.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	if b.cmd != nil || b.pending != nil {
+		t.Error("Close left the process or its pending turn behind")
+	}
+}
+
+// gameOnly hides the rules engine of a game
+type gameOnly struct{ GameLike }
+
+func TestExternalRequestShowsTheTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine bool // Whether the game shares its rules engine
+	}{
+		{name: "with the engine", engine: true},
+		{name: "without the engine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, seat := newTestMatch(t)
+			if err := NewEasyBot().PlayTurn(g, seat); err != nil {
+				t.Fatal(err)
+			}
+			e := g.Engine
+			if e.Cards.TableStack.GetEntriesInOrder() == nil {
+				t.Fatal("the first player put nothing on the table")
+			}
+
+			var game GameLike = g
+			if !tt.engine {
+				game = gameOnly{g}
+			}
+			next := e.Turns.Current().ID
+			req := newExternalRequest(game, game.GetPlayerState(next), 1)
+
+			if len(req.Hand) != len(e.GetPlayer(next).Hand) || req.Actions[0] != (ExternalAction{Pass: true}) {
+				t.Errorf("hand of %d cards with actions %v", len(req.Hand), req.Actions)
+			}
+			if !tt.engine {
+				if req.Players != nil || req.Table != nil {
+					t.Errorf("players %v and table %v without an engine", req.Players, req.Table)
+				}
+				return
+			}
+
+			if len(req.Players) != len(e.Players) {
+				t.Fatalf("%d players, want %d", len(req.Players), len(e.Players))
+			}
+			for i, p := range req.Players {
+				want := e.Players[i]
+				if p.ID != want.ID || p.Cards != len(want.Hand) || p.Score != e.Score(want.ID) {
+					t.Errorf("player %d = %+v, want %s with %d cards and %d points", i, p, want.ID, len(want.Hand), e.Score(want.ID))
+				}
+			}
+			entries := e.Cards.TableStack.GetEntriesInOrder()
+			if len(req.Table) != len(entries) {
+				t.Fatalf("%d cards on the table, want %d", len(req.Table), len(entries))
+			}
+			for i, tc := range req.Table {
+				if tc.Card.ID != entries[i].Card.ID || tc.PlayerID != entries[i].PlayerID {
+					t.Errorf("table card %d = %s of %s, want %s of %s", i, tc.Card.ID, tc.PlayerID, entries[i].Card.ID, entries[i].PlayerID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package ai
+
+import (
+	"encoding/json"
+	"math"
+	mrand "math/rand"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+var (
+	_ Bot       = (*HeuristicBot)(nil)
+	_ Memorizer = (*HeuristicBot)(nil)
+	_ Seeder    = (*HeuristicBot)(nil)
+)
+
+// Weights of a HeuristicBot. Each move is scored as the sum of its features
+// times their weight, the bot plays the best scored move or passes if
+// passing scores higher and someone else can still play.
+type Weights struct {
+	Dish       float64 `json:"dish"`       // The card completes a dish
+	Progress   float64 `json:"progress"`   // Share of recipe requirements on the table the card fills
+	Recipe     float64 `json:"recipe"`     // The card is a recipe
+	Ingredient float64 `json:"ingredient"` // The card is a plain ingredient
+	Wildcard   float64 `json:"wildcard"`   // The card is a wildcard
+	Action     float64 `json:"action"`     // The card is an action card
+	Leader     float64 `json:"leader"`     // The targeted opponent has the fewest cards in hand
+	OwnCard    float64 `json:"own_card"`   // The targeted table card is the bot's own
+	Pass       float64 `json:"pass"`       // Score of passing
+}
+
+// DefaultWeights are set by hand, tuned profiles should beat them
+var DefaultWeights = Weights{
+	Dish:       3,
+	Progress:   1,
+	Recipe:     0.5,
+	Ingredient: 0,
+	Wildcard:   -0.5,
+	Action:     0.2,
+	Leader:     1,
+	OwnCard:    -1,
+	Pass:       -1,
+}
+
+// Fields returns pointers to the weights in a fixed order, for tools that
+// treat them as a vector
+func (w *Weights) Fields() []*float64 {
+	return []*float64{&w.Dish, &w.Progress, &w.Recipe, &w.Ingredient, &w.Wildcard, &w.Action, &w.Leader, &w.OwnCard, &w.Pass}
+}
+
+// HeuristicBot scores the legal moves with weighted features. It sees the
+// table through the rules engine, on games that do not hand it out only the
+// features of its hand count.
+type HeuristicBot struct {
+	Weights Weights
+
+	rand   *mrand.Rand
+	source *rng.Source
+}
+
+func NewHeuristicBot(w Weights) *HeuristicBot {
+	rand, source := rng.New(rng.RandomSeed())
+
+	return &HeuristicBot{
+		Weights: w,
+		rand:    rand,
+		source:  source,
+	}
+}
+
+// SaveMemory implements Memorizer. The only state is the random generator
+// breaking ties.
+func (b *HeuristicBot) SaveMemory() (json.RawMessage, error) {
+	return json.Marshal(b.source.State())
+}
+
+// LoadMemory implements Memorizer.
+func (b *HeuristicBot) LoadMemory(data json.RawMessage) error {
+	var st rng.State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	b.source.Restore(st)
+	return nil
+}
+
+// Seed implements Seeder.
+func (b *HeuristicBot) Seed(seed int64) {
+	b.source.Seed(seed)
+}
+
+func (b *HeuristicBot) PlayTurn(g GameLike, botID string) error {
+	player := g.GetPlayerState(botID)
+	if player == nil || player.Finished || len(player.Hand) == 0 {
+		return nil
+	}
+
+	c, target, ok := b.BestMove(g, botID)
+	if !ok {
+		g.Pass(player.ID)
+		return nil
+	}
+	return g.PlayCardWithTarget(player.ID, c.ID, target)
+}
+
+// BestMove returns the move the bot would play without playing it, ok is
+// false when it would pass. The hint of the local player asks it.
+func (b *HeuristicBot) BestMove(g GameLike, botID string) (c *entity.Card, target entity.Target, ok bool) {
+	player := g.GetPlayerState(botID)
+	if player == nil {
+		return nil, entity.Target{}, false
+	}
+
+	var e *rules.Engine
+	if src, ok := g.(EngineSource); ok {
+		e = src.RulesEngine()
+	}
+
+	// Passing when nobody else can play would hand the turn back forever
+	var best []move
+	bestScore := math.Inf(-1)
+	if e != nil && othersCanPlay(e, botID) {
+		bestScore = b.Weights.Pass
+	}
+	for _, c := range player.Cards() {
+		for _, t := range g.LegalTargets(player.ID, c.ID) {
+			score := b.score(e, botID, c, t)
+			switch {
+			case score > bestScore:
+				best, bestScore = []move{{card: c, target: t}}, score
+			case score == bestScore:
+				best = append(best, move{card: c, target: t})
+			}
+		}
+	}
+
+	if len(best) == 0 {
+		return nil, entity.Target{}, false
+	}
+	m := best[b.rand.Intn(len(best))]
+	return m.card, m.target, true
+}
+
+func (b *HeuristicBot) score(e *rules.Engine, botID string, c *entity.Card, t entity.Target) float64 {
+	w := b.Weights
+	score := 0.0
+	switch c.Type {
+	case entity.CardTypeRecipe:
+		score += w.Recipe
+	case entity.CardTypeIngredient:
+		score += w.Ingredient
+	case entity.CardTypeWildcard:
+		score += w.Wildcard
+	case entity.CardTypeAction:
+		score += w.Action
+	}
+	if e == nil {
+		return score
+	}
+
+	if c.Type != entity.CardTypeAction {
+		if e.Cards.CompletingRecipe(c) != nil {
+			score += w.Dish
+		}
+		score += w.Progress * progress(e, c)
+	}
+	if t.PlayerID != "" && isLeader(e, botID, t.PlayerID) {
+		score += w.Leader
+	}
+	if t.CardID != "" {
+		if tc := e.Cards.TableStack.GetCard(t.CardID); tc != nil && tc.PlayerID == botID {
+			score += w.OwnCard
+		}
+	}
+	return score
+}
+
+// progress returns how many more recipe requirements would be filled with
+// the card on the table, as a share of the requirements of those recipes
+func progress(e *rules.Engine, c *entity.Card) float64 {
+	table := e.Cards.TableStack.GetAllCardsInOrder()
+	var recipes, ingredients []*entity.Card
+	for _, tc := range table {
+		if tc.Type == entity.CardTypeRecipe {
+			recipes = append(recipes, tc)
+		} else if tc.IsIngredient() {
+			ingredients = append(ingredients, tc)
+		}
+	}
+
+	filled := func(r *entity.Card, ings []*entity.Card) int {
+		n := 0
+		for _, idx := range card.MatchRequirements(r.Requirements, ings) {
+			if idx >= 0 {
+				n++
+			}
+		}
+		return n
+	}
+
+	gained, total := 0, 0
+	if c.Type == entity.CardTypeRecipe {
+		gained, total = filled(c, ingredients), len(c.Requirements)
+	} else {
+		with := append(ingredients[:len(ingredients):len(ingredients)], c)
+		for _, r := range recipes {
+			gained += filled(r, with) - filled(r, ingredients)
+			total += len(r.Requirements)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(gained) / float64(total)
+}
+
+// isLeader reports whether no other unfinished opponent has fewer cards in
+// hand than the target
+func isLeader(e *rules.Engine, botID, targetID string) bool {
+	target := e.GetPlayer(targetID)
+	if target == nil {
+		return false
+	}
+	fewest := math.MaxInt
+	for _, p := range e.Players {
+		if p.ID == botID {
+			continue
+		}
+		if turn := e.Turns.GetPlayerByID(p.ID); turn == nil || turn.Finished {
+			continue
+		}
+		fewest = min(fewest, len(p.Hand))
+	}
+	return len(target.Hand) <= fewest
+}
+
+// othersCanPlay reports whether another player has cards and has not passed
+func othersCanPlay(e *rules.Engine, botID string) bool {
+	for _, p := range e.Players {
+		turn := e.Turns.GetPlayerByID(p.ID)
+		if p.ID != botID && turn != nil && !turn.Finished && !turn.HandEmpty && !turn.Passed {
+			return true
+		}
+	}
+	return false
+}
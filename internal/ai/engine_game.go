@@ -0,0 +1,60 @@
+package ai
+
+import (
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+var (
+	_ GameLike     = (*EngineGame)(nil)
+	_ EngineSource = (*EngineGame)(nil)
+)
+
+// EngineGame lets bots play directly on a rules engine, without the rest of
+// the game around it
+type EngineGame struct {
+	Engine *rules.Engine
+}
+
+// GetPlayerState implements GameLike.
+func (g *EngineGame) GetPlayerState(id string) *PlayerState {
+	turn := g.Engine.Turns.GetPlayerByID(id)
+	player := g.Engine.GetPlayer(id)
+	if turn == nil || player == nil {
+		return nil
+	}
+
+	return &PlayerState{
+		ID:       turn.ID,
+		IsBot:    turn.IsBot,
+		Hand:     player.Hand,
+		Order:    player.OrderHand,
+		Passed:   turn.Passed,
+		Finished: turn.Finished,
+	}
+}
+
+// PlayCard implements GameLike.
+func (g *EngineGame) PlayCard(playerID string, cardID string) error {
+	return g.Engine.PlayCard(playerID, cardID, entity.Target{})
+}
+
+// PlayCardWithTarget implements GameLike.
+func (g *EngineGame) PlayCardWithTarget(playerID string, cardID string, target entity.Target) error {
+	return g.Engine.PlayCard(playerID, cardID, target)
+}
+
+// LegalTargets implements GameLike.
+func (g *EngineGame) LegalTargets(playerID string, cardID string) []entity.Target {
+	return g.Engine.LegalTargets(playerID, cardID)
+}
+
+// Pass implements GameLike.
+func (g *EngineGame) Pass(playerID string) {
+	_ = g.Engine.Pass(playerID)
+}
+
+// RulesEngine implements EngineSource.
+func (g *EngineGame) RulesEngine() *rules.Engine {
+	return g.Engine
+}
@@ -3,6 +3,7 @@ package ai
 import (
 	crand "crypto/rand"
 	"encoding/binary"
+	"io"
 	mrand "math/rand"
 	"time"
 )
@@ -11,6 +12,9 @@ type Manager struct {
 	bots     map[string]Bot // map PlayerID -> Bot
 	rand     *mrand.Rand
 	thinking map[string]time.Time // map PlayerID -> time when bot started thinking
+
+	minThinkTime time.Duration
+	maxThinkTime time.Duration
 }
 
 func NewManager() *Manager {
@@ -21,27 +25,61 @@ func NewManager() *Manager {
 		bots:     make(map[string]Bot),
 		rand:     mrand.New(mrand.NewSource(seed)),
 		thinking: make(map[string]time.Time),
+
+		minThinkTime: 1200 * time.Millisecond,
+		maxThinkTime: 2000 * time.Millisecond,
 	}
 }
 
+// SetThinkTime sets the range of the random delay before a bot plays
+func (m *Manager) SetThinkTime(min, max time.Duration) {
+	m.minThinkTime = min
+	m.maxThinkTime = max
+}
+
 func (m *Manager) RegisterBot(playerID string, bot Bot) {
 	m.bots[playerID] = bot
 }
 
+// Clear forgets the bots of the last match, bots that hold resources such
+// as a process are closed
+func (m *Manager) Clear() {
+	for _, bot := range m.bots {
+		if c, ok := bot.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+	m.bots = make(map[string]Bot)
+	m.thinking = make(map[string]time.Time)
+}
+
+func (m *Manager) GetBot(playerID string) Bot {
+	return m.bots[playerID]
+}
+
 func (m *Manager) OnTurn(playerID string, g GameLike) {
 	if bot, ok := m.bots[playerID]; ok {
 		if startTime, isThinking := m.thinking[playerID]; isThinking {
 			thinkDuration := time.Since(startTime)
 
-			// Random thinking time between 1200ms and 2000ms
-			minThinkTime := 1200 * time.Millisecond
-			maxThinkTime := 2000 * time.Millisecond
-			thinkTime := minThinkTime + time.Duration(m.rand.Int63n(int64(maxThinkTime-minThinkTime)))
+			// Random thinking time between minThinkTime and maxThinkTime
+			thinkTime := m.minThinkTime
+			if m.maxThinkTime > m.minThinkTime {
+				thinkTime += time.Duration(m.rand.Int63n(int64(m.maxThinkTime - m.minThinkTime)))
+			}
 
 			if thinkDuration < thinkTime {
 				return
 			}
 
+			if p, ok := bot.(Poller); ok {
+				if done, _ := p.PollTurn(g, playerID); !done {
+					return // Still thinking, asked again on the next frame
+				}
+				delete(m.thinking, playerID)
+				return
+			}
+
 			delete(m.thinking, playerID)
 
 			_ = bot.PlayTurn(g, playerID)
@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfileDir is where the tools look for heuristic bot profiles
+const DefaultProfileDir = "bots"
+
+// Profile is a named set of heuristic bot weights, as written by the tuner
+type Profile struct {
+	Name    string  `json:"name"`
+	Weights Weights `json:"weights"`
+	Fitness float64 `json:"fitness,omitempty"` // Share of opponents beaten when it was tuned
+}
+
+// LoadProfile reads a profile file, a profile without a name is named
+// after its file
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := Profile{Weights: DefaultWeights}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return &p, nil
+}
+
+// SaveProfile writes a profile file
+func SaveProfile(path string, p *Profile) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// RegisterProfile makes a profile available by its name, which cannot be
+// the name of a built-in bot
+func RegisterProfile(p *Profile) error {
+	w := p.Weights
+	if err := Register(p.Name, func() Bot { return NewHeuristicBot(w) }); err != nil {
+		return fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// LoadProfiles registers every profile in a directory and returns their
+// names, a missing directory has none
+func LoadProfiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var errs []error
+	for _, path := range paths {
+		p, err := LoadProfile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := RegisterProfile(p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, p.Name)
+	}
+	return names, errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Factory creates the bot of one seat
+type Factory func() Bot
+
+var registry = map[string]Factory{
+	"coop":      func() Bot { return NewCoopBot() },
+	"easy":      func() Bot { return NewEasyBot() },
+	"heuristic": func() Bot { return NewHeuristicBot(DefaultWeights) },
+}
+
+// builtins are the bots of this package, registered bots cannot replace
+// them
+var builtins = slices.Collect(maps.Keys(registry))
+
+// Register makes a bot available by name, to tools such as the simulator
+// and the tournament runner. The names of the built-in bots are taken.
+func Register(name string, f Factory) error {
+	if Builtin(name) {
+		return fmt.Errorf("bot name %q is taken by a built-in bot", name)
+	}
+	registry[name] = f
+	return nil
+}
+
+// Builtin reports whether a name is taken by a bot of this package
+func Builtin(name string) bool {
+	return slices.Contains(builtins, name)
+}
+
+// Registered returns the names of the registered bots, sorted
+func Registered() []string {
+	return slices.Sorted(maps.Keys(registry))
+}
+
+// New creates the bot registered under a name
+func New(name string) (Bot, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q, registered bots are %s", name, strings.Join(Registered(), ", "))
+	}
+	return f(), nil
+}
+
+// NewFromSpec creates a bot from a spec, either the name of a registered bot
+// or name=command for an external bot. It also returns the name.
+func NewFromSpec(spec string, timeout time.Duration) (string, Bot, error) {
+	name, command, external := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !external {
+		bot, err := New(name)
+		return name, bot, err
+	}
+
+	args := strings.Fields(command)
+	if name == "" || len(args) == 0 {
+		return "", nil, fmt.Errorf("invalid bot %q, expected name=command", spec)
+	}
+	return name, NewExternalBot(args, timeout), nil
+}
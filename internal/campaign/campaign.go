@@ -0,0 +1,229 @@
+// Package campaign reads the chapters of the campaign, a journey through the
+// regional kitchens of the country. Each chapter deals its own selection of
+// recipes with twists to the rules against a lineup of bots, and beating it
+// opens the next one.
+package campaign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/assets/configs"
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// Goal types
+const (
+	GoalFinish = "finish" // Finish at position Count or better
+	GoalDishes = "dishes" // Make Count dishes
+	GoalScore  = "score"  // Score at least Count points
+)
+
+// MaxGoals is the number of stars a chapter can give
+const MaxGoals = 3
+
+// Chapter is one stop of the campaign
+type Chapter struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Deck        string   `json:"deck"`
+	Ruleset     string   `json:"ruleset,omitempty"`
+	Recipes     []string `json:"recipes,omitempty"` // Recipe IDs dealt, every recipe of the deck when empty
+	Twists      []Twist  `json:"twists,omitempty"`
+	Bots        []Bot    `json:"bots"`
+	Goals       []Goal   `json:"goals"` // One star each, meeting the first beats the chapter
+	Map         Point    `json:"map"`
+}
+
+// Twist changes the rules of a chapter with deck effects added to those of
+// the deck
+type Twist struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Effects     []entity.Effect `json:"effects"`
+}
+
+// Bot is one seat of the lineup
+type Bot struct {
+	Name string `json:"name"`
+	Kind string `json:"bot"` // Registered name, see ai.Registered
+}
+
+// Goal earns a star when a match ends with it met
+type Goal struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// Point is the position of a chapter on the chapter map, in screen pixels
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Outcome is how the player did in a match of a chapter
+type Outcome struct {
+	Position int // 0 when the player did not finish
+	Dishes   int
+	Score    int
+}
+
+// Text describes the goal to the player
+func (g Goal) Text() string {
+	switch g.Type {
+	case GoalFinish:
+		if g.Count == 1 {
+			return "Finish first"
+		}
+		return fmt.Sprintf("Finish in the first %d", g.Count)
+	case GoalDishes:
+		return fmt.Sprintf("Make %d dishes", g.Count)
+	}
+	return fmt.Sprintf("Score %d points", g.Count)
+}
+
+func (g Goal) Met(o Outcome) bool {
+	switch g.Type {
+	case GoalFinish:
+		return o.Position > 0 && o.Position <= g.Count
+	case GoalDishes:
+		return o.Dishes >= g.Count
+	case GoalScore:
+		return o.Score >= g.Count
+	}
+	return false
+}
+
+// List returns every chapter in the order they are played, which is the
+// order of the file names
+func List() ([]*Chapter, error) {
+	files, err := fs.Glob(configs.CampaignFS, "campaign/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var chapters []*Chapter
+	ids := make(map[string]bool)
+	for _, name := range files {
+		c, err := read(name)
+		if err != nil {
+			return nil, err
+		}
+		if ids[c.ID] {
+			return nil, fmt.Errorf("%s: duplicate chapter %s", path.Base(name), c.ID)
+		}
+		ids[c.ID] = true
+		chapters = append(chapters, c)
+	}
+	return chapters, nil
+}
+
+func read(name string) (*Chapter, error) {
+	data, err := configs.CampaignFS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Chapter{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	return c, nil
+}
+
+// Validate checks a chapter, its deck included, and reports every problem
+// found
+func (c *Chapter) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.ID == "" {
+		fail("chapter %q has no id", c.Name)
+	}
+	if c.Name == "" {
+		fail("chapter %s has no name", c.ID)
+	}
+	if _, err := c.DeckConfig(); err != nil {
+		fail("chapter %s: %w", c.ID, err)
+	}
+
+	if len(c.Bots) == 0 || len(c.Bots) > 5 {
+		fail("chapter %s needs 1 to 5 bots, got %d", c.ID, len(c.Bots))
+	}
+	for i, b := range c.Bots {
+		if b.Name == "" {
+			fail("chapter %s: bot %d has no name", c.ID, i+1)
+		}
+		if _, err := ai.New(b.Kind); err != nil {
+			fail("chapter %s: bot %s: %w", c.ID, b.Name, err)
+		}
+	}
+
+	if len(c.Goals) == 0 || len(c.Goals) > MaxGoals {
+		fail("chapter %s needs 1 to %d goals, got %d", c.ID, MaxGoals, len(c.Goals))
+	}
+	for i, g := range c.Goals {
+		switch g.Type {
+		case GoalFinish:
+			if g.Count < 1 || g.Count > len(c.Bots)+1 {
+				fail("chapter %s: goal %d needs a position between 1 and %d", c.ID, i+1, len(c.Bots)+1)
+			}
+		case GoalDishes, GoalScore:
+			if g.Count < 1 {
+				fail("chapter %s: goal %d needs a count", c.ID, i+1)
+			}
+		default:
+			fail("chapter %s: goal %d has unknown type %q", c.ID, i+1, g.Type)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeckConfig reads the deck of the chapter with its ruleset, recipes and
+// twists applied
+func (c *Chapter) DeckConfig() (*card.DeckConfig, error) {
+	if !slices.Contains(card.Themes, c.Deck) {
+		return nil, fmt.Errorf("unknown deck %q", c.Deck)
+	}
+	cfg, err := card.ReadDeckConfig(c.Deck)
+	if err != nil {
+		return nil, err
+	}
+	if err := card.ApplyRuleset(cfg, c.Ruleset); err != nil {
+		return nil, err
+	}
+	if len(c.Recipes) > 0 {
+		if err := cfg.KeepRecipes(c.Recipes); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range c.Twists {
+		cfg.Deck.Effects = append(cfg.Deck.Effects, t.Effects...)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Stars counts the goals met, the chapter is beaten when the first one is
+func (c *Chapter) Stars(o Outcome) (stars int, beaten bool) {
+	for _, g := range c.Goals {
+		if g.Met(o) {
+			stars++
+		}
+	}
+	return stars, c.Goals[0].Met(o)
+}
@@ -0,0 +1,85 @@
+package campaign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the campaign progress
+const Key = "campaign.json"
+
+// Progress keeps the best stars of each chapter and which ones were beaten
+type Progress struct {
+	Stars  map[string]int  `json:"stars"`  // Chapter ID -> best stars
+	Beaten map[string]bool `json:"beaten"` // Chapter ID -> beaten once
+}
+
+func NewProgress() *Progress {
+	return &Progress{
+		Stars:  make(map[string]int),
+		Beaten: make(map[string]bool),
+	}
+}
+
+// LoadProgress reads the saved progress, a missing one gives an empty one
+func LoadProgress(store storage.Store) (*Progress, error) {
+	p := NewProgress()
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return NewProgress(), fmt.Errorf("read campaign progress: %w", err)
+	}
+	if p.Stars == nil {
+		p.Stars = make(map[string]int)
+	}
+	if p.Beaten == nil {
+		p.Beaten = make(map[string]bool)
+	}
+	return p, nil
+}
+
+func (p *Progress) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
+
+// Record keeps the stars of a match if they beat the best ones and marks
+// the chapter beaten, it reports whether anything changed
+func (p *Progress) Record(id string, stars int, beaten bool) bool {
+	changed := false
+	if stars > p.Stars[id] {
+		p.Stars[id] = stars
+		changed = true
+	}
+	if beaten && !p.Beaten[id] {
+		p.Beaten[id] = true
+		changed = true
+	}
+	return changed
+}
+
+// Unlocked reports whether chapters[i] can be played, the first always can
+// and every other once the one before it was beaten
+func (p *Progress) Unlocked(chapters []*Chapter, i int) bool {
+	return i == 0 || p.Beaten[chapters[i-1].ID]
+}
+
+// Total returns the stars earned over every chapter
+func (p *Progress) Total() int {
+	n := 0
+	for _, s := range p.Stars {
+		n += s
+	}
+	return n
+}
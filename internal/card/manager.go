@@ -1,31 +1,44 @@
 package card
 
 import (
-	crand "crypto/rand"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	mrand "math/rand"
+	"path"
+	"slices"
 
 	"github.com/thanhfphan/ebitengj2025/assets/configs"
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
 )
 
 type Manager struct {
-	Deck []*entity.Card
-	rand *mrand.Rand
+	Deck        []*entity.Card
+	DeckEffects []entity.Effect // Effects of the deck as a whole
+	Theme       string          // Name of the loaded deck
+	rand        *mrand.Rand
+	source      *rng.Source
 
-	TableStack *entity.TableStack
-	OnDishMade func(recipe *entity.Card)
-	OnPlayCard func(player *entity.Player, card *entity.Card)
+	ingredientNames map[string]string // ingredient ID -> name
+
+	TableStack  *entity.TableStack
+	DiscardPile []*entity.Card // Resolved action cards and cooked dishes, newest last
+	OnDishMade  func(recipe *entity.Card)
+	OnPlayCard  func(player *entity.Player, card *entity.Card)
+}
+
+// Dish is a recipe that was cooked together with the ingredients it used
+type Dish struct {
+	Recipe      *entity.Card
+	Ingredients []*entity.Card
 }
 
 func NewManager() *Manager {
-	var seed int64
-	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	rand, source := rng.New(rng.RandomSeed())
 
 	mgr := &Manager{
-		rand:       mrand.New(mrand.NewSource(seed)),
+		rand:       rand,
+		source:     source,
 		TableStack: entity.NewTableStack(),
 	}
 
@@ -36,56 +49,161 @@ func NewManager() *Manager {
 }
 
 func (m *Manager) LoadDeck(theme string) error {
-	m.Deck = []*entity.Card{}
-	m.TableStack = entity.NewTableStack()
-
-	var ingFile IngredientFile
-	var rcpFile RecipeFile
+	return m.LoadDeckRuleset(theme, RulesetStandard)
+}
 
-	if err := json.Unmarshal(configs.DefaultIngredientsJSON, &ingFile); err != nil {
+// LoadDeckRuleset loads a deck without the cards the ruleset leaves out
+func (m *Manager) LoadDeckRuleset(theme, ruleset string) error {
+	cfg, err := ReadDeckConfig(theme)
+	if err != nil {
 		return err
 	}
-	if err := json.Unmarshal(configs.DefaultRecipesJSON, &rcpFile); err != nil {
+	if err := ApplyRuleset(cfg, ruleset); err != nil {
 		return err
 	}
+	return m.UseDeck(theme, cfg)
+}
+
+// UseDeck builds a deck config read from a theme and changed afterwards,
+// such as the selection of recipes of a campaign chapter
+func (m *Manager) UseDeck(theme string, cfg *DeckConfig) error {
+	m.Theme = theme
+	return m.BuildDeck(cfg)
+}
+
+// RandState returns the state of the random generator used for shuffling
+// and random effects
+func (m *Manager) RandState() rng.State {
+	return m.source.State()
+}
+
+func (m *Manager) SetRandState(st rng.State) {
+	m.source.Restore(st)
+}
+
+// ReadDeckConfig parses the config files of a deck and validates them
+func ReadDeckConfig(theme string) (*DeckConfig, error) {
+	if !slices.Contains(Themes, theme) {
+		return nil, fmt.Errorf("unknown deck %q", theme)
+	}
+
+	cfg := &DeckConfig{}
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"deck.json", &cfg.Deck},
+		{"ingredients.json", &cfg.Ingredients},
+		{"recipes.json", &cfg.Recipes},
+		{"specials.json", &cfg.Specials},
+	}
+	for _, f := range files {
+		data, err := configs.DecksFS.ReadFile(path.Join("decks", theme, f.name))
+		if err != nil {
+			return nil, fmt.Errorf("deck %s: %w", theme, err)
+		}
+		if err := json.Unmarshal(data, f.v); err != nil {
+			return nil, fmt.Errorf("deck %s: %s: %w", theme, f.name, err)
+		}
+	}
+
+	for i := range cfg.Deck.Achievements {
+		cfg.Deck.Achievements[i].Deck = theme
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("deck %s: %w", theme, err)
+	}
+	return cfg, nil
+}
+
+// BuildDeck creates and shuffles the cards of a validated deck config
+func (m *Manager) BuildDeck(cfg *DeckConfig) error {
+	m.Deck = []*entity.Card{}
+	m.DeckEffects = cfg.Deck.Effects
+	m.TableStack = entity.NewTableStack()
+	m.DiscardPile = []*entity.Card{}
 
 	mapIng := make(map[string]IngredientConfig)
-	for _, ing := range ingFile.Ingredients {
+	for _, ing := range cfg.Ingredients.Ingredients {
 		mapIng[ing.ID] = ing
 	}
 
-	for _, r := range rcpFile.Recipes {
+	tagged := make(map[string][]IngredientConfig)
+	for _, ing := range cfg.Ingredients.Ingredients {
+		for _, tag := range ing.Tags {
+			tagged[tag] = append(tagged[tag], ing)
+		}
+	}
+
+	for _, r := range cfg.Recipes.Recipes {
 		card := &entity.Card{
-			Entity:              *entity.NewEntity(entity.TypeCard, r.Name),
-			Type:                entity.CardTypeRecipe,
-			RequiredIngredients: r.Requires,
+			Entity:   *entity.NewEntity(entity.TypeCard, r.Name),
+			Type:     entity.CardTypeRecipe,
+			RecipeID: r.ID,
+			Effects:  r.Effects,
 		}
 		m.Deck = append(m.Deck, card)
 
-		for _, ingID := range r.Requires {
-			ing, ok := mapIng[ingID]
-			if !ok {
-				return fmt.Errorf("recipe %s requires unknown ingredient %s", r.ID, ingID)
+		for _, req := range r.Requires {
+			for range req.Count {
+				var ing IngredientConfig
+				if req.Tag != "" {
+					candidates := tagged[req.Tag]
+					if len(candidates) == 0 {
+						return fmt.Errorf("recipe %s requires unknown tag %s", r.ID, req.Tag)
+					}
+					// Any ingredient with the tag will do, pick one for the deck
+					ing = candidates[m.rand.Intn(len(candidates))]
+					card.Requirements = append(card.Requirements, entity.Requirement{Tag: req.Tag})
+				} else {
+					var ok bool
+					ing, ok = mapIng[req.ID]
+					if !ok {
+						return fmt.Errorf("recipe %s requires unknown ingredient %s", r.ID, req.ID)
+					}
+					card.Requirements = append(card.Requirements, entity.Requirement{IngredientID: req.ID})
+				}
+
+				m.Deck = append(m.Deck, &entity.Card{
+					Entity:       *entity.NewEntity(entity.TypeCard, ing.Name),
+					Type:         entity.CardTypeIngredient,
+					IngredientID: ing.ID,
+					Tags:         ing.Tags,
+					Effects:      ing.Effects,
+				})
 			}
+		}
+	}
 
+	for _, sp := range cfg.Specials.Specials {
+		cardType := entity.CardTypeWildcard
+		if sp.Type == SpecialAction {
+			cardType = entity.CardTypeAction
+		}
+		for range sp.Count {
 			m.Deck = append(m.Deck, &entity.Card{
-				Entity:       *entity.NewEntity(entity.TypeCard, ing.Name),
-				Type:         entity.CardTypeIngredient,
-				IngredientID: ingID,
+				Entity:      *entity.NewEntity(entity.TypeCard, sp.Name),
+				Type:        cardType,
+				Effects:     sp.Effects,
+				Description: sp.Description,
 			})
 		}
 	}
 
+	m.ingredientNames = make(map[string]string)
+	for _, ing := range cfg.Ingredients.Ingredients {
+		m.ingredientNames[ing.ID] = ing.Name
+	}
+
 	m.shuffle(m.Deck)
 	return nil
 }
 
 func (m *Manager) GetMapIngredientNames() map[string]string {
-	result := make(map[string]string)
-	for _, card := range m.Deck {
-		if card.Type == entity.CardTypeIngredient {
-			result[card.IngredientID] = card.Name
-		}
+	result := make(map[string]string, len(m.ingredientNames))
+	for id, name := range m.ingredientNames {
+		result[id] = name
 	}
 	return result
 }
@@ -98,6 +216,21 @@ func (m *Manager) shuffle(cards []*entity.Card) {
 	}
 }
 
+// SetAside leaves n cards of the shuffled deck out of the match, call it
+// before dealing
+func (m *Manager) SetAside(n int) {
+	m.Deck = m.Deck[min(n, len(m.Deck)):]
+}
+
+// RandomCard picks one of the cards with the generator of the deck, nil if
+// there are none
+func (m *Manager) RandomCard(cards []*entity.Card) *entity.Card {
+	if len(cards) == 0 {
+		return nil
+	}
+	return cards[m.rand.Intn(len(cards))]
+}
+
 func (m *Manager) DealHands(players []*entity.Player) {
 	j := 0
 	numPlayers := len(players)
@@ -107,6 +240,54 @@ func (m *Manager) DealHands(players []*entity.Player) {
 	}
 }
 
+// Placement is a card a scripted deal puts on the table, as if the player
+// at index Player played it
+type Placement struct {
+	Card   string `json:"card"`
+	Player int    `json:"player"`
+}
+
+// DealScripted deals the named cards instead of the whole deck, hands[i] go
+// to players[i]. Each name takes the next unused card of the deck with that
+// name, the rest of the deck is left out of the match.
+func (m *Manager) DealScripted(players []*entity.Player, hands [][]string, table []Placement) error {
+	if len(hands) > len(players) {
+		return fmt.Errorf("%d hands for %d players", len(hands), len(players))
+	}
+
+	used := make(map[string]bool)
+	take := func(name string) (*entity.Card, error) {
+		for _, c := range m.Deck {
+			if c.Name == name && !used[c.ID] {
+				used[c.ID] = true
+				return c, nil
+			}
+		}
+		return nil, fmt.Errorf("the deck has no card %q left", name)
+	}
+
+	for i, hand := range hands {
+		for _, name := range hand {
+			c, err := take(name)
+			if err != nil {
+				return err
+			}
+			players[i].AddCard(c)
+		}
+	}
+	for _, pl := range table {
+		if pl.Player < 0 || pl.Player >= len(players) {
+			return fmt.Errorf("table card %q belongs to player %d of %d", pl.Card, pl.Player, len(players))
+		}
+		c, err := take(pl.Card)
+		if err != nil {
+			return err
+		}
+		m.TableStack.AddCard(c, players[pl.Player].ID)
+	}
+	return nil
+}
+
 func (m *Manager) PlayCard(player *entity.Player, cardID string) error {
 	removeCard := player.GetCard(cardID)
 	if removeCard == nil {
@@ -123,49 +304,153 @@ func (m *Manager) PlayCard(player *entity.Player, cardID string) error {
 	return nil
 }
 
-func (m *Manager) TryMakeDish() bool {
+// TakeActionCard removes an action card from the player's hand so its
+// effects can be resolved, the caller puts it on the discard pile afterwards
+func (m *Manager) TakeActionCard(player *entity.Player, cardID string) (*entity.Card, error) {
+	card := player.GetCard(cardID)
+	if card == nil {
+		return nil, fmt.Errorf("invalid card id: %s", cardID)
+	}
+	player.RemoveCard(card.ID)
+
+	if m.OnPlayCard != nil {
+		m.OnPlayCard(player, card)
+	}
+
+	return card, nil
+}
+
+// Discard puts a card on top of the discard pile
+func (m *Manager) Discard(card *entity.Card) {
+	m.DiscardPile = append(m.DiscardPile, card)
+}
+
+// SwapRandomCards exchanges one random card between the two hands. Nothing
+// happens if either hand is empty.
+func (m *Manager) SwapRandomCards(a, b *entity.Player) {
+	if len(a.OrderHand) == 0 || len(b.OrderHand) == 0 {
+		return
+	}
+
+	fromA := a.GetCard(a.OrderHand[m.rand.Intn(len(a.OrderHand))])
+	fromB := b.GetCard(b.OrderHand[m.rand.Intn(len(b.OrderHand))])
+	a.RemoveCard(fromA.ID)
+	b.RemoveCard(fromB.ID)
+	a.AddCard(fromB)
+	b.AddCard(fromA)
+}
+
+// TakeRandomCards moves up to n random cards from one hand to another
+func (m *Manager) TakeRandomCards(from, to *entity.Player, n int) {
+	for range n {
+		if len(from.OrderHand) == 0 {
+			return
+		}
+		card := from.GetCard(from.OrderHand[m.rand.Intn(len(from.OrderHand))])
+		from.RemoveCard(card.ID)
+		to.AddCard(card)
+	}
+}
+
+// DiscardRandomCards moves up to n random cards from the hand to the
+// discard pile
+func (m *Manager) DiscardRandomCards(player *entity.Player, n int) {
+	for range n {
+		if len(player.OrderHand) == 0 {
+			return
+		}
+		card := player.GetCard(player.OrderHand[m.rand.Intn(len(player.OrderHand))])
+		player.RemoveCard(card.ID)
+		m.DiscardPile = append(m.DiscardPile, card)
+	}
+}
+
+// DrawCards moves up to n cards from the top of the discard pile to the hand
+func (m *Manager) DrawCards(player *entity.Player, n int) {
+	for range n {
+		if len(m.DiscardPile) == 0 {
+			return
+		}
+		card := m.DiscardPile[len(m.DiscardPile)-1]
+		m.DiscardPile = m.DiscardPile[:len(m.DiscardPile)-1]
+		player.AddCard(card)
+	}
+}
+
+// ReturnToOwner takes a card off the table and puts it back into the hand
+// of the player who played it
+func (m *Manager) ReturnToOwner(cardID string, players []*entity.Player) error {
+	onTable := m.TableStack.GetCard(cardID)
+	if onTable == nil {
+		return fmt.Errorf("card %s is not on the table", cardID)
+	}
+
+	for _, p := range players {
+		if p.ID == onTable.PlayerID {
+			m.TableStack.RemoveCard(cardID)
+			p.AddCard(onTable.Card)
+			return nil
+		}
+	}
+	return fmt.Errorf("owner %s of card %s not found", onTable.PlayerID, cardID)
+}
+
+// TryMakeDish cooks the newest recipe on the table that can be completed.
+// The cards used go to the discard pile. It returns nil if no dish was made.
+func (m *Manager) TryMakeDish() *Dish {
+	dish := m.findDish(m.TableStack.GetAllCardsInReverseOrder())
+	if dish == nil {
+		return nil
+	}
+
+	m.TableStack.RemoveCard(dish.Recipe.ID)
+	m.DiscardPile = append(m.DiscardPile, dish.Recipe)
+	for _, ing := range dish.Ingredients {
+		m.TableStack.RemoveCard(ing.ID)
+		m.DiscardPile = append(m.DiscardPile, ing)
+	}
+	if m.OnDishMade != nil {
+		m.OnDishMade(dish.Recipe)
+	}
+	return dish
+}
+
+// CompletingRecipe returns the recipe that would be made if the given card
+// was played on the table right now, or nil if playing it makes no dish.
+func (m *Manager) CompletingRecipe(card *entity.Card) *entity.Card {
+	cards := append([]*entity.Card{card}, m.TableStack.GetAllCardsInReverseOrder()...)
+	if dish := m.findDish(cards); dish != nil {
+		return dish.Recipe
+	}
+	return nil
+}
+
+// findDish looks for the newest recipe in cards (newest first) whose
+// requirements are all covered by the ingredients in cards.
+func (m *Manager) findDish(cards []*entity.Card) *Dish {
 	var recipes []*entity.Card
 	var ingredients []*entity.Card
 
-	for _, card := range m.TableStack.GetAllCardsInReverseOrder() {
+	for _, card := range cards {
 		if card.Type == entity.CardTypeRecipe {
 			recipes = append(recipes, card)
-		} else if card.Type == entity.CardTypeIngredient {
+		} else if card.IsIngredient() {
 			ingredients = append(ingredients, card)
 		}
 	}
 
 	for _, r := range recipes {
-		need := make(map[string]int)
-		for _, ing := range r.RequiredIngredients {
-			need[ing]++
-		}
-
-		var usedIDs []string
-		usedCount := 0
-
-		for _, ing := range ingredients {
-			if cnt, ok := need[ing.IngredientID]; ok && cnt > 0 {
-				need[ing.IngredientID]--
-				usedIDs = append(usedIDs, ing.ID)
-				usedCount++
-				if usedCount == len(r.RequiredIngredients) {
-					break
-				}
-			}
+		match := MatchRequirements(r.Requirements, ingredients)
+		if !IsComplete(match) {
+			continue
 		}
 
-		if usedCount == len(r.RequiredIngredients) {
-			m.TableStack.RemoveCard(r.ID)
-			for _, id := range usedIDs {
-				m.TableStack.RemoveCard(id)
-			}
-			if m.OnDishMade != nil {
-				m.OnDishMade(r)
-			}
-			return true
+		used := make([]*entity.Card, 0, len(match))
+		for _, idx := range match {
+			used = append(used, ingredients[idx])
 		}
+		return &Dish{Recipe: r, Ingredients: used}
 	}
 
-	return false
+	return nil
 }
@@ -0,0 +1,143 @@
+package card
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+)
+
+// seededManager loads the default deck with a fixed seed, tag requirements
+// pick their ingredients at random
+func seededManager(t *testing.T) *Manager {
+	t.Helper()
+	m := NewManager()
+	m.SetRandState(rng.State{Seed: 1})
+	if err := m.LoadDeck("default"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestDealScripted(t *testing.T) {
+	m := seededManager(t)
+	copies := 0
+	for _, c := range m.Deck {
+		if c.Name == "Beef" {
+			copies++
+		}
+	}
+	allBeef := slices.Repeat([]string{"Beef"}, copies)
+
+	tests := []struct {
+		name    string
+		players int
+		hands   [][]string
+		table   []Placement
+		error   string // Part of the error, empty when the deal works
+	}{
+		{
+			name:    "hands and table",
+			players: 2,
+			hands:   [][]string{{"Beef", "Noodle"}, {"Broth"}},
+			table:   []Placement{{Card: "Beef Pho", Player: 1}},
+		},
+		{
+			name:    "fewer hands than players",
+			players: 3,
+			hands:   [][]string{{"Beef"}},
+		},
+		{
+			name:    "every copy of a card",
+			players: 2,
+			hands:   [][]string{allBeef},
+		},
+		{
+			name:    "more copies than the deck holds",
+			players: 2,
+			hands:   [][]string{allBeef, {"Beef"}},
+			error:   `no card "Beef" left`,
+		},
+		{
+			name:    "copies used by the table",
+			players: 2,
+			hands:   [][]string{allBeef},
+			table:   []Placement{{Card: "Beef", Player: 0}},
+			error:   `no card "Beef" left`,
+		},
+		{
+			name:    "unknown card",
+			players: 2,
+			hands:   [][]string{{"Durian"}},
+			error:   `no card "Durian"`,
+		},
+		{
+			name:    "more hands than players",
+			players: 1,
+			hands:   [][]string{{"Beef"}, {"Broth"}},
+			error:   "2 hands for 1 players",
+		},
+		{
+			name:    "table card of a missing player",
+			players: 2,
+			table:   []Placement{{Card: "Beef", Player: 2}},
+			error:   "belongs to player 2 of 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := seededManager(t)
+			players := make([]*entity.Player, tt.players)
+			for i := range players {
+				players[i] = entity.NewPlayer(fmt.Sprint("P", i), entity.TypePlayer)
+			}
+
+			err := m.DealScripted(players, tt.hands, tt.table)
+			if tt.error != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.error) {
+					t.Errorf("DealScripted() = %v, want an error about %q", err, tt.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dealt := make(map[string]bool)
+			deal := func(c *entity.Card) {
+				if dealt[c.ID] {
+					t.Errorf("card %s dealt twice", c.ID)
+				}
+				dealt[c.ID] = true
+			}
+			for i, p := range players {
+				var names []string
+				for _, id := range p.OrderHand {
+					deal(p.GetCard(id))
+					names = append(names, p.GetCard(id).Name)
+				}
+				var want []string
+				if i < len(tt.hands) {
+					want = tt.hands[i]
+				}
+				if !slices.Equal(names, want) {
+					t.Errorf("hand of %s = %v, want %v", p.Name, names, want)
+				}
+			}
+			entries := m.TableStack.GetEntriesInOrder()
+			if len(entries) != len(tt.table) {
+				t.Fatalf("%d cards on the table, want %d", len(entries), len(tt.table))
+			}
+			for i, e := range entries {
+				deal(e.Card)
+				if e.Card.Name != tt.table[i].Card || e.PlayerID != players[tt.table[i].Player].ID {
+					t.Errorf("table card %d = %s of %s, want %+v", i, e.Card.Name, e.PlayerID, tt.table[i])
+				}
+			}
+		})
+	}
+}
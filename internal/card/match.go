@@ -0,0 +1,69 @@
+package card
+
+import "github.com/thanhfphan/ebitengj2025/internal/entity"
+
+// MatchRequirements assigns ingredients to the requirements of a recipe. The
+// result has one entry per requirement holding the index of the ingredient
+// that fills it, or -1 when it stays open. Exact requirements are served
+// before tag requirements, and earlier ingredients are preferred over later
+// ones, so callers should pass the ingredients newest first. Wildcards only
+// fill what real ingredients cannot.
+func MatchRequirements(reqs []entity.Requirement, ingredients []*entity.Card) []int {
+	match := make([]int, len(reqs))
+	owner := make([]int, len(ingredients)) // ingredient index -> requirement index
+	for i := range match {
+		match[i] = -1
+	}
+	for i := range owner {
+		owner[i] = -1
+	}
+
+	var try func(r int, seen []bool) bool
+	try = func(r int, seen []bool) bool {
+		for i, ing := range ingredients {
+			if seen[i] || ing.Type == entity.CardTypeWildcard || !reqs[r].Accepts(ing) {
+				continue
+			}
+			seen[i] = true
+			if owner[i] < 0 || try(owner[i], seen) {
+				owner[i] = r
+				match[r] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, tagPass := range []bool{false, true} {
+		for r, req := range reqs {
+			if req.IsTag() != tagPass {
+				continue
+			}
+			try(r, make([]bool, len(ingredients)))
+		}
+	}
+
+	for i, ing := range ingredients {
+		if ing.Type != entity.CardTypeWildcard {
+			continue
+		}
+		for r := range reqs {
+			if match[r] < 0 {
+				match[r] = i
+				break
+			}
+		}
+	}
+
+	return match
+}
+
+// IsComplete reports whether every requirement got an ingredient.
+func IsComplete(match []int) bool {
+	for _, idx := range match {
+		if idx < 0 {
+			return false
+		}
+	}
+	return true
+}
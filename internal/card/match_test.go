@@ -0,0 +1,100 @@
+package card
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+func ingredient(id string, tags ...string) *entity.Card {
+	c := entity.NewCard(id, entity.CardTypeIngredient)
+	c.IngredientID = id
+	c.Tags = tags
+	return c
+}
+
+func TestMatchRequirements(t *testing.T) {
+	wildcard := entity.NewCard("Wildcard", entity.CardTypeWildcard)
+	beef := entity.Requirement{IngredientID: "beef"}
+	noodle := entity.Requirement{IngredientID: "noodle"}
+	herb := entity.Requirement{Tag: "herb"}
+
+	tests := []struct {
+		name        string
+		reqs        []entity.Requirement
+		ingredients []*entity.Card
+		want        []int
+	}{
+		{
+			name:        "exact ingredients",
+			reqs:        []entity.Requirement{beef, noodle},
+			ingredients: []*entity.Card{ingredient("noodle"), ingredient("beef")},
+			want:        []int{1, 0},
+		},
+		{
+			name:        "missing ingredient",
+			reqs:        []entity.Requirement{beef, noodle},
+			ingredients: []*entity.Card{ingredient("beef")},
+			want:        []int{0, -1},
+		},
+		{
+			name:        "tag",
+			reqs:        []entity.Requirement{herb},
+			ingredients: []*entity.Card{ingredient("beef"), ingredient("basil", "herb")},
+			want:        []int{1},
+		},
+		{
+			name:        "one card fills one requirement",
+			reqs:        []entity.Requirement{herb, herb},
+			ingredients: []*entity.Card{ingredient("basil", "herb")},
+			want:        []int{0, -1},
+		},
+		{
+			name:        "exact requirement served before the tag",
+			reqs:        []entity.Requirement{herb, {IngredientID: "basil"}},
+			ingredients: []*entity.Card{ingredient("basil", "herb"), ingredient("mint", "herb")},
+			want:        []int{1, 0},
+		},
+		{
+			name:        "ingredients moved to make room",
+			reqs:        []entity.Requirement{{Tag: "meat"}, {Tag: "beef"}},
+			ingredients: []*entity.Card{ingredient("beef", "meat", "beef"), ingredient("pork", "meat")},
+			want:        []int{1, 0},
+		},
+		{
+			name:        "earlier ingredients preferred",
+			reqs:        []entity.Requirement{beef},
+			ingredients: []*entity.Card{ingredient("beef"), ingredient("beef")},
+			want:        []int{0},
+		},
+		{
+			name:        "wildcard fills what is left",
+			reqs:        []entity.Requirement{beef, noodle},
+			ingredients: []*entity.Card{wildcard, ingredient("noodle")},
+			want:        []int{0, 1},
+		},
+		{
+			name:        "real ingredient preferred over a wildcard",
+			reqs:        []entity.Requirement{beef},
+			ingredients: []*entity.Card{wildcard, ingredient("beef")},
+			want:        []int{1},
+		},
+		{
+			name: "no requirements",
+			want: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchRequirements(tt.reqs, tt.ingredients)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MatchRequirements() = %v, want %v", got, tt.want)
+			}
+			if complete := !slices.Contains(tt.want, -1); IsComplete(got) != complete {
+				t.Errorf("IsComplete(%v) = %v, want %v", got, !complete, complete)
+			}
+		})
+	}
+}
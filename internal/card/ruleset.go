@@ -0,0 +1,53 @@
+package card
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/assets/configs"
+)
+
+// Themes lists the decks built into the game, one directory each in
+// assets/configs/decks. The default deck comes first.
+var Themes = readThemes()
+
+func readThemes() []string {
+	entries, err := fs.ReadDir(configs.DecksFS, "decks")
+	if err != nil {
+		panic(err)
+	}
+	themes := []string{"default"}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != "default" {
+			themes = append(themes, e.Name())
+		}
+	}
+	return themes
+}
+
+// Rulesets change which cards of a deck are dealt
+const (
+	RulesetStandard  = "standard"   // Every card of the deck
+	RulesetNoActions = "no_actions" // Wildcards stay, action cards are left out
+	RulesetClassic   = "classic"    // Only ingredients and recipes
+)
+
+var Rulesets = []string{RulesetStandard, RulesetNoActions, RulesetClassic}
+
+// ApplyRuleset removes the cards a ruleset leaves out of a deck config. An
+// empty name is the standard ruleset.
+func ApplyRuleset(cfg *DeckConfig, ruleset string) error {
+	switch ruleset {
+	case "", RulesetStandard:
+	case RulesetNoActions:
+		cfg.Specials.Specials = slices.DeleteFunc(cfg.Specials.Specials, func(sp SpecialConfig) bool {
+			return sp.Type == SpecialAction
+		})
+	case RulesetClassic:
+		cfg.Specials.Specials = nil
+	default:
+		return fmt.Errorf("unknown ruleset %q", ruleset)
+	}
+	return nil
+}
@@ -1,16 +1,74 @@
 package card
 
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
 type IngredientConfig struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	Icon string `json:"icon"`
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Icon    string          `json:"icon"`
+	Tags    []string        `json:"tags"`
+	Effects []entity.Effect `json:"effects,omitempty"`
+}
+
+// RequirementConfig describes one entry of a recipe's "requires" list. It is
+// either a plain ingredient ID ("I_THITBO") or an object such as
+// {"tag": "herb", "count": 2} or {"id": "I_BUN", "count": 2}.
+type RequirementConfig struct {
+	ID    string `json:"id,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+	Count int    `json:"count,omitempty"`
+}
+
+func (r *RequirementConfig) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*r = RequirementConfig{ID: id, Count: 1}
+		return nil
+	}
+
+	type plain RequirementConfig
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("invalid requirement %s: %w", data, err)
+	}
+	if (p.ID == "") == (p.Tag == "") {
+		return fmt.Errorf("requirement %s must set exactly one of id or tag", data)
+	}
+	if p.Count == 0 {
+		p.Count = 1
+	}
+	if p.Count < 0 {
+		return fmt.Errorf("requirement %s has negative count", data)
+	}
+	*r = RequirementConfig(p)
+	return nil
 }
 
 type RecipeConfig struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Requires []string `json:"requires"`
-	Icon     string   `json:"icon"`
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Requires []RequirementConfig `json:"requires"`
+	Icon     string              `json:"icon"`
+	Effects  []entity.Effect     `json:"effects,omitempty"`
+}
+
+// SpecialConfig describes cards that are neither ingredients nor recipes.
+// Type is "wildcard" or "action", what an action card does is given by its
+// on_play effects.
+type SpecialConfig struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Count       int             `json:"count"`
+	Effects     []entity.Effect `json:"effects,omitempty"`
 }
 
 type IngredientFile struct {
@@ -20,3 +78,59 @@ type IngredientFile struct {
 type RecipeFile struct {
 	Recipes []RecipeConfig `json:"recipes"`
 }
+
+type SpecialFile struct {
+	Specials []SpecialConfig `json:"specials"`
+}
+
+// DeckFile holds settings of the deck as a whole
+type DeckFile struct {
+	Name         string                   `json:"name"`
+	Effects      []entity.Effect          `json:"effects,omitempty"` // Apply to every player
+	Achievements []achievement.Definition `json:"achievements,omitempty"`
+}
+
+// DeckConfig is a whole deck as read from its config files
+type DeckConfig struct {
+	Deck        DeckFile
+	Ingredients IngredientFile
+	Recipes     RecipeFile
+	Specials    SpecialFile
+}
+
+func (d *DeckConfig) RecipeIDs() []string {
+	ids := make([]string, 0, len(d.Recipes.Recipes))
+	for _, r := range d.Recipes.Recipes {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// RecipeNames maps recipe IDs to their names
+func (d *DeckConfig) RecipeNames() map[string]string {
+	names := make(map[string]string)
+	for _, r := range d.Recipes.Recipes {
+		names[r.ID] = r.Name
+	}
+	return names
+}
+
+// KeepRecipes leaves only the given recipes in the deck, with the
+// achievements that count them. The ingredients of the recipes left out are
+// not dealt either.
+func (d *DeckConfig) KeepRecipes(ids []string) error {
+	known := d.RecipeNames()
+	for _, id := range ids {
+		if _, ok := known[id]; !ok {
+			return fmt.Errorf("unknown recipe %s", id)
+		}
+	}
+
+	d.Recipes.Recipes = slices.DeleteFunc(d.Recipes.Recipes, func(r RecipeConfig) bool {
+		return !slices.Contains(ids, r.ID)
+	})
+	d.Deck.Achievements = slices.DeleteFunc(d.Deck.Achievements, func(a achievement.Definition) bool {
+		return a.Goal.Recipe != "" && !slices.Contains(ids, a.Goal.Recipe)
+	})
+	return nil
+}
@@ -0,0 +1,209 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// Special card types
+const (
+	SpecialWildcard = "wildcard"
+	SpecialAction   = "action"
+)
+
+// Validate checks a deck config statically and reports every problem found
+func (d *DeckConfig) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	ingredients := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, ing := range d.Ingredients.Ingredients {
+		if ing.ID == "" {
+			fail("ingredient %q has no id", ing.Name)
+			continue
+		}
+		if ingredients[ing.ID] {
+			fail("duplicate ingredient %s", ing.ID)
+		}
+		ingredients[ing.ID] = true
+		for _, tag := range ing.Tags {
+			tags[tag] = true
+		}
+		errs = append(errs, validateEffects("ingredient "+ing.ID, ing.Effects, false)...)
+	}
+
+	recipes := make(map[string]bool)
+	for _, r := range d.Recipes.Recipes {
+		if r.ID == "" {
+			fail("recipe %q has no id", r.Name)
+			continue
+		}
+		if recipes[r.ID] {
+			fail("duplicate recipe %s", r.ID)
+		}
+		recipes[r.ID] = true
+		if len(r.Requires) == 0 {
+			fail("recipe %s requires nothing", r.ID)
+		}
+		for _, req := range r.Requires {
+			if req.ID != "" && !ingredients[req.ID] {
+				fail("recipe %s requires unknown ingredient %s", r.ID, req.ID)
+			}
+			if req.Tag != "" && !tags[req.Tag] {
+				fail("recipe %s requires unknown tag %s", r.ID, req.Tag)
+			}
+		}
+		errs = append(errs, validateEffects("recipe "+r.ID, r.Effects, false)...)
+	}
+
+	specials := make(map[string]bool)
+	for _, sp := range d.Specials.Specials {
+		if specials[sp.ID] {
+			fail("duplicate special %s", sp.ID)
+		}
+		specials[sp.ID] = true
+		if sp.Count < 1 {
+			fail("special %s must have a count of at least 1", sp.ID)
+		}
+		switch sp.Type {
+		case SpecialWildcard:
+		case SpecialAction:
+			if !hasTrigger(sp.Effects, entity.TriggerOnPlay) {
+				fail("action %s has no on_play effect", sp.ID)
+			}
+		default:
+			fail("special %s has unknown type %q", sp.ID, sp.Type)
+		}
+		errs = append(errs, validateEffects("special "+sp.ID, sp.Effects, false)...)
+	}
+
+	errs = append(errs, validateEffects("deck", d.Deck.Effects, true)...)
+
+	if err := achievement.Validate(d.Deck.Achievements); err != nil {
+		errs = append(errs, err)
+	}
+	for _, a := range d.Deck.Achievements {
+		if a.Goal.Recipe != "" && !recipes[a.Goal.Recipe] {
+			fail("achievement %s counts unknown recipe %s", a.ID, a.Goal.Recipe)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasTrigger(effects []entity.Effect, trigger string) bool {
+	for _, e := range effects {
+		if e.Trigger == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEffects checks the effects of a card, or of the deck itself when
+// deckLevel is set. Targets chosen by the player only make sense when the
+// card is played, and a card can ask for a single kind of chosen target.
+func validateEffects(owner string, effects []entity.Effect, deckLevel bool) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(owner+": "+format, args...))
+	}
+
+	chosen := ""
+	for i, e := range effects {
+		switch e.Trigger {
+		case entity.TriggerOnPlay, entity.TriggerOnDishMade:
+		case entity.TriggerOnFinish:
+			if !deckLevel {
+				fail("effect %d: on_finish is only allowed on the deck", i)
+			}
+		default:
+			fail("effect %d: unknown trigger %q", i, e.Trigger)
+		}
+
+		for _, c := range e.Conditions {
+			switch c.Type {
+			case entity.CondHandSizeAtMost, entity.CondHandSizeAtLeast, entity.CondTableSizeAtLeast:
+				if c.Value < 0 {
+					fail("effect %d: %s needs a value of at least 0", i, c.Type)
+				}
+			case entity.CondFinishPosition:
+				if e.Trigger != entity.TriggerOnFinish {
+					fail("effect %d: %s only works with on_finish", i, c.Type)
+				}
+				if c.Value < 1 {
+					fail("effect %d: %s needs a value of at least 1", i, c.Type)
+				}
+			case entity.CondFirstDish:
+				if e.Trigger != entity.TriggerOnDishMade {
+					fail("effect %d: %s only works with on_dish_made", i, c.Type)
+				}
+			default:
+				fail("effect %d: unknown condition %q", i, c.Type)
+			}
+		}
+
+		if len(e.Do) == 0 {
+			fail("effect %d does nothing", i)
+		}
+		for _, op := range e.Do {
+			if op.Amount < 0 && op.Type != entity.OpScore {
+				fail("effect %d: %s cannot have a negative amount", i, op.Type)
+			}
+
+			target := op.Target
+			if target == "" {
+				target = entity.TargetSelf
+			}
+			switch target {
+			case entity.TargetSelf, entity.TargetNext, entity.TargetAllOpponents:
+			case entity.TargetOpponent, entity.TargetTableCard:
+				if deckLevel || e.Trigger != entity.TriggerOnPlay {
+					fail("effect %d: target %s can only be chosen when the card is played", i, target)
+				}
+				if chosen != "" && chosen != target {
+					fail("effect %d: a card can only ask for one kind of target", i)
+				}
+				chosen = target
+			default:
+				fail("effect %d: unknown target %q", i, op.Target)
+			}
+
+			switch op.Type {
+			case entity.OpDraw, entity.OpDiscard:
+				if target == entity.TargetTableCard {
+					fail("effect %d: %s cannot target a table card", i, op.Type)
+				}
+			case entity.OpSteal, entity.OpSwap:
+				if target == entity.TargetSelf || target == entity.TargetTableCard {
+					fail("effect %d: %s needs another player as target", i, op.Type)
+				}
+			case entity.OpReclaim:
+				if target != entity.TargetTableCard {
+					fail("effect %d: reclaim needs a table_card target", i)
+				}
+			case entity.OpExtraTurn, entity.OpSkip:
+				if op.Target != "" {
+					fail("effect %d: %s takes no target", i, op.Type)
+				}
+			case entity.OpScore:
+				if op.Amount == 0 {
+					fail("effect %d: score needs a non-zero amount", i)
+				}
+				if target == entity.TargetTableCard {
+					fail("effect %d: score cannot target a table card", i)
+				}
+			default:
+				fail("effect %d: unknown op %q", i, op.Type)
+			}
+		}
+	}
+
+	return errs
+}
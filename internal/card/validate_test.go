@@ -0,0 +1,181 @@
+package card
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// validDeck is a small deck that passes validation, cases break one part
+func validDeck() *DeckConfig {
+	return &DeckConfig{
+		Ingredients: IngredientFile{Ingredients: []IngredientConfig{
+			{ID: "I_BEEF", Name: "Beef", Tags: []string{"meat"}},
+			{ID: "I_BASIL", Name: "Basil", Tags: []string{"herb"}},
+		}},
+		Recipes: RecipeFile{Recipes: []RecipeConfig{
+			{ID: "R_PHO", Name: "Pho", Requires: []RequirementConfig{{ID: "I_BEEF", Count: 1}, {Tag: "herb", Count: 1}}},
+		}},
+		Specials: SpecialFile{Specials: []SpecialConfig{
+			{ID: "S_WILD", Name: "Wild", Type: SpecialWildcard, Count: 1},
+			{ID: "S_SKIP", Name: "Skip", Type: SpecialAction, Count: 2, Effects: []entity.Effect{
+				{Trigger: entity.TriggerOnPlay, Do: []entity.Op{{Type: entity.OpSkip}}},
+			}},
+		}},
+		Deck: DeckFile{
+			Effects: []entity.Effect{{
+				Trigger:    entity.TriggerOnFinish,
+				Conditions: []entity.Condition{{Type: entity.CondFinishPosition, Value: 1}},
+				Do:         []entity.Op{{Type: entity.OpScore, Amount: 5}},
+			}},
+			Achievements: []achievement.Definition{
+				{ID: "pho", Name: "Pho", Goal: achievement.Goal{Type: achievement.GoalDishesMade, Recipe: "R_PHO"}},
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	onPlay := func(ops ...entity.Op) []entity.Effect {
+		return []entity.Effect{{Trigger: entity.TriggerOnPlay, Do: ops}}
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(d *DeckConfig)
+		error string // Part of the error, empty for a valid deck
+	}{
+		{name: "valid", edit: func(d *DeckConfig) {}},
+		{
+			name:  "duplicate ingredient",
+			edit:  func(d *DeckConfig) { d.Ingredients.Ingredients[1].ID = "I_BEEF" },
+			error: "duplicate ingredient I_BEEF",
+		},
+		{
+			name:  "unknown ingredient",
+			edit:  func(d *DeckConfig) { d.Recipes.Recipes[0].Requires[0].ID = "I_PORK" },
+			error: "requires unknown ingredient I_PORK",
+		},
+		{
+			name:  "unknown tag",
+			edit:  func(d *DeckConfig) { d.Recipes.Recipes[0].Requires[1].Tag = "spice" },
+			error: "requires unknown tag spice",
+		},
+		{
+			name:  "recipe without requirements",
+			edit:  func(d *DeckConfig) { d.Recipes.Recipes[0].Requires = nil },
+			error: "requires nothing",
+		},
+		{
+			name:  "special without count",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[0].Count = 0 },
+			error: "count of at least 1",
+		},
+		{
+			name:  "unknown special type",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[0].Type = "joker" },
+			error: `unknown type "joker"`,
+		},
+		{
+			name:  "action without on_play",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects[0].Trigger = entity.TriggerOnDishMade },
+			error: "has no on_play effect",
+		},
+		{
+			name:  "on_finish on a card",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects[0].Trigger = entity.TriggerOnFinish },
+			error: "only allowed on the deck",
+		},
+		{
+			name:  "effect doing nothing",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects[0].Do = nil },
+			error: "does nothing",
+		},
+		{
+			name: "chosen target on the deck",
+			edit: func(d *DeckConfig) {
+				d.Deck.Effects = onPlay(entity.Op{Type: entity.OpSteal, Target: entity.TargetOpponent})
+			},
+			error: "can only be chosen when the card is played",
+		},
+		{
+			name: "two kinds of chosen target",
+			edit: func(d *DeckConfig) {
+				d.Specials.Specials[1].Effects = onPlay(
+					entity.Op{Type: entity.OpSteal, Target: entity.TargetOpponent},
+					entity.Op{Type: entity.OpReclaim, Target: entity.TargetTableCard},
+				)
+			},
+			error: "one kind of target",
+		},
+		{
+			name:  "steal from self",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects = onPlay(entity.Op{Type: entity.OpSteal}) },
+			error: "needs another player",
+		},
+		{
+			name:  "reclaim without a table card",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects = onPlay(entity.Op{Type: entity.OpReclaim}) },
+			error: "reclaim needs a table_card target",
+		},
+		{
+			name: "negative draw",
+			edit: func(d *DeckConfig) {
+				d.Specials.Specials[1].Effects = onPlay(entity.Op{Type: entity.OpDraw, Amount: -1})
+			},
+			error: "cannot have a negative amount",
+		},
+		{
+			name:  "score of zero",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects = onPlay(entity.Op{Type: entity.OpScore}) },
+			error: "non-zero amount",
+		},
+		{
+			name:  "unknown op",
+			edit:  func(d *DeckConfig) { d.Specials.Specials[1].Effects = onPlay(entity.Op{Type: "teleport"}) },
+			error: `unknown op "teleport"`,
+		},
+		{
+			name: "finish position on another trigger",
+			edit: func(d *DeckConfig) {
+				d.Specials.Specials[1].Effects[0].Conditions = []entity.Condition{{Type: entity.CondFinishPosition, Value: 1}}
+			},
+			error: "only works with on_finish",
+		},
+		{
+			name:  "achievement of an unknown recipe",
+			edit:  func(d *DeckConfig) { d.Deck.Achievements[0].Goal.Recipe = "R_BUNCHA" },
+			error: "counts unknown recipe R_BUNCHA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := validDeck()
+			tt.edit(d)
+			err := d.Validate()
+			switch {
+			case tt.error == "" && err != nil:
+				t.Errorf("Validate() = %v, want no error", err)
+			case tt.error != "" && (err == nil || !strings.Contains(err.Error(), tt.error)):
+				t.Errorf("Validate() = %v, want an error about %q", err, tt.error)
+			}
+		})
+	}
+}
+
+func TestShippedDecksAreValid(t *testing.T) {
+	for _, theme := range Themes {
+		t.Run(theme, func(t *testing.T) {
+			cfg, err := ReadDeckConfig(theme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+//go:build !js
+
+// Package clipboard copies text to the system clipboard. Desktop builds hand
+// it to the copy tool of the platform, the web build to the browser.
+package clipboard
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no copy tool is installed
+var ErrUnavailable = errors.New("clipboard: no copy tool found")
+
+// tools are tried in order, the first one installed is used
+func tools() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	default:
+		return [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+	}
+}
+
+// Write replaces the content of the clipboard with text
+func Write(text string) error {
+	for _, tool := range tools() {
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, tool[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return ErrUnavailable
+}
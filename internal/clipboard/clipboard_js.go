@@ -0,0 +1,23 @@
+//go:build js
+
+package clipboard
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// ErrUnavailable is returned when the browser has no clipboard API, such as
+// on pages not served over HTTPS
+var ErrUnavailable = errors.New("clipboard: not supported by this browser")
+
+// Write replaces the content of the clipboard with text. The browser copies
+// in the background and may still refuse, that is not reported.
+func Write(text string) error {
+	cb := js.Global().Get("navigator").Get("clipboard")
+	if !cb.Truthy() {
+		return ErrUnavailable
+	}
+	cb.Call("writeText", text)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package daily derives the daily challenge from the date and keeps the
+// results of past days. Everyone playing on the same day gets the same deal
+// and bots that choose the same way.
+package daily
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the history
+const Key = "daily.json"
+
+// Bots seated in the daily challenge
+const Bots = 3
+
+// TurnPass marks a pass in Result.Turns, other values count the dishes made
+const TurnPass = -1
+
+// Date returns the day of t. Days start at midnight UTC so players in every
+// timezone share the same deal.
+func Date(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// Seed derives the seed of the deal, the random effects and the bots from
+// a date
+func Seed(date string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(storage.AppName + "/daily/" + date))
+	return int64(h.Sum64())
+}
+
+// Result of the attempt of one day. It is recorded as soon as the match
+// starts, an attempt left unfinished keeps Position 0.
+type Result struct {
+	Date     string `json:"date"`
+	Position int    `json:"position"` // 1 for the first player to finish
+	Players  int    `json:"players"`
+	Dishes   int    `json:"dishes"`
+	Score    int    `json:"score"`
+	Turns    []int  `json:"turns"` // Dishes made each turn of the player, TurnPass for a pass
+}
+
+func (r *Result) Finished() bool {
+	return r.Position > 0
+}
+
+// Summary describes the result in one line
+func (r *Result) Summary() string {
+	if !r.Finished() {
+		return "Left unfinished"
+	}
+	return fmt.Sprintf("%s of %d, %d dishes, %d points", ordinal(r.Position), r.Players, r.Dishes, r.Score)
+}
+
+// History keeps one result per day
+type History struct {
+	Results []Result `json:"results"` // Oldest first
+}
+
+// LoadHistory reads the saved history, a missing one gives an empty one
+func LoadHistory(store storage.Store) (*History, error) {
+	h := &History{}
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return &History{}, fmt.Errorf("read daily history: %w", err)
+	}
+	return h, nil
+}
+
+func (h *History) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
+
+// Get returns the result of a day, nil if it was not attempted
+func (h *History) Get(date string) *Result {
+	for i := range h.Results {
+		if h.Results[i].Date == date {
+			return &h.Results[i]
+		}
+	}
+	return nil
+}
+
+// Record adds the result of a day or replaces it
+func (h *History) Record(r Result) {
+	if old := h.Get(r.Date); old != nil {
+		*old = r
+		return
+	}
+	h.Results = append(h.Results, r)
+}
+
+// Streak counts the days in a row the challenge was finished, up to today.
+// A streak is not broken before today is over.
+func (h *History) Streak(today string) int {
+	day, err := time.Parse(time.DateOnly, today)
+	if err != nil {
+		return 0
+	}
+	if r := h.Get(today); r == nil || !r.Finished() {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	n := 0
+	for {
+		r := h.Get(day.Format(time.DateOnly))
+		if r == nil || !r.Finished() {
+			return n
+		}
+		n++
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
+// gridWidth is the number of turns in a row of the share grid
+const gridWidth = 5
+
+// Share is the text summary of a result, with a grid of the player's turns:
+// a black square for a pass, white for a card that made no dish, green for
+// a dish and yellow for more than one
+func Share(r Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Food Cards Daily %s\n%s\n", r.Date, r.Summary())
+
+	for i, t := range r.Turns {
+		switch {
+		case t == TurnPass:
+			b.WriteString("⬛")
+		case t == 0:
+			b.WriteString("⬜")
+		case t == 1:
+			b.WriteString("🟩")
+		default:
+			b.WriteString("🟨")
+		}
+		if (i+1)%gridWidth == 0 && i+1 < len(r.Turns) {
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
@@ -0,0 +1,150 @@
+package daily
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	newYork := time.FixedZone("EST", -5*3600)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "utc", t: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), want: "2025-07-01"},
+		{name: "ahead of utc", t: time.Date(2025, 7, 1, 8, 0, 0, 0, tokyo), want: "2025-06-30"},
+		{name: "behind utc", t: time.Date(2025, 7, 1, 20, 0, 0, 0, newYork), want: "2025-07-02"},
+		{name: "midnight", t: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), want: "2025-07-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Date(tt.t); got != tt.want {
+				t.Errorf("Date() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{name: "same day", a: "2025-07-01", b: "2025-07-01", same: true},
+		{name: "next day", a: "2025-07-01", b: "2025-07-02"},
+		{name: "next year", a: "2025-07-01", b: "2026-07-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := Seed(tt.a) == Seed(tt.b); same != tt.same {
+				t.Errorf("Seed(%s) == Seed(%s) is %v, want %v", tt.a, tt.b, same, tt.same)
+			}
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   string
+	}{
+		{
+			name:   "unfinished",
+			result: Result{Date: "2025-07-01", Players: 4},
+			want:   "Food Cards Daily 2025-07-01\nLeft unfinished",
+		},
+		{
+			name:   "one row",
+			result: Result{Date: "2025-07-01", Position: 1, Players: 4, Dishes: 3, Score: 5, Turns: []int{0, 1, TurnPass, 2}},
+			want:   "Food Cards Daily 2025-07-01\n1st of 4, 3 dishes, 5 points\n⬜🟩⬛🟨",
+		},
+		{
+			name:   "full row",
+			result: Result{Date: "2025-07-01", Position: 2, Players: 4, Turns: []int{0, 0, 0, 0, 1}},
+			want:   "Food Cards Daily 2025-07-01\n2nd of 4, 0 dishes, 0 points\n⬜⬜⬜⬜🟩",
+		},
+		{
+			name:   "two rows",
+			result: Result{Date: "2025-07-01", Position: 3, Players: 4, Dishes: 1, Turns: []int{0, 0, 0, 0, 0, 1}},
+			want:   "Food Cards Daily 2025-07-01\n3rd of 4, 1 dishes, 0 points\n⬜⬜⬜⬜⬜\n🟩",
+		},
+		{
+			name:   "no turns",
+			result: Result{Date: "2025-07-01", Position: 4, Players: 4},
+			want:   "Food Cards Daily 2025-07-01\n4th of 4, 0 dishes, 0 points",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Share(tt.result); got != tt.want {
+				t.Errorf("Share() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreak(t *testing.T) {
+	finished := func(date string) Result { return Result{Date: date, Position: 1, Players: 4} }
+	unfinished := func(date string) Result { return Result{Date: date, Players: 4} }
+
+	tests := []struct {
+		name    string
+		results []Result
+		today   string
+		want    int
+	}{
+		{name: "never played", today: "2025-07-03", want: 0},
+		{name: "today only", results: []Result{finished("2025-07-03")}, today: "2025-07-03", want: 1},
+		{
+			name:    "three days",
+			results: []Result{finished("2025-07-01"), finished("2025-07-02"), finished("2025-07-03")},
+			today:   "2025-07-03",
+			want:    3,
+		},
+		{
+			name:    "today not played yet",
+			results: []Result{finished("2025-07-01"), finished("2025-07-02")},
+			today:   "2025-07-03",
+			want:    2,
+		},
+		{
+			name:    "today left unfinished",
+			results: []Result{finished("2025-07-02"), unfinished("2025-07-03")},
+			today:   "2025-07-03",
+			want:    1,
+		},
+		{
+			name:    "missed day",
+			results: []Result{finished("2025-07-01"), finished("2025-07-03")},
+			today:   "2025-07-03",
+			want:    1,
+		},
+		{
+			name:    "across months",
+			results: []Result{finished("2025-06-30"), finished("2025-07-01")},
+			today:   "2025-07-01",
+			want:    2,
+		},
+		{name: "bad date", results: []Result{finished("2025-07-01")}, today: "today", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &History{}
+			for _, r := range tt.results {
+				h.Record(r)
+			}
+			if got := h.Streak(tt.today); got != tt.want {
+				t.Errorf("Streak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
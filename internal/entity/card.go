@@ -5,13 +5,19 @@ type CartType int
 const (
 	CardTypeIngredient CartType = iota
 	CardTypeRecipe
+	CardTypeWildcard
+	CardTypeAction
 )
 
 type Card struct {
 	Entity
-	Type                CartType
-	IngredientID        string   // If Type is CartIngredient, this is the ID of the ingredient
-	RequiredIngredients []string // If Type is CartRecipe, this is the list of required ingredients
+	Type         CartType
+	IngredientID string        // If Type is CartIngredient, this is the ID of the ingredient
+	Tags         []string      // If Type is CartIngredient, these are the categories of the ingredient
+	Requirements []Requirement // If Type is CartRecipe, this is the list of required ingredients
+	RecipeID     string        // If Type is CartRecipe, this is the ID of the recipe
+	Effects      []Effect
+	Description  string
 }
 
 func NewCard(name string, cartType CartType) *Card {
@@ -21,3 +27,14 @@ func NewCard(name string, cartType CartType) *Card {
 		Type:   cartType,
 	}
 }
+
+// IsIngredient reports whether the card can fill a recipe requirement
+func (c *Card) IsIngredient() bool {
+	return c.Type == CardTypeIngredient || c.Type == CardTypeWildcard
+}
+
+// Target is what an action card is played on. Unused fields are left empty.
+type Target struct {
+	PlayerID string
+	CardID   string
+}
@@ -0,0 +1,59 @@
+package entity
+
+// Effect is a declarative rule attached to a card or to the whole deck. When
+// its trigger fires and all conditions hold, the ops run in order.
+type Effect struct {
+	Trigger    string      `json:"trigger"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	Do         []Op        `json:"do"`
+}
+
+// Triggers
+const (
+	TriggerOnPlay     = "on_play"      // The card is played from hand
+	TriggerOnDishMade = "on_dish_made" // The card is used in a dish, or any dish for deck effects
+	TriggerOnFinish   = "on_finish"    // A player finishes, deck effects only
+)
+
+// Condition must hold for an effect to run
+type Condition struct {
+	Type  string `json:"type"`
+	Value int    `json:"value,omitempty"`
+}
+
+// Condition types
+const (
+	CondHandSizeAtMost   = "hand_size_at_most"   // Cards left in the acting player's hand
+	CondHandSizeAtLeast  = "hand_size_at_least"  // Cards left in the acting player's hand
+	CondTableSizeAtLeast = "table_size_at_least" // Cards on the table
+	CondFinishPosition   = "finish_position"     // 1 for the first player to finish
+	CondFirstDish        = "first_dish"          // The dish is the first of the match
+)
+
+// Op is one step of an effect
+type Op struct {
+	Type   string `json:"type"`
+	Amount int    `json:"amount,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// Op types
+const (
+	OpDraw      = "draw"       // Take cards from the discard pile
+	OpDiscard   = "discard"    // Put random cards from hand on the discard pile
+	OpExtraTurn = "extra_turn" // The acting player plays again
+	OpSkip      = "skip"       // The next player loses a turn
+	OpSteal     = "steal"      // Take random cards from the target's hand
+	OpSwap      = "swap"       // Swap a random card with the target
+	OpReclaim   = "reclaim"    // Return a card on the table to the hand of its owner
+	OpScore     = "score"      // Add Amount, which may be negative, to the target's score
+)
+
+// Op targets, an empty target means TargetSelf
+const (
+	TargetSelf         = "self"
+	TargetNext         = "next"
+	TargetOpponent     = "opponent"      // Chosen by the player when the card is played
+	TargetAllOpponents = "all_opponents" // Every unfinished opponent
+	TargetTableCard    = "table_card"    // Chosen by the player when the card is played
+)
@@ -0,0 +1,26 @@
+package entity
+
+// Event reports something that happened in a match, for listeners such as
+// achievements that only watch the game
+type Event struct {
+	Type     string
+	PlayerID string
+	RecipeID string // EventDishMade
+	Card     string // Name of the card played, signalled or the dish made, or the customer of an order, for logs
+	Position int    // EventFinish, 1 for the first player to finish
+	Count    int    // EventPlayResolved, dishes completed by the play. EventOrderServed, the tip
+}
+
+// Event types
+const (
+	EventPass         = "pass"
+	EventCardPlayed   = "card_played" // Its effects and dishes follow
+	EventDishMade     = "dish_made"
+	EventPlayResolved = "play_resolved" // A card was played and everything it caused is resolved
+	EventFinish       = "finish"
+	EventSignal       = "signal"        // A co-op player pointed out an ingredient of their hand
+	EventOrderPlaced  = "order_placed"  // A customer of the restaurant mode came in
+	EventOrderServed  = "order_served"  // A dish made was served to an order, Card is the dish
+	EventOrderExpired = "order_expired" // A customer left without their order
+	EventMatchOver    = "match_over"
+)
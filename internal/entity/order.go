@@ -0,0 +1,41 @@
+package entity
+
+// Order is what a customer asks for in the restaurant mode: dishes to be
+// cooked before they leave
+type Order struct {
+	ID       string
+	Customer string
+	Dishes   []*Card // Recipes still wanted, served ones are removed
+	Deadline int     // Last turn of the table the customer waits for
+	Tip      int     // Points for each dish served
+}
+
+// Wants reports whether the order is still waiting for a dish of the recipe
+func (o *Order) Wants(recipeID string) bool {
+	return o.dishIndex(recipeID) >= 0
+}
+
+// Serve removes a dish of the recipe from the order, it returns false when
+// the order does not want it
+func (o *Order) Serve(recipeID string) bool {
+	i := o.dishIndex(recipeID)
+	if i < 0 {
+		return false
+	}
+	o.Dishes = append(o.Dishes[:i:i], o.Dishes[i+1:]...)
+	return true
+}
+
+// Done reports whether every dish of the order was served
+func (o *Order) Done() bool {
+	return len(o.Dishes) == 0
+}
+
+func (o *Order) dishIndex(recipeID string) int {
+	for i, d := range o.Dishes {
+		if d.RecipeID == recipeID {
+			return i
+		}
+	}
+	return -1
+}
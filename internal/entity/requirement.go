@@ -0,0 +1,33 @@
+package entity
+
+import "slices"
+
+// Requirement is a single slot of a recipe. It is filled either by one exact
+// ingredient or by any ingredient carrying the given tag.
+type Requirement struct {
+	IngredientID string
+	Tag          string
+}
+
+func (r Requirement) IsTag() bool {
+	return r.Tag != ""
+}
+
+// Accepts reports whether the card can fill this requirement. A wildcard
+// fills any requirement.
+func (r Requirement) Accepts(card *Card) bool {
+	if card == nil || !card.IsIngredient() {
+		return false
+	}
+	if card.Type == CardTypeWildcard {
+		return true
+	}
+	if r.IsTag() {
+		return card.HasTag(r.Tag)
+	}
+	return card.IngredientID == r.IngredientID
+}
+
+func (c *Card) HasTag(tag string) bool {
+	return slices.Contains(c.Tags, tag)
+}
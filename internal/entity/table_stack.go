@@ -33,6 +33,11 @@ func (t *TableStack) AddCard(card *Card, playerID string) {
 	t.playOrder = append(t.playOrder, card.ID)
 }
 
+// GetCard returns the card on the table with its owner, or nil
+func (t *TableStack) GetCard(cardID string) *CardOnTable {
+	return t.cards[cardID]
+}
+
 func (t *TableStack) RemoveCard(cardID string) {
 	delete(t.cards, cardID)
 	for i, id := range t.playOrder {
@@ -54,6 +59,18 @@ func (t *TableStack) GetAllCardsInOrder() []*Card {
 	return result
 }
 
+// GetEntriesInOrder returns all cards with their owners in the order they
+// were played
+func (t *TableStack) GetEntriesInOrder() []CardOnTable {
+	var result []CardOnTable
+	for _, id := range t.playOrder {
+		if cardEntry, ok := t.cards[id]; ok {
+			result = append(result, *cardEntry)
+		}
+	}
+	return result
+}
+
 // GetAllCardsInReverseOrder returns all cards in the reverse order they were played
 func (t *TableStack) GetAllCardsInReverseOrder() []*Card {
 	var result []*Card
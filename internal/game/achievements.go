@@ -0,0 +1,55 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// loadAchievements reads the shared and deck achievements and their progress
+func (g *Game) loadAchievements() {
+	defs, err := achievement.LoadDefinitions()
+	if err != nil {
+		fmt.Println("Error loading achievements:", err)
+	}
+	g.Achievements = achievement.NewTracker(defs)
+
+	for _, theme := range card.Themes {
+		cfg, err := card.ReadDeckConfig(theme)
+		if err != nil {
+			fmt.Println("Error reading deck config:", err)
+			continue
+		}
+		g.Achievements.AddDeck(theme, cfg.Deck.Achievements, cfg.RecipeIDs())
+	}
+
+	if err := g.Achievements.Load(g.Store); err != nil {
+		fmt.Println("Error loading achievement progress:", err)
+	}
+}
+
+func (g *Game) saveAchievements() {
+	if err := g.Achievements.Save(g.Store); err != nil {
+		fmt.Println("Error saving achievements:", err)
+	}
+}
+
+// onEngineEvent is called by the engine for everything that happens in a match.
+// Achievements pause for the rest of a match once the player used a hint.
+func (g *Game) onEngineEvent(e entity.Event) {
+	g.MatchLog.Record(g.Engine, e)
+	if g.HintsUsed > 0 {
+		return
+	}
+
+	unlocked := g.Achievements.Handle(e)
+	for _, d := range unlocked {
+		fmt.Println("Achievement unlocked:", d.Name)
+		g.Toast.Show("Achievement unlocked: "+d.Name, d.Description)
+	}
+	if len(unlocked) > 0 || e.Type == entity.EventMatchOver {
+		g.saveAchievements()
+	}
+}
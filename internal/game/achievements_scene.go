@@ -0,0 +1,150 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*AchievementsScene)(nil)
+
+// AchievementsScene is the gallery of every achievement and its progress
+type AchievementsScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+	tiles     []achievementTile
+}
+
+type achievementTile struct {
+	x, y, w, h int
+	unlocked   bool
+}
+
+func NewAchievementsScene() *AchievementsScene {
+	return &AchievementsScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *AchievementsScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+	textFont := g.TextFont(18)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+		colLocked        = color.RGBA{0xAA, 0xAA, 0xAA, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 80
+
+	tracker := g.Achievements
+	title := ui.NewUILabel(cx, startY, fmt.Sprintf("ACHIEVEMENTS %d/%d", tracker.Unlocked(), len(tracker.Definitions)), titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	// Two columns of tiles
+	tileW, tileH := 540, 70
+	gap := 16
+	left := cx - tileW - gap/2
+	top := startY + 40
+	for i, d := range tracker.Definitions {
+		x := left + (i%2)*(tileW+gap)
+		y := top + (i/2)*(tileH+gap/2)
+		p := tracker.Get(d.ID)
+		s.tiles = append(s.tiles, achievementTile{x: x, y: y, w: tileW, h: tileH, unlocked: p.Unlocked})
+
+		name := ui.NewUILabel(x+12, y+28, d.Name, defaultFont)
+		desc := ui.NewUILabel(x+12, y+56, d.Description, textFont)
+		progress := ui.NewUILabel(x+tileW-110, y+28, fmt.Sprintf("%d/%d", min(p.Count, tracker.Target(d)), tracker.Target(d)), textFont)
+		if p.Unlocked {
+			name.TextColor = colTitle
+			progress.Text = "Unlocked"
+		} else {
+			name.TextColor = colLocked
+			desc.TextColor = colLocked
+			progress.TextColor = colLocked
+			if d.Hidden {
+				desc.Text = "???"
+			}
+		}
+		if d.Deck != "" {
+			desc.Text += fmt.Sprintf(" (%s deck)", d.Deck)
+		}
+		for _, l := range []*ui.UILabel{name, desc, progress} {
+			s.uiManager.AddElement(l)
+			s.elements = append(s.elements, l)
+		}
+	}
+
+	backBtn := ui.NewUIButton(cx-100, ScreenH-80, 200, 50, "Back", defaultFont)
+	backBtn.BackgroundColor = colButtonBg
+	backBtn.HoverColor = colButtonHover
+	backBtn.PressedColor = colButtonPressed
+	backBtn.TextColor = colButtonText
+	backBtn.OnClick = func() {
+		g.PopScene()
+	}
+	s.uiManager.AddElement(backBtn)
+	s.elements = append(s.elements, backBtn)
+}
+
+func (s *AchievementsScene) Exit(g *Game) {
+}
+
+func (s *AchievementsScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *AchievementsScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+
+	for _, t := range s.tiles {
+		bg := color.RGBA{0x20, 0x20, 0x20, 0xC0}
+		border := color.RGBA{0x66, 0x66, 0x66, 0xFF}
+		if t.unlocked {
+			bg = color.RGBA{0x36, 0x55, 0x34, 0xE0}
+			border = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+		}
+		vector.DrawFilledRect(screen, float32(t.x), float32(t.y), float32(t.w), float32(t.h), bg, false)
+		vector.StrokeRect(screen, float32(t.x), float32(t.y), float32(t.w), float32(t.h), 2, border, false)
+	}
+}
+
+func (s *AchievementsScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
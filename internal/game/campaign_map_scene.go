@@ -0,0 +1,235 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/thanhfphan/ebitengj2025/internal/campaign"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*CampaignMapScene)(nil)
+
+// CampaignMapScene shows the chapters of the campaign on a map, the route
+// between them and the details of the chapter picked
+type CampaignMapScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+
+	chapters []*campaign.Chapter
+	selected int
+	unlocked []bool
+
+	nameLabel   *ui.UILabel
+	detailLabel *ui.UILabel
+	playBtn     *ui.UIButton
+}
+
+func NewCampaignMapScene() *CampaignMapScene {
+	return &CampaignMapScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *CampaignMapScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colLocked        = color.RGBA{0x99, 0x99, 0x99, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	btnH := 50
+
+	makeBtn := func(label string, x, y, w int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, btnH, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+	makeLabel := func(x, y int, text string, size float64) *ui.UILabel {
+		l := ui.NewUILabel(x, y, text, g.TextFont(size))
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	chapters, err := campaign.List()
+	if err != nil {
+		fmt.Println("Error loading campaign:", err)
+	}
+	s.chapters = chapters
+
+	title := ui.NewUILabel(ScreenW/2, 80, fmt.Sprintf("CAMPAIGN %d/%d", g.Campaign.Total(), campaign.MaxGoals*len(chapters)), titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	if err != nil {
+		message := ui.NewUILabel(ScreenW/2, 140, "The campaign could not be loaded.", g.TextFont(18))
+		message.AlignCenter()
+		s.uiManager.AddElement(message)
+		s.elements = append(s.elements, message)
+	}
+
+	// Chapters on the map, the first one not beaten yet is picked
+	nodeW := 200
+	s.selected = -1
+	s.unlocked = make([]bool, len(chapters))
+	for i, c := range chapters {
+		s.unlocked[i] = g.Campaign.Unlocked(chapters, i)
+		if s.unlocked[i] && (s.selected < 0 || !g.Campaign.Beaten[chapters[s.selected].ID]) {
+			s.selected = i
+		}
+
+		b := makeBtn(c.Name, c.Map.X-nodeW/2, c.Map.Y-btnH/2, nodeW, func() {
+			s.selectChapter(i)
+		})
+		if !s.unlocked[i] {
+			b.BackgroundColor = colLocked
+			b.HoverColor = colLocked
+			b.PressedColor = colLocked
+		}
+
+		status := makeLabel(c.Map.X, c.Map.Y+btnH/2+26, chapterStatus(g, c, s.unlocked[i]), 18)
+		status.AlignCenter()
+		status.TextColor = colTitle
+	}
+
+	// Details of the chapter picked
+	panelX := ScreenW/2 + 100
+	s.nameLabel = makeLabel(panelX, 170, "", 32)
+	s.nameLabel.TextColor = colTitle
+	s.detailLabel = makeLabel(panelX, 210, "", 18)
+	s.detailLabel.Wrap = ScreenW - panelX - 60
+	s.playBtn = makeBtn("Play", panelX, ScreenH-160, 200, func() {
+		c := s.chapters[s.selected]
+		var next *campaign.Chapter
+		if s.selected+1 < len(s.chapters) {
+			next = s.chapters[s.selected+1]
+		}
+		g.PopScene() // Leave the main menu below for the chapter
+		g.ReplaceScene(NewCampaignScene(c, next))
+	})
+	s.selectChapter(max(s.selected, 0))
+
+	makeBtn("Back", ScreenW/2-100, ScreenH-80, 200, func() {
+		g.PopScene()
+	})
+}
+
+func chapterStatus(g *Game, c *campaign.Chapter, unlocked bool) string {
+	switch {
+	case !unlocked:
+		return "Locked"
+	case !g.Campaign.Beaten[c.ID]:
+		return "Not beaten"
+	}
+	return fmt.Sprintf("%d of %d stars", g.Campaign.Stars[c.ID], len(c.Goals))
+}
+
+// selectChapter shows the details of a chapter, a locked one cannot be
+// played
+func (s *CampaignMapScene) selectChapter(i int) {
+	if i >= len(s.chapters) {
+		s.nameLabel.Text = ""
+		s.detailLabel.Text = ""
+		s.playBtn.SetVisible(false)
+		return
+	}
+	s.selected = i
+	c := s.chapters[i]
+
+	lines := []string{c.Description, ""}
+	for _, t := range c.Twists {
+		lines = append(lines, t.Name+": "+t.Description)
+	}
+	if len(c.Twists) > 0 {
+		lines = append(lines, "")
+	}
+
+	var bots []string
+	for _, b := range c.Bots {
+		bots = append(bots, b.Name)
+	}
+	lines = append(lines, "Opponents: "+strings.Join(bots, ", "), "", "Goals, the first one beats the chapter:")
+	for _, goal := range c.Goals {
+		lines = append(lines, "- "+goal.Text())
+	}
+	if !s.unlocked[i] {
+		lines = append(lines, "", fmt.Sprintf("Beat %s to open this chapter.", s.chapters[i-1].Name))
+	}
+
+	s.nameLabel.Text = c.Name
+	s.detailLabel.Text = strings.Join(lines, "\n")
+	s.playBtn.SetVisible(s.unlocked[i])
+}
+
+func (s *CampaignMapScene) Exit(g *Game) {
+}
+
+func (s *CampaignMapScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *CampaignMapScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+
+	// The route between chapters, the stretches already travelled in gold
+	for i := 1; i < len(s.chapters); i++ {
+		from, to := s.chapters[i-1].Map, s.chapters[i].Map
+		col := color.RGBA{0x99, 0x99, 0x99, 0xFF}
+		if s.unlocked[i] {
+			col = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+		}
+		vector.StrokeLine(screen, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), 6, col, true)
+	}
+
+	// Ring around the chapter picked
+	if s.selected >= 0 && s.selected < len(s.chapters) {
+		p := s.chapters[s.selected].Map
+		vector.StrokeRect(screen, float32(p.X-106), float32(p.Y-31), 212, 62, 3, color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}, false)
+	}
+}
+
+func (s *CampaignMapScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/campaign"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*CampaignMatch)(nil)
+
+// CampaignMatch plays a chapter of the campaign against its lineup. It is
+// never saved, the stars and the chapter beaten are kept once it ends.
+type CampaignMatch struct {
+	*LocalMatch
+	chapter  *campaign.Chapter
+	next     *campaign.Chapter // Opened by beating the chapter, nil for the last one
+	stars    int
+	beaten   bool
+	unlocked bool // The match opened the next chapter
+	err      error
+}
+
+func NewCampaignMatch(c, next *campaign.Chapter) *CampaignMatch {
+	return &CampaignMatch{
+		LocalMatch: &LocalMatch{bots: len(c.Bots)},
+		chapter:    c,
+		next:       next,
+	}
+}
+
+func (m *CampaignMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	hands, err := g.setupChapterGameData(m.chapter)
+	if err != nil {
+		m.err = fmt.Errorf("chapter %s: %w", m.chapter.ID, err)
+		for _, hand := range hands {
+			g.CurrentUIManager.RemoveElement(hand)
+		}
+		return nil, true
+	}
+	return hands, true
+}
+
+func (m *CampaignMatch) Err() error { return m.err }
+
+// Update leaves the engine alone when the chapter could not be dealt
+func (m *CampaignMatch) Update() {
+	if m.err != nil {
+		return
+	}
+	m.LocalMatch.Update()
+}
+
+// Outcome of the player so far
+func (m *CampaignMatch) Outcome() campaign.Outcome {
+	id := m.g.Player.ID
+	return campaign.Outcome{
+		Position: slices.Index(m.g.TurnManager.FinishedOrder(), id) + 1,
+		Dishes:   len(m.g.Engine.Dishes[id]),
+		Score:    m.g.Engine.Score(id),
+	}
+}
+
+// Stars earned and whether the chapter was beaten, known once the match
+// ended
+func (m *CampaignMatch) Stars() (int, bool) { return m.stars, m.beaten }
+
+// Unlocked returns the chapter the match opened, nil if it opened none
+func (m *CampaignMatch) Unlocked() *campaign.Chapter {
+	if !m.unlocked {
+		return nil
+	}
+	return m.next
+}
+
+// End keeps the stars and the chapter beaten, and records the match in the
+// profile
+func (m *CampaignMatch) End() {
+	m.stars, m.beaten = m.chapter.Stars(m.Outcome())
+	m.unlocked = m.beaten && m.next != nil && !m.g.Campaign.Beaten[m.chapter.ID]
+	if m.g.Campaign.Record(m.chapter.ID, m.stars, m.beaten) {
+		if err := m.g.Campaign.Save(m.g.Store); err != nil {
+			fmt.Println("Error saving campaign progress:", err)
+		}
+	}
+	m.g.RecordMatch()
+}
+
+// Leave gives the match up, nothing is kept
+func (m *CampaignMatch) Leave() {}
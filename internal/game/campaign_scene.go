@@ -0,0 +1,104 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/internal/campaign"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*CampaignScene)(nil)
+
+// CampaignScene plays a chapter of the campaign, its twists and goals are
+// shown beside the table
+type CampaignScene struct {
+	*PlayingScene
+	chapter      *campaign.Chapter
+	next         *campaign.Chapter
+	match        *CampaignMatch
+	chapterLabel *ui.UILabel
+}
+
+func NewCampaignScene(c, next *campaign.Chapter) *CampaignScene {
+	m := NewCampaignMatch(c, next)
+	return &CampaignScene{
+		PlayingScene: NewMatchScene(m),
+		chapter:      c,
+		next:         next,
+		match:        m,
+	}
+}
+
+func (s *CampaignScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.chapterLabel != nil { // Back from the settings
+		return
+	}
+
+	s.chapterLabel = ui.NewUILabel(20, 110, "", g.TextFont(18))
+	s.chapterLabel.Wrap = 300
+	s.uiManager.AddElement(s.chapterLabel)
+	s.elements = append(s.elements, s.chapterLabel)
+
+	s.gameOverMenu.newGameBtn.Text = "Retry"
+	s.gameOverMenu.newGameBtn.OnClick = func() {
+		g.ReplaceScene(NewCampaignScene(s.chapter, s.next))
+	}
+	s.gameOverMenu.menuBtn.Text = "Chapter Map"
+	s.gameOverMenu.menuBtn.OnClick = func() {
+		g.PopScene()
+		g.PushScene(NewMainMenuScene())
+		g.PushScene(NewCampaignMapScene())
+	}
+}
+
+func (s *CampaignScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+
+	if s.match.Err() != nil {
+		s.chapterLabel.SetVisible(false)
+		return
+	}
+	s.chapterLabel.SetVisible(!s.isPaused && !s.gameOverMenu.visible)
+	s.chapterLabel.Text = s.chapterText()
+
+	if s.gameOverMenu.visible {
+		stars, beaten := s.match.Stars()
+		s.gameOverMenu.titleLabel.Text = "CHAPTER NOT BEATEN"
+		if beaten {
+			s.gameOverMenu.titleLabel.Text = "CHAPTER BEATEN"
+		}
+		s.gameOverMenu.resultLabel.Text = fmt.Sprintf("%s: %d of %d stars", s.chapter.Name, stars, len(s.chapter.Goals))
+		if next := s.match.Unlocked(); next != nil {
+			s.gameOverMenu.resultLabel.Text += ", " + next.Name + " is open"
+		}
+	}
+}
+
+func (s *CampaignScene) chapterText() string {
+	lines := []string{s.chapter.Name, ""}
+	for _, t := range s.chapter.Twists {
+		lines = append(lines, t.Name+": "+t.Description)
+	}
+	if len(s.chapter.Twists) > 0 {
+		lines = append(lines, "")
+	}
+
+	o := s.match.Outcome()
+	lines = append(lines, "Goals, the first one beats the chapter:")
+	for _, goal := range s.chapter.Goals {
+		line := "- " + goal.Text()
+		switch goal.Type {
+		case campaign.GoalDishes:
+			line += fmt.Sprintf(" (%d)", o.Dishes)
+		case campaign.GoalScore:
+			line += fmt.Sprintf(" (%d)", o.Score)
+		}
+		if goal.Met(o) {
+			line += ", done"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
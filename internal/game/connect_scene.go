@@ -0,0 +1,202 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/netplay"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*ConnectScene)(nil)
+
+// DefaultServer is the address of a match server started with default flags
+const DefaultServer = "localhost:7777"
+
+// ConnectScene asks for a match server and opens a session with it
+type ConnectScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+
+	serverInput *ui.UITextInput
+	nameInput   *ui.UITextInput
+	statusLabel *ui.UILabel
+	connectBtn  *ui.UIButton
+
+	result chan connectResult // Set while dialling
+}
+
+type connectResult struct {
+	link *serverLink
+	err  error
+}
+
+func NewConnectScene() *ConnectScene {
+	return &ConnectScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *ConnectScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 140
+	fieldW, fieldH := 420, 50
+
+	makeBtn := func(label string, x, y int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, 200, 50, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	addLabel := func(x, y int, text string) *ui.UILabel {
+		l := ui.NewUILabel(x, y, text, defaultFont)
+		l.TextColor = colTitle
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	title := ui.NewUILabel(cx, startY, "JOIN ONLINE", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	y := startY + 80
+	addLabel(cx-fieldW/2, y, "Server")
+	s.serverInput = ui.NewUITextInput(cx-fieldW/2, y+16, fieldW, fieldH, defaultFont)
+	s.serverInput.Text = DefaultServer
+	s.serverInput.MaxLength = 64
+	s.uiManager.AddElement(s.serverInput)
+	s.elements = append(s.elements, s.serverInput)
+
+	y += 110
+	addLabel(cx-fieldW/2, y, "Name")
+	s.nameInput = ui.NewUITextInput(cx-fieldW/2, y+16, fieldW, fieldH, defaultFont)
+	s.nameInput.Placeholder = "Player"
+	s.nameInput.MaxLength = 16
+	s.uiManager.AddElement(s.nameInput)
+	s.elements = append(s.elements, s.nameInput)
+
+	y += 120
+	s.statusLabel = ui.NewUILabel(cx, y, "", defaultFont)
+	s.statusLabel.AlignCenter()
+	s.statusLabel.TextColor = colTitle
+	s.uiManager.AddElement(s.statusLabel)
+	s.elements = append(s.elements, s.statusLabel)
+
+	y += 50
+	s.connectBtn = makeBtn("Connect", cx-210, y, func() {
+		s.connect()
+	})
+	makeBtn("Back", cx+10, y, func() {
+		g.PopScene()
+	})
+}
+
+// connect dials the server without blocking the game loop, Update picks up
+// the result
+func (s *ConnectScene) connect() {
+	if s.result != nil {
+		return
+	}
+
+	addr := strings.TrimSpace(s.serverInput.Text)
+	if addr == "" {
+		s.statusLabel.Text = "Enter the address of a server."
+		return
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "ws://" + addr + "/ws"
+	}
+	name := strings.TrimSpace(s.nameInput.Text)
+
+	s.statusLabel.Text = "Connecting..."
+	s.connectBtn.SetVisible(false)
+	result := make(chan connectResult, 1)
+	s.result = result
+	go func() {
+		dial := func() (netplay.Conn, error) {
+			return netplay.DialWebSocket(addr)
+		}
+		link, err := dialServerLink(dial, name)
+		result <- connectResult{link: link, err: err}
+	}()
+}
+
+func (s *ConnectScene) Exit(g *Game) {
+}
+
+func (s *ConnectScene) Update(g *Game) {
+	if s.result != nil {
+		select {
+		case r := <-s.result:
+			s.result = nil
+			s.connectBtn.SetVisible(true)
+			if r.err != nil {
+				fmt.Println("Error connecting to server:", r.err)
+				s.statusLabel.Text = "Could not connect: " + r.err.Error()
+				return
+			}
+			g.ReplaceScene(NewLobbyScene(r.link))
+			return
+		default:
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		s.connect()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) && s.result == nil {
+		g.PopScene()
+	}
+}
+
+func (s *ConnectScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *ConnectScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
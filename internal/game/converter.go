@@ -1,60 +1,97 @@
 package game
 
 import (
+	"github.com/thanhfphan/ebitengj2025/internal/card"
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
 	"github.com/thanhfphan/ebitengj2025/internal/view"
 )
 
-func ToViewCard(card *entity.Card) view.Card {
+func ToViewCard(c *entity.Card) view.Card {
 	cardType := "ingredient"
-	if card.Type == entity.CardTypeRecipe {
+	switch c.Type {
+	case entity.CardTypeRecipe:
 		cardType = "recipe"
+	case entity.CardTypeWildcard:
+		cardType = "wildcard"
+	case entity.CardTypeAction:
+		cardType = "action"
+	}
+
+	requirements := make([]view.Requirement, 0, len(c.Requirements))
+	for _, req := range c.Requirements {
+		requirements = append(requirements, view.Requirement{
+			IngredientID: req.IngredientID,
+			Tag:          req.Tag,
+		})
 	}
 
 	return view.Card{
-		ID:                     card.ID,
-		Type:                   cardType,
-		Name:                   card.Name,
-		Icon:                   "", // TODO: Extract icon from card data
-		IngredientID:           card.IngredientID,
-		RequiredIngredientIDs:  card.RequiredIngredients,
-		CurrentIngredientCount: make(map[string]bool),
+		ID:                 c.ID,
+		Type:               cardType,
+		Name:               c.Name,
+		Icon:               "", // TODO: Extract icon from card data
+		Description:        c.Description,
+		IngredientID:       c.IngredientID,
+		Tags:               c.Tags,
+		Requirements:       requirements,
+		FilledRequirements: make([]bool, len(requirements)),
 	}
 }
 
+// ToViewHandCard converts a card in hand, marking which requirements of a
+// recipe are already covered by the ingredients on the table
+func ToViewHandCard(c *entity.Card, stack *entity.TableStack) view.Card {
+	result := ToViewCard(c)
+	if c.Type == entity.CardTypeRecipe {
+		markFilledRequirements(&result, c, stack.GetAllCardsInReverseOrder())
+	}
+	return result
+}
+
 // ToViewTableStack converts an entity.TableStack to a view.TableStack
 func ToViewTableStack(stack *entity.TableStack) view.TableStack {
 	result := view.TableStack{
-		MapRecipes:         make(map[string]view.Card),
-		MapIngredients:     make(map[string]view.Card),
-		MapIngredientsByID: make(map[string]bool),
-		StackRecipes:       []string{},
+		MapRecipes:     make(map[string]view.Card),
+		MapIngredients: make(map[string]view.Card),
+		StackRecipes:   []string{},
 	}
 
-	for _, card := range stack.GetCardsByType(entity.CardTypeIngredient) {
-		if card.IngredientID == "" {
+	for _, c := range stack.GetCardsByType(entity.CardTypeIngredient) {
+		if c.IngredientID == "" {
 			// should not happen
 			panic("Ingredient card has no ingredient ID")
 		}
-		result.MapIngredients[card.ID] = ToViewCard(card)
-		result.MapIngredientsByID[card.IngredientID] = true
+		result.MapIngredients[c.ID] = ToViewCard(c)
+	}
+	for _, c := range stack.GetCardsByType(entity.CardTypeWildcard) {
+		result.MapIngredients[c.ID] = ToViewCard(c)
 	}
 
-	for _, card := range stack.GetAllCardsInReverseOrder() {
-		if card.Type != entity.CardTypeRecipe {
+	cards := stack.GetAllCardsInReverseOrder()
+	for _, c := range cards {
+		if c.Type != entity.CardTypeRecipe {
 			continue
 		}
 
-		recipeCard := ToViewCard(card)
-		for _, reqID := range recipeCard.RequiredIngredientIDs {
-			if _, has := result.MapIngredientsByID[reqID]; has {
-				// Mark as available if the ingredient is on the table
-				recipeCard.CurrentIngredientCount[reqID] = true
-			}
-		}
+		recipeCard := ToViewCard(c)
+		// Mark as available if the ingredient is on the table
+		markFilledRequirements(&recipeCard, c, cards)
 		result.MapRecipes[recipeCard.ID] = recipeCard
 		result.StackRecipes = append(result.StackRecipes, recipeCard.ID)
 	}
 
 	return result
 }
+
+func markFilledRequirements(v *view.Card, recipe *entity.Card, cards []*entity.Card) {
+	ingredients := make([]*entity.Card, 0, len(cards))
+	for _, c := range cards {
+		if c.IsIngredient() {
+			ingredients = append(ingredients, c)
+		}
+	}
+
+	for i, idx := range card.MatchRequirements(recipe.Requirements, ingredients) {
+		v.FilledRequirements[i] = idx >= 0
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*CoopMatch)(nil)
+
+var errNoHintsInCoop = errors.New("signal your ingredients to help the table instead")
+
+// CoopLevel is a difficulty of the co-op mode
+type CoopLevel struct {
+	Name     string
+	SetAside int // Cards of the deck left out of the deal
+	Target   int // Dishes the table must cook
+	Signals  int
+}
+
+// CoopLevels from the easiest
+var CoopLevels = []CoopLevel{
+	{Name: "Relaxed", SetAside: 0, Target: 8, Signals: 4},
+	{Name: "Busy", SetAside: 4, Target: 7, Signals: 3},
+	{Name: "Rush", SetAside: 8, Target: 6, Signals: 2},
+}
+
+// CoopMatch seats the player with cooperative bots, the table cooks toward
+// one target and wins or loses together. Game.Engine is back to the regular
+// rules once the match ends or is left.
+type CoopMatch struct {
+	*LocalMatch
+	level CoopLevel
+
+	won       bool
+	dishes    int
+	teamScore int
+}
+
+func NewCoopMatch(level CoopLevel) *CoopMatch {
+	return &CoopMatch{
+		LocalMatch: &LocalMatch{bots: DefaultBots},
+		level:      level,
+	}
+}
+
+func (m *CoopMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	return g.setupCoopGameData(m.bots, m.level), true
+}
+
+func (m *CoopMatch) Level() CoopLevel { return m.level }
+
+// Coop is the state of the table, nil once the match is over
+func (m *CoopMatch) Coop() *rules.Coop { return m.g.Engine.Coop }
+
+// Signal points out an ingredient of the player's hand to the table
+func (m *CoopMatch) Signal(cardID string) error {
+	return m.g.Engine.Signal(m.g.Player.ID, cardID)
+}
+
+func (m *CoopMatch) Hint() (*Hint, error) { return nil, errNoHintsInCoop }
+
+// Result of the table, known once the match ended
+func (m *CoopMatch) Result() (won bool, dishes, teamScore int) {
+	return m.won, m.dishes, m.teamScore
+}
+
+// End keeps the result and puts the engine back to the regular rules
+func (m *CoopMatch) End() {
+	m.won = m.g.Engine.CoopWon()
+	m.dishes = m.g.Engine.DishesMade
+	m.teamScore = m.g.Engine.TeamScore()
+	m.g.Engine.Coop = nil
+}
+
+// Leave gives the match up
+func (m *CoopMatch) Leave() {
+	m.g.Engine.Coop = nil
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*CoopScene)(nil)
+
+// CoopScene plays a co-op match. The target, the signals and the shared
+// score are shown beside the table, the player signals the selected card.
+type CoopScene struct {
+	*PlayingScene
+	match     *CoopMatch
+	coopLabel *ui.UILabel
+	signalBtn *ui.UIButton
+}
+
+func NewCoopScene(level CoopLevel) *CoopScene {
+	m := NewCoopMatch(level)
+	return &CoopScene{
+		PlayingScene: NewMatchScene(m),
+		match:        m,
+	}
+}
+
+func (s *CoopScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.coopLabel != nil { // Back from the settings
+		return
+	}
+
+	s.coopLabel = ui.NewUILabel(20, 110, "", g.TextFont(18))
+	s.coopLabel.Wrap = 300
+	s.uiManager.AddElement(s.coopLabel)
+	s.elements = append(s.elements, s.coopLabel)
+
+	s.signalBtn = ui.NewUIButton(ScreenW/2+410, 650, 100, 40, "Signal", g.AssetManager.GetFont("nunito", 24))
+	s.signalBtn.OnClick = func() {
+		s.signal()
+	}
+	s.signalBtn.SetVisible(false)
+	s.uiManager.AddElement(s.signalBtn)
+	s.elements = append(s.elements, s.signalBtn)
+
+	s.gameOverMenu.newGameBtn.Text = "Play Again"
+	s.gameOverMenu.newGameBtn.OnClick = func() {
+		g.ReplaceScene(NewCoopScene(s.match.Level()))
+	}
+	s.gameOverMenu.menuBtn.Text = "Co-op"
+	s.gameOverMenu.menuBtn.OnClick = func() {
+		g.PopScene()
+		g.PushScene(NewMainMenuScene())
+		g.PushScene(NewCoopSelectScene())
+	}
+}
+
+// signal points out the selected card, the reason is shown where hints go
+// when it cannot be
+func (s *CoopScene) signal() {
+	cardID := s.playerHand.GetSelectedCardID()
+	if cardID == "" {
+		s.hintLabel.Text = "Select an ingredient to signal"
+		s.hintLabel.SetVisible(true)
+		return
+	}
+	s.clearHint()
+	if err := s.match.Signal(cardID); err != nil {
+		s.hintLabel.Text = err.Error()
+		s.hintLabel.SetVisible(true)
+	}
+}
+
+func (s *CoopScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+	s.hintBtn.SetVisible(false)
+
+	coop := s.match.Coop()
+	s.signalBtn.SetVisible(coop != nil && coop.Signals > 0 && s.playBtn.IsVisible())
+	s.coopLabel.SetVisible(coop != nil && !s.isPaused && !s.gameOverMenu.visible)
+	if coop != nil {
+		s.coopLabel.Text = s.coopText(g)
+	}
+
+	if s.gameOverMenu.visible {
+		won, dishes, teamScore := s.match.Result()
+		s.gameOverMenu.titleLabel.Text = "OUT OF CARDS"
+		if won {
+			s.gameOverMenu.titleLabel.Text = "TARGET COOKED"
+		}
+		s.gameOverMenu.resultLabel.Text = fmt.Sprintf("%d of %d dishes, team score %d", dishes, s.match.Level().Target, teamScore)
+	}
+}
+
+func (s *CoopScene) coopText(g *Game) string {
+	level := s.match.Level()
+	coop := s.match.Coop()
+	lines := []string{
+		"Co-op: " + level.Name,
+		fmt.Sprintf("Cook %d dishes together before the hands run out.", level.Target),
+		"",
+		fmt.Sprintf("Dishes: %d of %d", g.Engine.DishesMade, level.Target),
+		fmt.Sprintf("Signals left: %d", coop.Signals),
+		fmt.Sprintf("Team score: %d", g.Engine.TeamScore()),
+	}
+
+	var signals []string
+	for _, p := range g.Players {
+		c := g.Engine.SignalledCard(p.ID)
+		if c == nil {
+			continue
+		}
+		name := p.Name
+		if p == g.Player {
+			name = "You"
+		}
+		signals = append(signals, fmt.Sprintf("%s: %s", name, c.Name))
+	}
+	if len(signals) > 0 {
+		lines = append(lines, "", "Signalled")
+		lines = append(lines, signals...)
+	}
+	return strings.Join(lines, "\n")
+}
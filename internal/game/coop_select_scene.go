@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*CoopSelectScene)(nil)
+
+// CoopSelectScene lists the levels of the co-op mode
+type CoopSelectScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+}
+
+func NewCoopSelectScene() *CoopSelectScene {
+	return &CoopSelectScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *CoopSelectScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 100
+	btnW, btnH := 300, 50
+
+	makeBtn := func(label string, x, y, w int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, btnH, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	title := ui.NewUILabel(cx, startY, "CO-OP", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	intro := ui.NewUILabel(cx, startY+50, "Everyone at the table plays together. Cook the target before the hands run out, and spend signals to point out an ingredient you hold.", g.TextFont(18))
+	intro.AlignCenter()
+	intro.Wrap = 800
+	s.uiManager.AddElement(intro)
+	s.elements = append(s.elements, intro)
+
+	y := startY + 130
+	for _, level := range CoopLevels {
+		makeBtn(level.Name, cx-btnW-20, y, btnW, func() {
+			g.PopScene() // Leave the main menu below for the match
+			g.ReplaceScene(NewCoopScene(level))
+		})
+
+		text := fmt.Sprintf("Cook %d dishes, %d signals", level.Target, level.Signals)
+		if level.SetAside > 0 {
+			text += fmt.Sprintf(", %d cards left out of the deal", level.SetAside)
+		}
+		info := ui.NewUILabel(cx, y+33, text, g.TextFont(18))
+		info.TextColor = colTitle
+		s.uiManager.AddElement(info)
+		s.elements = append(s.elements, info)
+		y += btnH + 16
+	}
+
+	makeBtn("Back", cx-100, ScreenH-80, 200, func() {
+		g.PopScene()
+	})
+}
+
+func (s *CoopSelectScene) Exit(g *Game) {
+}
+
+func (s *CoopSelectScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *CoopSelectScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *CoopSelectScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/daily"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*DailyMatch)(nil)
+
+var errNoHintsInDaily = errors.New("hints are off in the daily challenge")
+
+// DailyMatch is the challenge of one day against seeded bots. It counts as
+// the attempt of the day as soon as it is dealt and is never saved, leaving
+// it gives it up.
+type DailyMatch struct {
+	*LocalMatch
+	result daily.Result
+}
+
+// newDailyBot makes the bots of the challenge. They stay easy bots whatever
+// bot the game was started with, so everyone faces the same table.
+func newDailyBot() ai.Bot { return ai.NewEasyBot() }
+
+func NewDailyMatch(date string) *DailyMatch {
+	return &DailyMatch{
+		LocalMatch: &LocalMatch{bots: daily.Bots},
+		result:     daily.Result{Date: date, Players: daily.Bots + 1},
+	}
+}
+
+func (m *DailyMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	hands := g.setupSeededGameData(m.bots, daily.Seed(m.result.Date), newDailyBot)
+	g.matchInProgress = false
+	m.record()
+	return hands, true
+}
+
+// Result of the attempt so far
+func (m *DailyMatch) Result() daily.Result { return m.result }
+
+func (m *DailyMatch) Play(cardID string, target entity.Target) error {
+	before := len(m.g.Engine.Dishes[m.g.Player.ID])
+	if err := m.LocalMatch.Play(cardID, target); err != nil {
+		return err
+	}
+	m.result.Turns = append(m.result.Turns, len(m.g.Engine.Dishes[m.g.Player.ID])-before)
+	return nil
+}
+
+func (m *DailyMatch) Pass() error {
+	if err := m.LocalMatch.Pass(); err != nil {
+		return err
+	}
+	m.result.Turns = append(m.result.Turns, daily.TurnPass)
+	return nil
+}
+
+func (m *DailyMatch) Hint() (*Hint, error) { return nil, errNoHintsInDaily }
+
+// End records the result in the history and the profile, the saved match
+// of a regular game is left alone
+func (m *DailyMatch) End() {
+	id := m.g.Player.ID
+	m.result.Position = slices.Index(m.g.TurnManager.FinishedOrder(), id) + 1
+	m.result.Dishes = len(m.g.Engine.Dishes[id])
+	m.result.Score = m.g.Engine.Score(id)
+	m.record()
+	m.g.RecordMatch()
+}
+
+// Leave keeps the turns played, the attempt stays unfinished
+func (m *DailyMatch) Leave() {
+	if m.result.Finished() {
+		return
+	}
+	m.record()
+}
+
+func (m *DailyMatch) record() {
+	m.g.Daily.Record(m.result)
+	if err := m.g.Daily.Save(m.g.Store); err != nil {
+		fmt.Println("Error saving daily history:", err)
+	}
+}
@@ -0,0 +1,221 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/clipboard"
+	"github.com/thanhfphan/ebitengj2025/internal/daily"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var (
+	_ Scene = (*DailyMenuScene)(nil)
+	_ Scene = (*DailyScene)(nil)
+)
+
+// dailyHistoryShown is the number of past days listed in the daily menu
+const dailyHistoryShown = 7
+
+// DailyMenuScene shows the challenge of the day, the result once played and
+// the results of the last days
+type DailyMenuScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+}
+
+func NewDailyMenuScene() *DailyMenuScene {
+	return &DailyMenuScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *DailyMenuScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 100
+	btnW, btnH := 300, 50
+
+	makeBtn := func(label string, x, y, w int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, btnH, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+	makeLabel := func(y int, text string, size float64) *ui.UILabel {
+		l := ui.NewUILabel(cx, y, text, g.TextFont(size))
+		l.AlignCenter()
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	today := daily.Date(time.Now())
+
+	title := ui.NewUILabel(cx, startY, "DAILY CHALLENGE", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	makeLabel(startY+50, fmt.Sprintf("%s   Streak: %d", today, g.Daily.Streak(today)), 24).TextColor = colTitle
+
+	y := startY + 100
+	if r := g.Daily.Get(today); r != nil {
+		makeLabel(y, "Today: "+r.Summary(), 24)
+		makeLabel(y+34, "One attempt per day, come back tomorrow for a new deal.", 18)
+		result := *r
+		makeBtn("Copy result", cx-btnW/2, y+60, btnW, func() {
+			copyDailyResult(g, result)
+		})
+	} else {
+		makeLabel(y, fmt.Sprintf("Everyone gets the same deal and the same %d bots today.", daily.Bots), 24)
+		makeLabel(y+34, "One attempt per day, leaving the match gives it up.", 18)
+		makeBtn("Play", cx-btnW/2, y+60, btnW, func() {
+			g.PopScene() // Leave the main menu below for the match
+			g.ReplaceScene(NewDailyScene(today))
+		})
+	}
+
+	y += 150
+	makeLabel(y, "Last days", 24).TextColor = colTitle
+	var lines []string
+	results := slices.Clone(g.Daily.Results)
+	slices.Reverse(results)
+	for _, r := range results {
+		if r.Date == today {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s   %s", r.Date, r.Summary()))
+		if len(lines) == dailyHistoryShown {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No daily challenge played yet.")
+	}
+	makeLabel(y+34, strings.Join(lines, "\n"), 18)
+
+	makeBtn("Back", cx-100, ScreenH-80, 200, func() {
+		g.PopScene()
+	})
+}
+
+// copyDailyResult puts the share text of a result on the clipboard, it is
+// printed instead on platforms without one
+func copyDailyResult(g *Game, r daily.Result) {
+	text := daily.Share(r)
+	if err := clipboard.Write(text); err != nil {
+		fmt.Println("Error copying to the clipboard:", err)
+		fmt.Println(text)
+		g.Toast.Show("Could not copy the result", "The clipboard is not available")
+		return
+	}
+	g.Toast.Show("Result copied", "Paste it anywhere to share your day")
+}
+
+func (s *DailyMenuScene) Exit(g *Game) {
+}
+
+func (s *DailyMenuScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *DailyMenuScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *DailyMenuScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
+
+// DailyScene plays the challenge of a day, the result can be copied from
+// the game over menu
+type DailyScene struct {
+	*PlayingScene
+	match *DailyMatch
+	ready bool
+}
+
+func NewDailyScene(date string) *DailyScene {
+	m := NewDailyMatch(date)
+	return &DailyScene{
+		PlayingScene: NewMatchScene(m),
+		match:        m,
+	}
+}
+
+func (s *DailyScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.ready { // Back from the settings
+		return
+	}
+	s.ready = true
+
+	s.gameOverMenu.newGameBtn.Text = "Copy result"
+	s.gameOverMenu.newGameBtn.OnClick = func() {
+		copyDailyResult(g, s.match.Result())
+	}
+	s.gameOverMenu.menuBtn.Text = "Daily Challenge"
+	s.gameOverMenu.menuBtn.OnClick = func() {
+		g.PopScene()
+		g.PushScene(NewMainMenuScene())
+		g.PushScene(NewDailyMenuScene())
+	}
+}
+
+func (s *DailyScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+	s.hintBtn.SetVisible(false)
+
+	if s.gameOverMenu.visible {
+		r := s.match.Result()
+		s.gameOverMenu.titleLabel.Text = "DAILY " + r.Date
+		if r.Finished() {
+			s.gameOverMenu.resultLabel.Text = r.Summary()
+		}
+	}
+}
@@ -1,26 +1,39 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/thanhfphan/ebitengj2025/assets/fonts"
 	"github.com/thanhfphan/ebitengj2025/assets/images"
 	"github.com/thanhfphan/ebitengj2025/assets/sounds"
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
 	"github.com/thanhfphan/ebitengj2025/internal/ai"
 	"github.com/thanhfphan/ebitengj2025/internal/am"
+	"github.com/thanhfphan/ebitengj2025/internal/campaign"
 	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/daily"
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/profile"
+	"github.com/thanhfphan/ebitengj2025/internal/puzzle"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
 	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/save"
+	"github.com/thanhfphan/ebitengj2025/internal/settings"
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
 	"github.com/thanhfphan/ebitengj2025/internal/ui"
+	"golang.org/x/image/font"
 )
 
 var (
-	_ ebiten.Game  = (*Game)(nil)
-	_ ai.GameLike  = (*Game)(nil)
-	_ SceneManager = (*Game)(nil)
+	_ ebiten.Game     = (*Game)(nil)
+	_ ai.EngineSource = (*Game)(nil)
+	_ ai.GameLike     = (*Game)(nil)
+	_ SceneManager    = (*Game)(nil)
 )
 
 var (
@@ -54,8 +67,24 @@ type Game struct {
 	AIManager        *ai.Manager
 	CardManager      *card.Manager
 	TurnManager      *rules.TurnManager
-
-	sceneStack []Scene // Scene stack for managing scenes
+	Engine           *rules.Engine
+	MatchLog         *rules.Log // Recent events of the local match
+	HintsUsed        int        // Hints asked in the match, achievements pause once one was
+	Store            storage.Store
+	Settings         *settings.Settings
+	Profile          *profile.Profile
+	Puzzles          *puzzle.Progress   // Best stars of each solved puzzle
+	Daily            *daily.History     // Results of the daily challenges
+	Campaign         *campaign.Progress // Best stars and beaten chapters of the campaign
+	Achievements     *achievement.Tracker
+	Toast            *ui.UIToast // Notifications drawn over every scene
+
+	// NewBot creates the bot of each bot seat in local matches
+	NewBot func() ai.Bot
+
+	sceneStack      []Scene // Scene stack for managing scenes
+	matchInProgress bool
+	hintBot         *ai.HeuristicBot // Evaluates the moves of the local player for hints
 }
 
 func New() (*Game, error) {
@@ -71,9 +100,45 @@ func New() (*Game, error) {
 		AIManager:    aiManager,
 		CardManager:  cardManager,
 		TurnManager:  turnManager,
+		Engine:       rules.NewEngine(cardManager, turnManager),
+		MatchLog:     rules.NewLog(rules.DefaultLogSize),
+		Store:        storage.New(),
+		NewBot:       func() ai.Bot { return ai.NewEasyBot() },
 		sceneStack:   []Scene{},
 	}
 
+	s, err := settings.Load(g.Store)
+	if err != nil {
+		fmt.Println("Error loading settings, using defaults:", err)
+	}
+	g.Settings = s
+
+	p, err := profile.Load(g.Store)
+	if err != nil {
+		fmt.Println("Error loading profile:", err)
+	}
+	g.Profile = p
+
+	pz, err := puzzle.LoadProgress(g.Store)
+	if err != nil {
+		fmt.Println("Error loading puzzle progress:", err)
+	}
+	g.Puzzles = pz
+
+	dh, err := daily.LoadHistory(g.Store)
+	if err != nil {
+		fmt.Println("Error loading daily history:", err)
+	}
+	g.Daily = dh
+
+	cp, err := campaign.LoadProgress(g.Store)
+	if err != nil {
+		fmt.Println("Error loading campaign progress:", err)
+	}
+	g.Campaign = cp
+	g.loadAchievements()
+	g.Engine.OnEvent = g.onEngineEvent
+
 	cardManager.OnDishMade = func(recipe *entity.Card) {
 		fmt.Println("Recipe made:", recipe.Name)
 		err := g.AssetManager.PlaySound(SoundRecipeMade)
@@ -95,7 +160,6 @@ func New() (*Game, error) {
 	if err := g.AssetManager.LoadMusicFromBytes(MusicBackground, sounds.BambooFlute_ogg, "ogg"); err != nil {
 		return nil, err
 	}
-	assetManager.SetMusicVolume(0.2)
 	if err := g.AssetManager.LoadSoundFromBytes(SoundSelect, sounds.CardSwipe_wav, "wav"); err != nil {
 		return nil, err
 	}
@@ -106,6 +170,9 @@ func New() (*Game, error) {
 		return nil, err
 	}
 
+	g.Toast = ui.NewUIToast(ScreenW-420, 20, 400, 70, g.AssetManager.GetFont("nunito", 18), g.AssetManager.GetFont("nunito", 18))
+
+	g.ApplySettings()
 	g.AssetManager.PlayMusic(MusicBackground)
 
 	// Images
@@ -130,36 +197,279 @@ func New() (*Game, error) {
 	return g, nil
 }
 
-func (g *Game) setupGameData(botCount int) []*ui.UIBotHand {
-	g.Players = []*entity.Player{}
-	botHands := []*ui.UIBotHand{}
+func (g *Game) setupGameData(botCount int, newBot func() ai.Bot) []*ui.UIBotHand {
 	g.CardManager.LoadDeck("default")
-	g.TurnManager.Reset()
 
 	g.Player = entity.NewPlayer("P0", entity.TypePlayer)
-	g.Players = append(g.Players, g.Player)
-	g.TurnManager.AddPlayer(g.Player.ID, false)
+	g.Players = []*entity.Player{g.Player}
+	botHands := g.seatBots(botCount, newBot)
+
+	g.Achievements.StartMatch(g.Player.ID, g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = true
+
+	return botHands
+}
+
+// setupSeededGameData deals the match of the seed. The deal, the random
+// effects and the choices of the bots are the same every time, only the
+// player's moves change the course of the match.
+func (g *Game) setupSeededGameData(botCount int, seed int64, newBot func() ai.Bot) []*ui.UIBotHand {
+	g.CardManager.SetRandState(rng.State{Seed: seed})
+	botHands := g.setupGameData(botCount, newBot)
+	for i, p := range g.Players {
+		if s, ok := g.AIManager.GetBot(p.ID).(ai.Seeder); ok {
+			s.Seed(seed + int64(i))
+		}
+	}
+	return botHands
+}
 
-	defaultFont := g.AssetManager.GetFont("nunito", 32)
+// setupChapterGameData deals the deck of a campaign chapter against its
+// lineup of bots. It is not saved, leaving gives the match up.
+func (g *Game) setupChapterGameData(c *campaign.Chapter) ([]*ui.UIBotHand, error) {
+	cfg, err := c.DeckConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.CardManager.UseDeck(c.Deck, cfg); err != nil {
+		return nil, err
+	}
 
+	g.AIManager.Clear()
+	g.Player = entity.NewPlayer("P0", entity.TypePlayer)
+	g.Players = []*entity.Player{g.Player}
+	botHands := []*ui.UIBotHand{}
+	for _, b := range c.Bots {
+		bot, err := ai.New(b.Kind)
+		if err != nil {
+			return botHands, err
+		}
+		p := entity.NewPlayer(b.Name, entity.TypeBot)
+		g.Players = append(g.Players, p)
+		g.AIManager.RegisterBot(p.ID, bot)
+		botHands = append(botHands, g.newOpponentHand())
+	}
+
+	g.Achievements.StartMatch(g.Player.ID, g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = false
+
+	return botHands, nil
+}
+
+// setupCoopGameData deals a co-op match of the level, the player shares the
+// table with cooperative bots. It is neither saved nor recorded.
+func (g *Game) setupCoopGameData(botCount int, level CoopLevel) []*ui.UIBotHand {
+	if err := g.CardManager.LoadDeckRuleset("default", card.RulesetNoActions); err != nil {
+		fmt.Println("Error loading deck:", err)
+	}
+	g.CardManager.SetAside(level.SetAside)
+	g.Engine.Coop = rules.NewCoop(level.Target, level.Signals)
+
+	g.AIManager.Clear()
+	g.Player = entity.NewPlayer("P0", entity.TypePlayer)
+	g.Players = []*entity.Player{g.Player}
+	botHands := []*ui.UIBotHand{}
 	for i := 1; i <= botCount; i++ {
 		bot := entity.NewPlayer(fmt.Sprintf("B%d", i), entity.TypeBot)
 		g.Players = append(g.Players, bot)
-		g.TurnManager.AddPlayer(bot.ID, true)
-		g.AIManager.RegisterBot(bot.ID, ai.NewEasyBot())
+		g.AIManager.RegisterBot(bot.ID, ai.NewCoopBot())
+		botHands = append(botHands, g.newOpponentHand())
+	}
+
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = false
 
-		botHand := ui.NewUIBotHand(0, 0, CardWidth, CardHeight, defaultFont) // Position will be set later
-		botHands = append(botHands, botHand)
-		g.CurrentUIManager.AddElement(botHand)
+	return botHands
+}
+
+// setupRestaurantGameData deals a regular match with customers ordering
+// dishes. It is not saved, leaving gives the match up.
+func (g *Game) setupRestaurantGameData(botCount int) []*ui.UIBotHand {
+	g.CardManager.LoadDeck("default")
+	g.Engine.Orders = rules.NewOrders(RestaurantEvery, RestaurantPatience, RestaurantReputation)
+
+	g.Player = entity.NewPlayer("P0", entity.TypePlayer)
+	g.Players = []*entity.Player{g.Player}
+	botHands := g.seatBots(botCount, g.NewBot)
+
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = false
+
+	return botHands
+}
+
+// setupSpectatedGameData deals a match between bots for the player to
+// watch. It is neither saved nor recorded.
+func (g *Game) setupSpectatedGameData(botCount int) []*ui.UIBotHand {
+	g.CardManager.LoadDeck("default")
+
+	g.Player = nil
+	g.Players = []*entity.Player{}
+	botHands := g.seatBots(botCount, g.NewBot)
+
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = false
+
+	return botHands
+}
+
+// setupHotSeatGameData deals a match between humans sharing the device and
+// bots. Player is the first human, the others get opponent hands too. It is
+// neither saved nor recorded.
+func (g *Game) setupHotSeatGameData(humans, botCount int) []*ui.UIBotHand {
+	g.CardManager.LoadDeck("default")
+
+	g.Players = []*entity.Player{}
+	for i := 1; i <= humans; i++ {
+		g.Players = append(g.Players, entity.NewPlayer(fmt.Sprintf("Player %d", i), entity.TypePlayer))
+	}
+	g.Player = g.Players[0]
+	botHands := g.seatBots(botCount, g.NewBot)
+	for range humans - 1 {
+		botHands = append(botHands, g.newOpponentHand())
 	}
 
-	g.CardManager.DealHands(g.Players)
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.Engine.Start(g.Players)
+	g.matchInProgress = false
 
 	return botHands
 }
 
+// seatBots adds bots made by newBot to the players and returns their hands
+func (g *Game) seatBots(botCount int, newBot func() ai.Bot) []*ui.UIBotHand {
+	g.AIManager.Clear()
+	botHands := []*ui.UIBotHand{}
+	for i := 1; i <= botCount; i++ {
+		bot := entity.NewPlayer(fmt.Sprintf("B%d", i), entity.TypeBot)
+		g.Players = append(g.Players, bot)
+		g.AIManager.RegisterBot(bot.ID, newBot())
+		botHands = append(botHands, g.newOpponentHand())
+	}
+	return botHands
+}
+
+// newOpponentHand adds the hand of an opponent to the current UI, its
+// position is set by the scene
+func (g *Game) newOpponentHand() *ui.UIBotHand {
+	botHand := ui.NewUIBotHand(0, 0, CardWidth, CardHeight, g.AssetManager.GetFont("nunito", 32))
+	g.CurrentUIManager.AddElement(botHand)
+	return botHand
+}
+
+// restoreGameData rebuilds a saved match, the human player is the first one
+// who is not a bot
+func (g *Game) restoreGameData(f *save.File) ([]*ui.UIBotHand, error) {
+	g.AIManager.Clear()
+	botHands := []*ui.UIBotHand{}
+	for _, p := range f.Match.Players {
+		if !p.IsBot {
+			continue
+		}
+		g.AIManager.RegisterBot(p.ID, g.NewBot())
+		botHands = append(botHands, g.newOpponentHand())
+	}
+
+	if err := f.Restore(g.Engine, g.AIManager); err != nil {
+		return botHands, err
+	}
+
+	g.Players = g.Engine.Players
+	g.Player = nil
+	for _, p := range g.Players {
+		if !p.IsBot() {
+			g.Player = p
+			break
+		}
+	}
+	if g.Player == nil {
+		return botHands, errors.New("saved match has no human player")
+	}
+	g.Achievements.ResumeMatch(g.Player.ID, g.CardManager.Theme, f.Match.Achievements)
+	g.MatchLog.Reset()
+	g.HintsUsed = f.Match.Hints
+	g.matchInProgress = true
+
+	return botHands, nil
+}
+
+// EndMatch is called once the match is over or abandoned, the saved copy
+// is no longer needed
+func (g *Game) EndMatch() {
+	g.matchInProgress = false
+	g.DeleteSavedMatch()
+}
+
+// ApplySettings pushes the current settings to the audio, window, bots and UI
+func (g *Game) ApplySettings() {
+	g.AssetManager.SetMasterVolume(g.Settings.MasterVolume)
+	g.AssetManager.SetMusicVolume(g.Settings.MusicVolume)
+	ebiten.SetFullscreen(g.Settings.WindowMode == settings.WindowModeFullscreen)
+	g.AIManager.SetThinkTime(g.Settings.BotThinkTime())
+	ui.SetHighContrast(g.Settings.Accessibility.HighContrast)
+}
+
+func (g *Game) SaveSettings() {
+	if err := g.Settings.Save(g.Store); err != nil {
+		fmt.Println("Error saving settings:", err)
+	}
+}
+
+// TextFont returns the font for in game text, bigger when large text is on
+func (g *Game) TextFont(size float64) font.Face {
+	if g.Settings.Accessibility.LargeText {
+		size += 6
+	}
+	return g.AssetManager.GetFont("nunito", size)
+}
+
+// RecordMatch adds the finished match to the profile of the local player
+func (g *Game) RecordMatch() {
+	if g.Player == nil {
+		return
+	}
+	position := slices.Index(g.TurnManager.FinishedOrder(), g.Player.ID) + 1
+	if position == 0 {
+		return
+	}
+
+	g.Profile.Record(profile.MatchResult{
+		Position: position,
+		Players:  len(g.Players),
+		Dishes:   g.Engine.Dishes[g.Player.ID],
+		Hints:    g.HintsUsed,
+	})
+	if err := g.Profile.Save(g.Store); err != nil {
+		fmt.Println("Error saving profile:", err)
+	}
+}
+
 // Update implements ebiten.Game.
 func (g *Game) Update() error {
+	if ebiten.IsWindowBeingClosed() {
+		if err := g.SaveMatch(); err != nil {
+			fmt.Println("Error saving match:", err)
+		}
+		return ebiten.Termination
+	}
+
 	g.HandleInput()
 
 	currentScene := g.CurrentScene()
@@ -172,6 +482,7 @@ func (g *Game) Update() error {
 	}
 
 	g.AssetManager.Update()
+	g.Toast.Update()
 
 	if g.State == GameStateQuit {
 		return ebiten.Termination
@@ -192,6 +503,8 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if g.CurrentUIManager != nil {
 		g.CurrentUIManager.Draw(screen)
 	}
+
+	g.Toast.Draw(screen)
 }
 
 // Layout implements ebiten.Game.
@@ -242,6 +555,7 @@ func (g *Game) GetPlayerState(id string) *ai.PlayerState {
 		ID:       playerTurn.ID,
 		IsBot:    playerTurn.IsBot,
 		Hand:     player.Hand,
+		Order:    player.OrderHand,
 		Passed:   playerTurn.Passed,
 		Finished: playerTurn.Finished,
 	}
@@ -249,30 +563,19 @@ func (g *Game) GetPlayerState(id string) *ai.PlayerState {
 
 // Pass implements ai.GameLike.
 func (g *Game) Pass(playerID string) {
-	current := g.TurnManager.Current()
-	if current == nil || current.ID != playerID || current.Finished {
-		fmt.Println("Cannot pass because it's not your turn. Player:", playerID, "Current:", current)
-		return
-	}
-
-	if err := g.TurnManager.Pass(playerID); err != nil {
-		fmt.Println("Error passing turn:", err)
+	if err := g.Engine.Pass(playerID); err != nil {
+		fmt.Println("Cannot pass:", err)
 	}
-
-	g.TurnManager.Next()
 }
 
 // PlayCard implements ai.GameLike.
 func (g *Game) PlayCard(playerID string, cardID string) error {
-	current := g.TurnManager.Current()
-	if current == nil || current.ID != playerID || current.Finished {
-		fmt.Println("Cannot play card because it's not your turn. Player:", playerID, "Current:", current)
-		return fmt.Errorf("Cannot play card because it's not your turn. Player: %s, Current: %v", playerID, current)
-	}
+	return g.PlayCardWithTarget(playerID, cardID, entity.Target{})
+}
 
-	player := g.GetPlayer(playerID)
-	err := g.CardManager.PlayCard(player, cardID)
-	if err != nil {
+// PlayCardWithTarget implements ai.GameLike.
+func (g *Game) PlayCardWithTarget(playerID string, cardID string, target entity.Target) error {
+	if err := g.Engine.PlayCard(playerID, cardID, target); err != nil {
 		fmt.Println("Error playing card:", err)
 		return err
 	}
@@ -281,41 +584,21 @@ func (g *Game) PlayCard(playerID string, cardID string) error {
 		fmt.Println("Error playing sound:", err)
 	}
 
-	g.TurnManager.MarkAllUnpassed()
-
-	hasDish := false
-	for g.CardManager.TryMakeDish() {
-		hasDish = true
-		for _, p := range g.Players {
-			if len(p.Hand) == 0 {
-				g.TurnManager.MarkHandEmpty(p.ID)
-				if !g.CardManager.TableStack.HasPlayerCards(p.ID) {
-					g.TurnManager.MarkFinished(p.ID)
-				}
-			}
-		}
-	}
-
-	for _, p := range g.Players {
-		if len(p.Hand) == 0 {
-			g.TurnManager.MarkHandEmpty(p.ID)
-		}
-	}
+	return nil
+}
 
-	if !hasDish || len(player.Hand) == 0 {
-		g.TurnManager.Next()
-	}
+// LegalTargets implements ai.GameLike.
+func (g *Game) LegalTargets(playerID string, cardID string) []entity.Target {
+	return g.Engine.LegalTargets(playerID, cardID)
+}
 
-	return nil
+// RulesEngine implements ai.EngineSource.
+func (g *Game) RulesEngine() *rules.Engine {
+	return g.Engine
 }
 
 func (g *Game) GetPlayer(id string) *entity.Player {
-	for _, p := range g.Players {
-		if p.ID == id {
-			return p
-		}
-	}
-	return nil
+	return g.Engine.GetPlayer(id)
 }
 
 // PushScene adds a new scene to the top of the stack
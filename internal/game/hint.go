@@ -0,0 +1,138 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+var (
+	errHintsOff    = errors.New("hints are off in the settings")
+	errNoHintsHere = errors.New("hints are not available in online matches")
+)
+
+// Hint is the move suggested to the local player, with a short reason
+type Hint struct {
+	Card   *entity.Card // Nil to pass
+	Target entity.Target
+	Reason string
+}
+
+// Text is shown above the hand
+func (h *Hint) Text() string {
+	if h.Card == nil {
+		return "Hint: pass, " + h.Reason
+	}
+	return fmt.Sprintf("Hint: play %s, %s", h.Card.Name, h.Reason)
+}
+
+// Hint asks the evaluator bot for the best move of the local player and
+// counts it as used
+func (g *Game) Hint() *Hint {
+	if g.hintBot == nil {
+		g.hintBot = ai.NewHeuristicBot(ai.DefaultWeights)
+	}
+	g.HintsUsed++
+
+	h := &Hint{}
+	if c, target, ok := g.hintBot.BestMove(g, g.Player.ID); ok {
+		h.Card, h.Target = c, target
+	}
+	h.Reason = hintReason(g.Engine, g.Player, h)
+	return h
+}
+
+func hintReason(e *rules.Engine, player *entity.Player, h *Hint) string {
+	c := h.Card
+	if c == nil {
+		for id := range player.Hand {
+			if len(e.LegalTargets(player.ID, id)) > 0 {
+				return "nothing helps now, wait for the others"
+			}
+		}
+		return "no card can be played now"
+	}
+
+	if c.Type != entity.CardTypeAction {
+		if r := e.Cards.CompletingRecipe(c); r != nil {
+			return "completes " + r.Name
+		}
+	}
+
+	table := tableIngredients(e)
+	switch {
+	case c.Type == entity.CardTypeRecipe:
+		if n := filledRequirements(c, table); n > 0 {
+			return fmt.Sprintf("%d of %d ingredients for it are on the table", n, len(c.Requirements))
+		}
+	case c.IsIngredient():
+		for _, r := range e.Cards.TableStack.GetCardsByType(entity.CardTypeRecipe) {
+			if filledRequirements(r, append(table, c)) > filledRequirements(r, table) {
+				return "adds to " + r.Name
+			}
+		}
+	case h.Target.PlayerID != "":
+		if p := e.GetPlayer(h.Target.PlayerID); p != nil {
+			return fmt.Sprintf("on %s, who holds %d cards", p.Name, len(p.Hand))
+		}
+	case h.Target.CardID != "":
+		if tc := e.Cards.TableStack.GetCard(h.Target.CardID); tc != nil {
+			return "on " + tc.Card.Name
+		}
+	}
+
+	if kept, r := heldFor(e, player, c, table); kept != nil {
+		return fmt.Sprintf("holds %s for %s", kept.Name, r.Name)
+	}
+	return "one card fewer to get rid of"
+}
+
+// heldFor finds another card of the hand worth keeping because it fills a
+// missing requirement of a recipe on the table or in hand
+func heldFor(e *rules.Engine, player *entity.Player, played *entity.Card, table []*entity.Card) (kept, recipe *entity.Card) {
+	recipes := e.Cards.TableStack.GetCardsByType(entity.CardTypeRecipe)
+	for _, id := range player.OrderHand {
+		if c := player.GetCard(id); c != nil && c != played && c.Type == entity.CardTypeRecipe {
+			recipes = append(recipes, c)
+		}
+	}
+
+	for _, id := range player.OrderHand {
+		k := player.GetCard(id)
+		if k == nil || k == played || k.Type != entity.CardTypeIngredient {
+			continue
+		}
+		for _, r := range recipes {
+			for i, idx := range card.MatchRequirements(r.Requirements, table) {
+				if idx < 0 && r.Requirements[i].Accepts(k) {
+					return k, r
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func tableIngredients(e *rules.Engine) []*entity.Card {
+	var ings []*entity.Card
+	for _, c := range e.Cards.TableStack.GetAllCardsInOrder() {
+		if c.IsIngredient() {
+			ings = append(ings, c)
+		}
+	}
+	return ings
+}
+
+func filledRequirements(recipe *entity.Card, ingredients []*entity.Card) int {
+	n := 0
+	for _, idx := range card.MatchRequirements(recipe.Requirements, ingredients) {
+		if idx >= 0 {
+			n++
+		}
+	}
+	return n
+}
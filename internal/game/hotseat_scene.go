@@ -0,0 +1,143 @@
+package game
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*HotSeatScene)(nil)
+
+// Seats around the table of a hot-seat match
+const (
+	minHotSeatHumans  = 2
+	maxHotSeatPlayers = 6
+)
+
+// HotSeatScene sets up a match for several humans sharing the device
+type HotSeatScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+
+	humans int
+	bots   int
+}
+
+func NewHotSeatScene() *HotSeatScene {
+	return &HotSeatScene{
+		elements: []ui.Element{},
+		humans:   2,
+		bots:     2,
+	}
+}
+
+func (s *HotSeatScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 140
+	btnW, btnH := 420, 50
+
+	makeBtn := func(label string, x, y, w int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, btnH, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	title := ui.NewUILabel(cx, startY, "HOT SEAT", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	hint := ui.NewUILabel(cx, startY+60, "Players take turns on this device, the screen hides each hand in between.", g.TextFont(18))
+	hint.AlignCenter()
+	s.uiManager.AddElement(hint)
+	s.elements = append(s.elements, hint)
+
+	y := startY + 110
+	var humansBtn, botsBtn *ui.UIButton
+	refresh := func() {
+		humansBtn.Text = "Players: " + strconv.Itoa(s.humans)
+		botsBtn.Text = "Bots: " + strconv.Itoa(s.bots)
+	}
+	humansBtn = makeBtn("", cx-btnW/2, y, btnW, func() {
+		s.humans++
+		if s.humans > maxHotSeatPlayers {
+			s.humans = minHotSeatHumans
+		}
+		s.bots = min(s.bots, maxHotSeatPlayers-s.humans)
+		refresh()
+	})
+	y += btnH + 20
+	botsBtn = makeBtn("", cx-btnW/2, y, btnW, func() {
+		s.bots = (s.bots + 1) % (maxHotSeatPlayers - s.humans + 1)
+		refresh()
+	})
+	refresh()
+
+	y += btnH + 50
+	makeBtn("Start", cx-210, y, 200, func() {
+		g.PopScene() // Leave the main menu below for the match
+		g.ReplaceScene(NewMatchScene(NewHotSeatMatch(s.humans, s.bots)))
+	})
+	makeBtn("Back", cx+10, y, 200, func() {
+		g.PopScene()
+	})
+}
+
+func (s *HotSeatScene) Exit(g *Game) {
+}
+
+func (s *HotSeatScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *HotSeatScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *HotSeatScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
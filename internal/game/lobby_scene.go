@@ -0,0 +1,396 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/netplay"
+	"github.com/thanhfphan/ebitengj2025/internal/settings"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*LobbyScene)(nil)
+
+const lobbyRoomRows = 6
+
+// LobbyScene lists the rooms of a match server, creates them and waits in
+// one until its host starts the match
+type LobbyScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+
+	link     *serverLink
+	handover bool // The link was handed to the match scene, Exit must not close it
+
+	rooms    []netplay.RoomInfo
+	room     *netplay.RoomInfo // Room we are in, nil in the lobby
+	ready    bool
+	watching bool // Joined the room as a spectator
+	opts     netplay.RoomOptions
+
+	lobbyElements []ui.Element // Shown outside a room
+	roomElements  []ui.Element // Shown inside a room
+	roomRows      []lobbyRoomRow
+	memberLabels  []*ui.UILabel
+	roomTitle     *ui.UILabel
+	roomDetails   *ui.UILabel
+	readyBtn      *ui.UIButton
+	startBtn      *ui.UIButton
+	statusLabel   *ui.UILabel
+}
+
+type lobbyRoomRow struct {
+	label    *ui.UILabel
+	joinBtn  *ui.UIButton
+	watchBtn *ui.UIButton
+}
+
+func NewLobbyScene(link *serverLink) *LobbyScene {
+	return &LobbyScene{
+		elements: []ui.Element{},
+		link:     link,
+		opts: netplay.RoomOptions{
+			Deck:    card.Themes[0],
+			Ruleset: card.RulesetStandard,
+			Seats:   4,
+			BotFill: true,
+		},
+	}
+}
+
+func (s *LobbyScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+	textFont := g.TextFont(18)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	makeBtn := func(x, y, w, h int, label string, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, h, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+	addLabel := func(x, y int, text string) *ui.UILabel {
+		l := ui.NewUILabel(x, y, text, defaultFont)
+		l.TextColor = colTitle
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	cx := ScreenW / 2
+	title := ui.NewUILabel(cx, 70, "LOBBY", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	// Room list on the left
+	leftX := 80
+	y := 150
+	s.lobbyElements = append(s.lobbyElements, addLabel(leftX, y, "Rooms"))
+	y += 30
+	for range lobbyRoomRows {
+		row := lobbyRoomRow{
+			label: ui.NewUILabel(leftX, y+30, "", textFont),
+		}
+		s.uiManager.AddElement(row.label)
+		s.elements = append(s.elements, row.label)
+		row.joinBtn = makeBtn(leftX+460, y, 90, 44, "Join", nil)
+		row.watchBtn = makeBtn(leftX+560, y, 90, 44, "Watch", nil)
+		s.lobbyElements = append(s.lobbyElements, row.label, row.joinBtn, row.watchBtn)
+		s.roomRows = append(s.roomRows, row)
+		y += 60
+	}
+
+	// New room options on the right
+	rightX := 760
+	optW, optH := 420, 44
+	y = 150
+	s.lobbyElements = append(s.lobbyElements, addLabel(rightX, y, "New room"))
+	y += 20
+	nameInput := ui.NewUITextInput(rightX, y, optW, optH, defaultFont)
+	nameInput.Placeholder = "Room name"
+	nameInput.MaxLength = 24
+	s.uiManager.AddElement(nameInput)
+	s.elements = append(s.elements, nameInput)
+	s.lobbyElements = append(s.lobbyElements, nameInput)
+	y += optH + 16
+
+	makeOption := func(label func() string, onClick func()) {
+		var b *ui.UIButton
+		b = makeBtn(rightX, y, optW, optH, label(), func() {
+			onClick()
+			b.Text = label()
+		})
+		s.lobbyElements = append(s.lobbyElements, b)
+		y += optH + 16
+	}
+	makeOption(func() string {
+		return "Deck: " + s.opts.Deck
+	}, func() {
+		s.opts.Deck = settings.Next(card.Themes, s.opts.Deck)
+	})
+	makeOption(func() string {
+		return "Rules: " + rulesetNames[s.opts.Ruleset]
+	}, func() {
+		s.opts.Ruleset = settings.Next(card.Rulesets, s.opts.Ruleset)
+	})
+	makeOption(func() string {
+		return "Seats: " + strconv.Itoa(s.opts.Seats)
+	}, func() {
+		s.opts.Seats = s.opts.Seats%6 + 1
+		if s.opts.Seats < 2 {
+			s.opts.Seats = 2
+		}
+	})
+	makeOption(func() string {
+		return "Fill with bots: " + onOff(s.opts.BotFill)
+	}, func() {
+		s.opts.BotFill = !s.opts.BotFill
+	})
+	makeOption(func() string {
+		return "Spectators see hands: " + onOff(s.opts.GodView)
+	}, func() {
+		s.opts.GodView = !s.opts.GodView
+	})
+	s.lobbyElements = append(s.lobbyElements, makeBtn(rightX, y, optW, optH, "Create Room", func() {
+		s.opts.Name = nameInput.Text
+		s.send(s.link.client.CreateRoom(s.opts))
+	}))
+
+	// Inside a room
+	y = 150
+	s.roomTitle = addLabel(leftX, y, "")
+	s.roomDetails = ui.NewUILabel(leftX, y+36, "", textFont)
+	s.uiManager.AddElement(s.roomDetails)
+	s.elements = append(s.elements, s.roomDetails)
+	s.roomElements = append(s.roomElements, s.roomTitle, s.roomDetails)
+	y += 90
+	for range 6 {
+		l := ui.NewUILabel(leftX, y, "", defaultFont)
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		s.memberLabels = append(s.memberLabels, l)
+		s.roomElements = append(s.roomElements, l)
+		y += 40
+	}
+
+	y = 240
+	s.readyBtn = makeBtn(rightX, y, optW, optH, "Ready", func() {
+		s.send(s.link.client.SetReady(!s.ready))
+	})
+	y += optH + 16
+	s.startBtn = makeBtn(rightX, y, optW, optH, "Start Match", func() {
+		s.send(s.link.client.Start())
+	})
+	y += optH + 16
+	s.roomElements = append(s.roomElements, s.readyBtn, s.startBtn,
+		makeBtn(rightX, y, optW, optH, "Leave Room", func() {
+			s.send(s.link.client.LeaveRoom())
+		}))
+
+	s.statusLabel = ui.NewUILabel(cx, ScreenH-150, "", defaultFont)
+	s.statusLabel.AlignCenter()
+	s.statusLabel.TextColor = colTitle
+	s.uiManager.AddElement(s.statusLabel)
+	s.elements = append(s.elements, s.statusLabel)
+
+	makeBtn(cx-100, ScreenH-110, 200, 50, "Disconnect", func() {
+		g.PopScene()
+	})
+
+	s.refresh()
+}
+
+var rulesetNames = map[string]string{
+	card.RulesetStandard:  "Standard",
+	card.RulesetNoActions: "No action cards",
+	card.RulesetClassic:   "Classic",
+}
+
+// send reports an intent that could not reach the server
+func (s *LobbyScene) send(err error) {
+	if err != nil {
+		s.statusLabel.Text = "Connection error: " + err.Error()
+	}
+}
+
+// refresh shows the lobby or the room we are in
+func (s *LobbyScene) refresh() {
+	inRoom := s.room != nil
+	for _, e := range s.lobbyElements {
+		e.SetVisible(!inRoom)
+	}
+	for _, e := range s.roomElements {
+		e.SetVisible(inRoom)
+	}
+
+	if !inRoom {
+		for i, row := range s.roomRows {
+			if i >= len(s.rooms) {
+				row.label.Text = ""
+				row.joinBtn.SetVisible(false)
+				row.watchBtn.SetVisible(false)
+				continue
+			}
+			r := s.rooms[i]
+			row.label.Text = roomSummary(r)
+			row.joinBtn.SetVisible(!r.Started && len(r.Members) < r.Options.Seats)
+			id := r.ID
+			row.joinBtn.OnClick = func() {
+				s.watching = false
+				s.send(s.link.client.JoinRoom(id, false))
+			}
+			row.watchBtn.OnClick = func() {
+				s.watching = true
+				s.send(s.link.client.JoinRoom(id, true))
+			}
+		}
+		return
+	}
+
+	r := s.room
+	s.roomTitle.Text = r.Options.Name
+	s.roomDetails.Text = roomSummary(*r)
+	if r.Spectators > 0 {
+		s.roomDetails.Text += fmt.Sprintf(", %d watching", r.Spectators)
+	}
+	host := false
+	for i, l := range s.memberLabels {
+		if i >= len(r.Members) {
+			l.Text = ""
+			continue
+		}
+		m := r.Members[i]
+		text := m.Name
+		switch {
+		case m.Host:
+			text += "  (host)"
+		case m.Ready:
+			text += "  - ready"
+		}
+		l.Text = text
+		if m.Host && m.Name == s.link.client.Name {
+			host = true
+		}
+	}
+	s.readyBtn.SetVisible(!host && !s.watching)
+	s.startBtn.SetVisible(host)
+	if s.watching {
+		s.roomTitle.Text += "  (watching)"
+	}
+	s.readyBtn.Text = "Ready"
+	if s.ready {
+		s.readyBtn.Text = "Not Ready"
+	}
+}
+
+func roomSummary(r netplay.RoomInfo) string {
+	text := fmt.Sprintf("%s - %s, %s, %d/%d players", r.Options.Name, r.Options.Deck,
+		rulesetNames[r.Options.Ruleset], len(r.Members), r.Options.Seats)
+	if r.Options.BotFill {
+		text += ", bots"
+	}
+	if r.Started {
+		text += " - playing"
+	}
+	return text
+}
+
+func (s *LobbyScene) Exit(g *Game) {
+	if !s.handover {
+		s.link.close()
+	}
+}
+
+func (s *LobbyScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+		return
+	}
+
+	for {
+		in, ok := s.link.poll()
+		if !ok {
+			return
+		}
+		if in.err != nil {
+			fmt.Println("Lost connection to the server:", in.err)
+			s.statusLabel.Text = "Lost connection to the server."
+			return
+		}
+
+		msg := in.msg
+		switch msg.Type {
+		case netplay.MsgRooms:
+			s.rooms = msg.Rooms
+			s.room = nil
+			s.ready = false
+		case netplay.MsgRoom:
+			s.room = msg.Room
+			s.ready = false
+			for _, m := range msg.Room.Members {
+				if m.Name == s.link.client.Name {
+					s.ready = m.Ready
+				}
+			}
+		case netplay.MsgWelcome:
+			// The match started, the next messages are its states
+			s.handover = true
+			g.PopScene() // Leave the main menu below for the match
+			g.ReplaceScene(NewMatchScene(newRemoteMatch(s.link)))
+			return
+		case netplay.MsgError:
+			s.statusLabel.Text = msg.Error
+			continue
+		}
+		s.statusLabel.Text = ""
+		s.refresh()
+	}
+}
+
+func (s *LobbyScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *LobbyScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
@@ -1,10 +1,14 @@
 package game
 
 import (
+	"errors"
+	"fmt"
 	"image/color"
 	"runtime"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/thanhfphan/ebitengj2025/internal/save"
+	"github.com/thanhfphan/ebitengj2025/internal/tutorial"
 	"github.com/thanhfphan/ebitengj2025/internal/ui"
 )
 
@@ -51,8 +55,8 @@ func (s *MainMenuScene) Enter(g *Game) {
 		gapY   = 32
 	)
 
-	makeBtn := func(label string, y int, onClick func()) *ui.UIButton {
-		b := ui.NewUIButton(cx-btnW/2, y, btnW, btnH, label, defaultFont)
+	makeBtn := func(label string, x, y int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, btnW, btnH, label, defaultFont)
 		b.BackgroundColor = colButtonBg
 		b.HoverColor = colButtonHover
 		b.PressedColor = colButtonPressed
@@ -71,23 +75,118 @@ func (s *MainMenuScene) Enter(g *Game) {
 	s.uiManager.AddElement(title)
 	s.elements = append(s.elements, title)
 
-	y := startY + 120
-	s.elements = append(s.elements,
-		makeBtn("New Game", y, func() {
+	// Shown when a saved match cannot be loaded
+	messageLabel := ui.NewUILabel(cx, ScreenH-30, "", defaultFont)
+	messageLabel.AlignCenter()
+	messageLabel.TextColor = colTitle
+	s.uiManager.AddElement(messageLabel)
+	s.elements = append(s.elements, messageLabel)
+
+	type menuItem struct {
+		label   string
+		onClick func()
+	}
+	var items []menuItem
+
+	if g.HasSavedMatch() {
+		items = append(items, menuItem{"Continue", func() {
+			f, err := g.LoadMatch()
+			if err != nil {
+				fmt.Println("Error loading saved match:", err)
+				g.DeleteSavedMatch()
+				messageLabel.Text = "The saved match could not be loaded and was discarded."
+				if errors.Is(err, save.ErrUnsupported) {
+					messageLabel.Text = "The saved match is from an incompatible version and was discarded."
+				}
+				return
+			}
+			g.PopScene()
+			g.PushScene(NewResumedPlayingScene(f))
+		}})
+	}
+
+	items = append(items,
+		menuItem{"New Game", func() {
 			g.PopScene()
 			g.PushScene(NewPlayingScene())
-		}),
-		makeBtn("Settings", y+btnH+gapY, func() {
+		}},
+		menuItem{"Campaign", func() {
+			g.PushScene(NewCampaignMapScene())
+		}},
+		menuItem{"Tutorial", func() {
+			scripts, err := tutorial.List()
+			if err != nil || len(scripts) == 0 {
+				fmt.Println("Error loading tutorials:", err)
+				messageLabel.Text = "The tutorial could not be loaded."
+				return
+			}
+			g.PopScene()
+			g.PushScene(NewTutorialScene(scripts[0]))
+		}},
+		menuItem{"Puzzles", func() {
+			g.PushScene(NewPuzzleSelectScene())
+		}},
+		menuItem{"Daily Challenge", func() {
+			g.PushScene(NewDailyMenuScene())
+		}},
+		menuItem{"Co-op", func() {
+			g.PushScene(NewCoopSelectScene())
+		}},
+		menuItem{"Restaurant", func() {
+			g.PopScene()
+			g.PushScene(NewRestaurantScene())
+		}},
+		menuItem{"Hot Seat", func() {
+			g.PushScene(NewHotSeatScene())
+		}},
+		menuItem{"Join Online", func() {
+			g.PushScene(NewConnectScene())
+		}},
+		menuItem{"Watch Bots", func() {
+			g.PopScene()
+			g.PushScene(NewMatchScene(NewSpectatedLocalMatch(DefaultBots + 1)))
+		}},
+		menuItem{"Recipe Book", func() {
+			g.PushScene(NewRecipeBookScene())
+		}},
+		menuItem{"Stats", func() {
+			g.PushScene(NewStatsScene())
+		}},
+		menuItem{"Achievements", func() {
+			g.PushScene(NewAchievementsScene())
+		}},
+		menuItem{"Settings", func() {
 			g.PushScene(NewSettingsScene())
-		}),
-		makeBtn("Quit", y+2*(btnH+gapY), func() {
+		}},
+		menuItem{"Quit", func() {
 			if runtime.GOARCH == "wasm" && runtime.GOOS == "js" {
 				return
 			}
 			g.State = GameStateQuit
-		}),
+		}},
 	)
 
+	// One column while it fits, then two, then three
+	columns := 1
+	switch {
+	case len(items) > 12:
+		columns = 3
+		btnW = ScreenW / 5
+		gapY = 20
+	case len(items) > 5:
+		columns = 2
+		btnW = ScreenW / 4
+		gapY = 20
+	}
+	rows := (len(items) + columns - 1) / columns
+	colGap := 40
+	left := cx - (columns*btnW+(columns-1)*colGap)/2
+	for i, item := range items {
+		col, row := i/rows, i%rows
+		x := left + col*(btnW+colGap)
+		y := startY + 120 + row*(btnH+gapY)
+		s.elements = append(s.elements, makeBtn(item.label, x, y, item.onClick))
+	}
 }
 
 func (s *MainMenuScene) Exit(g *Game) {
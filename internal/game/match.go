@@ -0,0 +1,244 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/save"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+// Match is the match PlayingScene shows and sends the player's intents to.
+// LocalMatch runs the rules engine in process, RemoteMatch mirrors a match
+// hosted by a server.
+type Match interface {
+	// Setup prepares the match and returns a hand for every opponent, every
+	// player when spectating. A remote match is not ready until the server
+	// sent its first state, Setup is called again until it is.
+	Setup(g *Game) (hands []*ui.UIBotHand, ready bool)
+	// Update drives the bots, or applies what the server sent
+	Update()
+
+	Player() *entity.Player    // The local player, nil when spectating
+	Players() []*entity.Player // The local player first, then the others in turn order
+	// Spectating is true when watching a match without a seat in it
+	Spectating() bool
+	// HandsVisible is true when the hands of the others hold their real
+	// cards, only spectators may see them
+	HandsVisible() bool
+	Log() []string // Recent events, oldest first
+
+	// Handover returns the name of the player the device must be passed to
+	// before their turn when several humans share it, empty otherwise
+	Handover() string
+	// TakeSeat is called once the device was passed, Player becomes the
+	// player whose turn it is
+	TakeSeat()
+	Table() *entity.TableStack
+	Current() *rules.PlayerTurn
+	Score(playerID string) int
+	FinishedOrder() []string
+	IsOver() bool
+
+	Play(cardID string, target entity.Target) error
+	Pass() error
+	LegalTargets(cardID string) []entity.Target
+	CompletingRecipe(c *entity.Card) *entity.Card
+	IngredientNames() map[string]string
+	// Hint suggests a move to the player, each call counts as a used hint
+	Hint() (*Hint, error)
+
+	// Busy is true while an intent waits for the server
+	Busy() bool
+	// Status is shown over the table, such as while reconnecting
+	Status() string
+	// Err returns why a match stopped before its end, such as a lost connection
+	Err() error
+
+	// End is called once the match is over, Leave when the player quits it
+	End()
+	Leave()
+}
+
+var _ Match = (*LocalMatch)(nil)
+
+// DefaultBots is the number of bots in a new local match
+const DefaultBots = 3
+
+// errSpectator is returned for intents sent while watching a match
+var errSpectator = errors.New("spectators cannot play")
+
+// LocalMatch is a match against bots on this device, run by Game.Engine
+type LocalMatch struct {
+	g        *Game
+	bots     int
+	resume   *save.File // Saved match to continue instead of dealing a new one
+	spectate bool       // Only bots play, the player watches
+	humans   int        // Humans sharing the device, more than one for hot-seat
+	curtain  bool       // The hand of Player is hidden until the device is passed
+}
+
+func NewLocalMatch(bots int) *LocalMatch {
+	return &LocalMatch{bots: bots}
+}
+
+// NewResumedLocalMatch continues a saved match
+func NewResumedLocalMatch(f *save.File) *LocalMatch {
+	return &LocalMatch{bots: DefaultBots, resume: f}
+}
+
+// NewSpectatedLocalMatch deals a match between bots for the player to watch
+func NewSpectatedLocalMatch(bots int) *LocalMatch {
+	return &LocalMatch{bots: bots, spectate: true}
+}
+
+// NewHotSeatMatch deals a match between humans passing the device around
+// and bots
+func NewHotSeatMatch(humans, bots int) *LocalMatch {
+	return &LocalMatch{bots: bots, humans: humans}
+}
+
+func (m *LocalMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	if m.spectate {
+		return g.setupSpectatedGameData(m.bots), true
+	}
+	if m.hotSeat() {
+		m.curtain = true
+		return g.setupHotSeatGameData(m.humans, m.bots), true
+	}
+	if m.resume == nil {
+		return g.setupGameData(m.bots, g.NewBot), true
+	}
+
+	botHands, err := g.restoreGameData(m.resume)
+	m.resume = nil
+	if err != nil {
+		fmt.Println("Error restoring saved match:", err)
+		g.DeleteSavedMatch()
+		for _, hand := range botHands {
+			g.CurrentUIManager.RemoveElement(hand)
+		}
+		return g.setupGameData(DefaultBots, g.NewBot), true
+	}
+	return botHands, true
+}
+
+func (m *LocalMatch) Update() {
+	m.g.UpdateTurn()
+}
+
+func (m *LocalMatch) Player() *entity.Player     { return m.g.Player }
+func (m *LocalMatch) Table() *entity.TableStack  { return m.g.CardManager.TableStack }
+func (m *LocalMatch) Current() *rules.PlayerTurn { return m.g.TurnManager.Current() }
+func (m *LocalMatch) Score(playerID string) int  { return m.g.Engine.Score(playerID) }
+func (m *LocalMatch) FinishedOrder() []string    { return m.g.TurnManager.FinishedOrder() }
+func (m *LocalMatch) IsOver() bool               { return m.g.Engine.IsOver() }
+func (m *LocalMatch) Busy() bool                 { return false }
+func (m *LocalMatch) Status() string             { return "" }
+func (m *LocalMatch) Err() error                 { return nil }
+func (m *LocalMatch) Spectating() bool           { return m.spectate }
+func (m *LocalMatch) HandsVisible() bool         { return m.spectate }
+func (m *LocalMatch) Log() []string              { return m.g.MatchLog.Lines }
+
+func (m *LocalMatch) hotSeat() bool { return m.humans > 1 }
+
+// recorded is true for matches of the single local player, which are saved
+// and count for their profile
+func (m *LocalMatch) recorded() bool { return !m.spectate && !m.hotSeat() }
+
+// Players starts from the player holding the device in a hot-seat match, so
+// their hand is the one shown at the bottom
+func (m *LocalMatch) Players() []*entity.Player {
+	i := slices.Index(m.g.Players, m.g.Player)
+	if !m.hotSeat() || i <= 0 {
+		return m.g.Players
+	}
+	return append(slices.Clone(m.g.Players[i:]), m.g.Players[:i]...)
+}
+
+func (m *LocalMatch) Handover() string {
+	if !m.hotSeat() || m.g.Engine.IsOver() {
+		return ""
+	}
+	current := m.g.TurnManager.Current()
+	if current == nil || current.IsBot || current.Finished {
+		return ""
+	}
+	if m.curtain || current.ID != m.g.Player.ID {
+		return m.g.GetPlayer(current.ID).Name
+	}
+	return ""
+}
+
+func (m *LocalMatch) TakeSeat() {
+	if current := m.g.TurnManager.Current(); current != nil && !current.IsBot {
+		m.g.Player = m.g.GetPlayer(current.ID)
+	}
+	m.curtain = false
+}
+
+func (m *LocalMatch) Play(cardID string, target entity.Target) error {
+	if m.spectate {
+		return errSpectator
+	}
+	return m.g.PlayCardWithTarget(m.g.Player.ID, cardID, target)
+}
+
+func (m *LocalMatch) Pass() error {
+	if m.spectate {
+		return errSpectator
+	}
+	return m.g.Engine.Pass(m.g.Player.ID)
+}
+
+func (m *LocalMatch) LegalTargets(cardID string) []entity.Target {
+	if m.spectate {
+		return nil
+	}
+	return m.g.LegalTargets(m.g.Player.ID, cardID)
+}
+
+func (m *LocalMatch) CompletingRecipe(c *entity.Card) *entity.Card {
+	return m.g.CardManager.CompletingRecipe(c)
+}
+
+// Hint pauses achievements for the rest of the match, the first hint says so
+func (m *LocalMatch) Hint() (*Hint, error) {
+	if m.spectate {
+		return nil, errSpectator
+	}
+	if !m.g.Settings.Hints {
+		return nil, errHintsOff
+	}
+	h := m.g.Hint()
+	if m.recorded() && m.g.HintsUsed == 1 {
+		m.g.Toast.Show("Hint used", "Achievements are paused for the rest of this match")
+	}
+	return h, nil
+}
+
+func (m *LocalMatch) IngredientNames() map[string]string {
+	return m.g.CardManager.GetMapIngredientNames()
+}
+
+// End records the result, the saved copy is no longer needed. Watched and
+// hot-seat matches leave the saved one alone.
+func (m *LocalMatch) End() {
+	if !m.recorded() {
+		return
+	}
+	m.g.RecordMatch()
+	m.g.EndMatch()
+}
+
+// Leave saves the match so it can be continued from the main menu
+func (m *LocalMatch) Leave() {
+	if !m.recorded() {
+		return
+	}
+	m.g.SuspendMatch()
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/save"
 	"github.com/thanhfphan/ebitengj2025/internal/ui"
 	"github.com/thanhfphan/ebitengj2025/internal/view"
 	"golang.org/x/image/font"
@@ -28,6 +32,29 @@ type PlayingScene struct {
 	bgImage      *ebiten.Image
 	playBtn      *ui.UIButton
 	passBtn      *ui.UIButton
+	scoreLabel   *ui.UILabel
+	statusLabel  *ui.UILabel // Waiting for players or the server
+	match        Match
+	ready        bool // Set once the match is set up and opponents are seated
+
+	// Spectating
+	logLabel   *ui.UILabel  // Recent events of the match
+	godViewBtn *ui.UIButton // Shown when the hands of every player can be revealed
+	godView    bool
+
+	// Hot-seat curtain, hides the last hand until the device was passed
+	curtainLabel *ui.UILabel
+	curtainBtn   *ui.UIButton
+
+	// Hint of the best move, kept until the player acts
+	hintBtn   *ui.UIButton
+	hintLabel *ui.UILabel
+	hint      *Hint
+
+	// Target prompt for action cards
+	pendingCardID   string
+	targetLabel     *ui.UILabel
+	cancelTargetBtn *ui.UIButton
 }
 
 type PauseMenu struct {
@@ -36,13 +63,24 @@ type PauseMenu struct {
 }
 
 type GameOverMenu struct {
-	elements []ui.Element
-	overlay  *ebiten.Image
-	visible  bool
+	elements    []ui.Element
+	overlay     *ebiten.Image
+	visible     bool
+	titleLabel  *ui.UILabel
+	resultLabel *ui.UILabel
+	newGameBtn  *ui.UIButton
+	menuBtn     *ui.UIButton
 }
 
+// NewPlayingScene starts a new match against bots
 func NewPlayingScene() *PlayingScene {
+	return NewMatchScene(NewLocalMatch(DefaultBots))
+}
+
+// NewMatchScene plays the given match
+func NewMatchScene(m Match) *PlayingScene {
 	return &PlayingScene{
+		match:        m,
 		elements:     []ui.Element{},
 		botHands:     []*ui.UIBotHand{},
 		isPaused:     false,
@@ -54,6 +92,11 @@ func NewPlayingScene() *PlayingScene {
 	}
 }
 
+// NewResumedPlayingScene continues a saved match
+func NewResumedPlayingScene(f *save.File) *PlayingScene {
+	return NewMatchScene(NewResumedLocalMatch(f))
+}
+
 func (s *PlayingScene) Enter(g *Game) {
 	g.CurrentUIManager = s.uiManager
 	if s.isPaused { // FIXME: might want to add resume method instead??
@@ -89,8 +132,8 @@ func (s *PlayingScene) Enter(g *Game) {
 	handHeight := 160
 	s.playerHand = ui.NewUIHand(centerX-handWidth/2, 600, handWidth, handHeight)
 	s.playerHand.SetOnPlayCard(func(cardID string) {
-		if g.Player != nil {
-			g.PlayCard(g.Player.ID, cardID)
+		if s.match.Player() != nil {
+			s.playCard(g, cardID)
 		}
 	})
 
@@ -109,8 +152,12 @@ func (s *PlayingScene) Enter(g *Game) {
 	btnX := centerX + 300
 	passBtn := ui.NewUIButton(btnX, 600, 100, 40, "Pass", defaultFont)
 	passBtn.OnClick = func() {
-		if g.Player != nil {
-			g.Pass(g.Player.ID)
+		if s.match.Player() == nil {
+			return
+		}
+		s.clearHint()
+		if err := s.match.Pass(); err != nil {
+			fmt.Println("Cannot pass:", err)
 		}
 	}
 	s.uiManager.AddElement(passBtn)
@@ -125,6 +172,83 @@ func (s *PlayingScene) Enter(g *Game) {
 	s.elements = append(s.elements, playBtn)
 	s.playBtn = playBtn
 
+	hintBtn := ui.NewUIButton(btnX+110, 600, 100, 40, "Hint", defaultFont)
+	hintBtn.OnClick = func() {
+		s.showHint()
+	}
+	s.uiManager.AddElement(hintBtn)
+	s.elements = append(s.elements, hintBtn)
+	s.hintBtn = hintBtn
+
+	s.hintLabel = ui.NewUILabel(centerX, 545, "", g.TextFont(18))
+	s.hintLabel.AlignCenter()
+	s.hintLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	s.hintLabel.SetVisible(false)
+	s.uiManager.AddElement(s.hintLabel)
+	s.elements = append(s.elements, s.hintLabel)
+
+	s.scoreLabel = ui.NewUILabel(20, 40, "", g.TextFont(18))
+	s.uiManager.AddElement(s.scoreLabel)
+	s.elements = append(s.elements, s.scoreLabel)
+
+	s.statusLabel = ui.NewUILabel(centerX, centerY, "", defaultFont)
+	s.statusLabel.AlignCenter()
+	s.statusLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	s.uiManager.AddElement(s.statusLabel)
+	s.elements = append(s.elements, s.statusLabel)
+
+	// Target prompt, hidden until an action card needs a target
+	s.targetLabel = ui.NewUILabel(centerX, 80, "", defaultFont)
+	s.targetLabel.AlignCenter()
+	s.targetLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	s.targetLabel.SetVisible(false)
+	s.uiManager.AddElement(s.targetLabel)
+	s.elements = append(s.elements, s.targetLabel)
+
+	cancelTargetBtn := ui.NewUIButton(btnX, 550, 100, 40, "Cancel", defaultFont)
+	cancelTargetBtn.OnClick = func() {
+		s.stopTargeting()
+	}
+	cancelTargetBtn.SetVisible(false)
+	s.uiManager.AddElement(cancelTargetBtn)
+	s.elements = append(s.elements, cancelTargetBtn)
+	s.cancelTargetBtn = cancelTargetBtn
+
+	// Event log and god view, for spectators
+	s.logLabel = ui.NewUILabel(20, 90, "", g.TextFont(18))
+	s.logLabel.SetVisible(false)
+	s.uiManager.AddElement(s.logLabel)
+	s.elements = append(s.elements, s.logLabel)
+
+	godViewBtn := ui.NewUIButton(btnX, 650, 160, 40, "", defaultFont)
+	godViewBtn.OnClick = func() {
+		s.godView = !s.godView
+	}
+	godViewBtn.SetVisible(false)
+	s.uiManager.AddElement(godViewBtn)
+	s.elements = append(s.elements, godViewBtn)
+	s.godViewBtn = godViewBtn
+
+	s.curtainLabel = ui.NewUILabel(centerX, 560, "", defaultFont)
+	s.curtainLabel.AlignCenter()
+	s.curtainLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	s.curtainLabel.SetVisible(false)
+	s.uiManager.AddElement(s.curtainLabel)
+	s.elements = append(s.elements, s.curtainLabel)
+
+	curtainBtn := ui.NewUIButton(centerX-150, 600, 300, 50, "", defaultFont)
+	curtainBtn.BackgroundColor = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+	curtainBtn.HoverColor = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+	curtainBtn.PressedColor = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+	curtainBtn.TextColor = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+	curtainBtn.OnClick = func() {
+		s.match.TakeSeat()
+	}
+	curtainBtn.SetVisible(false)
+	s.uiManager.AddElement(curtainBtn)
+	s.elements = append(s.elements, curtainBtn)
+	s.curtainBtn = curtainBtn
+
 	s.initPauseMenu(g)
 	s.initGameOverMenu(g)
 
@@ -132,8 +256,15 @@ func (s *PlayingScene) Enter(g *Game) {
 }
 
 func (s *PlayingScene) setupGame(g *Game) {
-	numBots := 3
-	s.botHands = g.setupGameData(numBots)
+	botHands, ready := s.match.Setup(g)
+	if !ready {
+		s.statusLabel.Text = "Waiting for the other players..."
+		return
+	}
+	s.ready = true
+	s.statusLabel.Text = ""
+	s.botHands = botHands
+	numBots := len(botHands)
 
 	// Position bot hands
 	reserved := math.Pi / 3
@@ -153,6 +284,7 @@ func (s *PlayingScene) setupGame(g *Game) {
 }
 
 func (s *PlayingScene) Exit(g *Game) {
+	s.match.Leave()
 }
 
 func (s *PlayingScene) Update(g *Game) {
@@ -166,28 +298,203 @@ func (s *PlayingScene) Update(g *Game) {
 		return
 	}
 
+	s.match.Update()
+	if err := s.match.Err(); err != nil {
+		fmt.Println("Match stopped:", err)
+		s.showStoppedMenu(err)
+		return
+	}
+	if !s.ready {
+		s.setupGame(g)
+		if !s.ready {
+			return
+		}
+	}
+
 	// Check for game over
-	if winnerOrder := g.TurnManager.FinishedOrder(); len(winnerOrder) == len(g.Players) {
-		fmt.Println("Game finished. Winner order:", winnerOrder)
-		s.showGameOverMenu()
+	if s.match.IsOver() {
+		fmt.Println("Game finished. Winner order:", s.match.FinishedOrder())
+		s.match.End()
+		s.UpdateHands(g)
+		s.showGameOverMenu(g)
 		return
 	}
 
+	s.statusLabel.Text = s.match.Status()
+	s.updateCurtain()
 	s.updateButtonStates(g)
-	g.UpdateTurn()
+	if s.hintBtn.IsVisible() && inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		s.showHint()
+	}
+	s.updateSpectatorView()
 	s.UpdateHands(g)
 }
 
+// updateCurtain asks to pass the device to the next human of a hot-seat
+// match, their hand stays hidden until they take it
+func (s *PlayingScene) updateCurtain() {
+	name := s.match.Handover()
+	closed := name != ""
+	if closed && !s.curtainBtn.IsVisible() {
+		s.stopTargeting()
+		s.tableCards.ResetCanMakeDish()
+	}
+	s.curtainLabel.SetVisible(closed)
+	s.curtainBtn.SetVisible(closed)
+	if closed {
+		s.curtainLabel.Text = "Pass the device to " + name
+		s.curtainBtn.Text = "I am " + name
+	}
+}
+
+// logLines is how many recent events the log panel shows
+const logLines = 12
+
+// updateSpectatorView shows the event log and the god view toggle while
+// watching a match
+func (s *PlayingScene) updateSpectatorView() {
+	spectating := s.match.Spectating()
+	s.logLabel.SetVisible(spectating)
+	s.godViewBtn.SetVisible(spectating && s.match.HandsVisible())
+	if !spectating {
+		return
+	}
+
+	lines := s.match.Log()
+	lines = lines[max(0, len(lines)-logLines):]
+	s.logLabel.Text = "Spectating\n\n" + strings.Join(lines, "\n")
+	s.godViewBtn.Text = "God view: " + onOff(s.godView)
+}
+
+// opponents returns the players whose hands are shown around the table, in
+// the order of botHands
+func (s *PlayingScene) opponents() []*entity.Player {
+	players := s.match.Players()
+	if s.match.Player() == nil || len(players) == 0 {
+		return players
+	}
+	return players[1:]
+}
+
 func (s *PlayingScene) updateButtonStates(g *Game) {
 	if s.playBtn == nil || s.passBtn == nil {
 		return
 	}
 
-	current := g.TurnManager.Current()
-	isPlayerTurn := current != nil && current.ID == g.Player.ID && !current.Finished
+	current := s.match.Current()
+	player := s.match.Player()
+	isPlayerTurn := current != nil && player != nil && current.ID == player.ID && !current.Finished
+	canAct := isPlayerTurn && s.pendingCardID == "" && !s.match.Busy() && !s.curtainBtn.IsVisible()
 
-	s.playBtn.SetVisible(isPlayerTurn)
-	s.passBtn.SetVisible(isPlayerTurn)
+	s.playBtn.SetVisible(canAct)
+	s.passBtn.SetVisible(canAct)
+	s.hintBtn.SetVisible(canAct && g.Settings.Hints)
+	if !isPlayerTurn || s.curtainBtn.IsVisible() {
+		s.clearHint()
+	}
+}
+
+// showHint highlights the suggested card and shows why. The hint is kept
+// for the turn so asking again does not count twice.
+func (s *PlayingScene) showHint() {
+	if s.hint == nil {
+		h, err := s.match.Hint()
+		if err != nil {
+			s.hintLabel.Text = err.Error()
+			s.hintLabel.SetVisible(true)
+			return
+		}
+		s.hint = h
+	}
+
+	cardID := ""
+	if s.hint.Card != nil {
+		cardID = s.hint.Card.ID
+	}
+	s.playerHand.SetHinted(cardID)
+	s.hintLabel.Text = s.hint.Text()
+	s.hintLabel.SetVisible(true)
+}
+
+func (s *PlayingScene) clearHint() {
+	s.hint = nil
+	s.playerHand.SetHinted("")
+	s.hintLabel.SetVisible(false)
+}
+
+// playCard plays a card from the player's hand, action cards that need a
+// target first prompt the player to pick one
+func (s *PlayingScene) playCard(g *Game, cardID string) {
+	selected := s.match.Player().GetCard(cardID)
+	if selected == nil || s.match.Busy() {
+		return
+	}
+	s.clearHint()
+
+	kind := rules.ChosenTarget(selected)
+	if kind == rules.TargetNone {
+		s.match.Play(cardID, entity.Target{})
+		return
+	}
+
+	targets := s.match.LegalTargets(cardID)
+	if len(targets) == 0 {
+		return
+	}
+
+	play := func(target entity.Target) {
+		s.stopTargeting()
+		s.match.Play(cardID, target)
+	}
+
+	s.pendingCardID = cardID
+	s.targetLabel.SetVisible(true)
+	s.cancelTargetBtn.SetVisible(true)
+
+	switch kind {
+	case rules.TargetOpponent:
+		s.targetLabel.Text = "Choose an opponent"
+		opponents := s.opponents()
+		for i, hand := range s.botHands {
+			if i >= len(opponents) {
+				continue
+			}
+			target := entity.Target{PlayerID: opponents[i].ID}
+			if !slices.Contains(targets, target) {
+				continue
+			}
+			hand.Highlighted = true
+			hand.OnClick = func() { play(target) }
+		}
+	case rules.TargetTableCard:
+		s.targetLabel.Text = "Choose a card on the table"
+		s.tableCards.OnCardClicked = func(id string) {
+			target := entity.Target{CardID: id}
+			if slices.Contains(targets, target) {
+				play(target)
+			}
+		}
+	}
+}
+
+func (s *PlayingScene) scoreText(g *Game) string {
+	players := s.match.Players()
+	parts := make([]string, 0, len(players))
+	for _, p := range players {
+		parts = append(parts, fmt.Sprintf("%s: %d", p.Name, s.match.Score(p.ID)))
+	}
+	return "Score  " + strings.Join(parts, "  ")
+}
+
+func (s *PlayingScene) stopTargeting() {
+	s.pendingCardID = ""
+	s.targetLabel.SetVisible(false)
+	s.cancelTargetBtn.SetVisible(false)
+	s.tableCards.OnCardClicked = nil
+	for _, hand := range s.botHands {
+		hand.Highlighted = false
+		hand.OnClick = nil
+	}
 }
 
 func (s *PlayingScene) UpdateHands(g *Game) {
@@ -200,34 +507,50 @@ func (s *PlayingScene) UpdateHands(g *Game) {
 		"subtitle": g.AssetManager.GetFont("nunito", 12),
 		"body":     g.AssetManager.GetFont("nunito", 10),
 	}
-	ingredientNames := g.CardManager.GetMapIngredientNames()
-	viewTableStack := ToViewTableStack(g.CardManager.TableStack)
+	ingredientNames := s.match.IngredientNames()
+	table := s.match.Table()
+	viewTableStack := ToViewTableStack(table)
 
 	// Update table cards
 	s.tableCards.UpdateFromTableStack(viewTableStack, fonts, ingredientNames)
 
-	// Update player's hand
-	viewPlayerCards := make([]view.Card, 0, len(g.Player.Hand))
-	for _, id := range g.Player.OrderHand {
-		card := g.Player.GetCard(id)
-		viewPlayerCards = append(viewPlayerCards, ToViewCard(card))
+	s.scoreLabel.Text = s.scoreText(g)
+
+	// Update player's hand, spectators have none
+	if player := s.match.Player(); player != nil {
+		viewPlayerCards := make([]view.Card, 0, len(player.Hand))
+		for _, id := range player.OrderHand {
+			card := player.GetCard(id)
+			viewPlayerCards = append(viewPlayerCards, ToViewHandCard(card, table))
+		}
+		s.playerHand.UpdateCards(viewPlayerCards, fonts, ingredientNames)
 	}
-	s.playerHand.UpdateCards(viewPlayerCards, viewTableStack, fonts, ingredientNames)
+	s.playerHand.SetVisible(s.match.Player() != nil && !s.curtainBtn.IsVisible())
 
 	// Update bot hands
 	cardBackImage := g.AssetManager.GetImage(ImageCardBack)
+	reveal := s.godView && s.match.HandsVisible()
+	revealFont := g.TextFont(14)
+	opponents := s.opponents()
 	for i, botHand := range s.botHands {
-		if i+1 >= len(g.Players) {
+		if i >= len(opponents) {
 			continue
 		}
 
-		// Index 0 is the player, so we start from index 1
-		bot := g.Players[i+1]
+		bot := opponents[i]
 		botViewCards := make([]view.Card, 0, len(bot.Hand))
 		for _, card := range bot.Hand {
 			botViewCards = append(botViewCards, ToViewCard(card))
 		}
 		botHand.UpdateCards(botViewCards, cardBackImage)
+
+		botHand.Revealed = nil
+		if reveal {
+			for _, id := range bot.OrderHand {
+				botHand.Revealed = append(botHand.Revealed, bot.GetCard(id).Name)
+			}
+			botHand.RevealFont = revealFont
+		}
 	}
 }
 
@@ -274,14 +597,19 @@ func (s *PlayingScene) highlightMatchingRecipes(g *Game, cardID string) {
 		return
 	}
 
-	selectedCard := g.Player.GetCard(cardID)
-	if selectedCard == nil || selectedCard.Type != entity.CardTypeIngredient {
+	player := s.match.Player()
+	if player == nil {
+		return
+	}
+	selectedCard := player.GetCard(cardID)
+	if selectedCard == nil || !selectedCard.IsIngredient() {
 		return
 	}
 
 	s.tableCards.ResetCanMakeDish()
-	viewTableStack := ToViewTableStack(g.CardManager.TableStack)
-	s.tableCards.UpdateCanMakeDish(selectedCard.IngredientID, viewTableStack)
+	if recipe := s.match.CompletingRecipe(selectedCard); recipe != nil {
+		s.tableCards.HighlightCanMakeDish(recipe.ID)
+	}
 }
 
 // Initialize the pause menu
@@ -340,8 +668,20 @@ func (s *PlayingScene) initPauseMenu(g *Game) {
 	s.uiManager.AddElement(settingsBtn)
 	s.pauseMenu.elements = append(s.pauseMenu.elements, settingsBtn)
 
+	// Recipe book button
+	recipeBookBtn := ui.NewUIButton(centerX-btnWidth/2, startY+2*btnSpacing, btnWidth, btnHeight, "Recipe Book", defaultFont)
+	recipeBookBtn.BackgroundColor = colButtonBg
+	recipeBookBtn.HoverColor = colButtonHover
+	recipeBookBtn.PressedColor = colButtonPressed
+	recipeBookBtn.TextColor = colButtonText
+	recipeBookBtn.OnClick = func() {
+		g.PushScene(NewRecipeBookScene())
+	}
+	s.uiManager.AddElement(recipeBookBtn)
+	s.pauseMenu.elements = append(s.pauseMenu.elements, recipeBookBtn)
+
 	// Main menu button
-	menuBtn := ui.NewUIButton(centerX-btnWidth/2, startY+2*btnSpacing, btnWidth, btnHeight, "Return to Main Menu", defaultFont)
+	menuBtn := ui.NewUIButton(centerX-btnWidth/2, startY+3*btnSpacing, btnWidth, btnHeight, "Return to Main Menu", defaultFont)
 	menuBtn.BackgroundColor = colButtonBg
 	menuBtn.HoverColor = colButtonHover
 	menuBtn.PressedColor = colButtonPressed
@@ -389,11 +729,11 @@ func (s *PlayingScene) initGameOverMenu(g *Game) {
 	btnSpacing := 70
 
 	// Game over title
-	gameOverTitle := ui.NewUILabel(centerX, startY-80, "GAME OVER", titleFont)
-	gameOverTitle.AlignCenter()
-	gameOverTitle.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
-	s.uiManager.AddElement(gameOverTitle)
-	s.gameOverMenu.elements = append(s.gameOverMenu.elements, gameOverTitle)
+	s.gameOverMenu.titleLabel = ui.NewUILabel(centerX, startY-80, "GAME OVER", titleFont)
+	s.gameOverMenu.titleLabel.AlignCenter()
+	s.gameOverMenu.titleLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	s.uiManager.AddElement(s.gameOverMenu.titleLabel)
+	s.gameOverMenu.elements = append(s.gameOverMenu.elements, s.gameOverMenu.titleLabel)
 
 	// Button colors
 	colButtonBg := color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
@@ -401,6 +741,11 @@ func (s *PlayingScene) initGameOverMenu(g *Game) {
 	colButtonPressed := color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
 	colButtonText := color.RGBA{0x36, 0x55, 0x34, 0xFF}
 
+	s.gameOverMenu.resultLabel = ui.NewUILabel(centerX, startY-30, "", g.TextFont(24))
+	s.gameOverMenu.resultLabel.AlignCenter()
+	s.uiManager.AddElement(s.gameOverMenu.resultLabel)
+	s.gameOverMenu.elements = append(s.gameOverMenu.elements, s.gameOverMenu.resultLabel)
+
 	// New Game button
 	newGameBtn := ui.NewUIButton(centerX-btnWidth/2, startY, btnWidth, btnHeight, "New Game", defaultFont)
 	newGameBtn.BackgroundColor = colButtonBg
@@ -413,6 +758,7 @@ func (s *PlayingScene) initGameOverMenu(g *Game) {
 	}
 	s.uiManager.AddElement(newGameBtn)
 	s.gameOverMenu.elements = append(s.gameOverMenu.elements, newGameBtn)
+	s.gameOverMenu.newGameBtn = newGameBtn
 
 	// Main Menu button
 	mainMenuBtn := ui.NewUIButton(centerX-btnWidth/2, startY+btnSpacing, btnWidth, btnHeight, "Return to Main Menu", defaultFont)
@@ -426,13 +772,47 @@ func (s *PlayingScene) initGameOverMenu(g *Game) {
 	}
 	s.uiManager.AddElement(mainMenuBtn)
 	s.gameOverMenu.elements = append(s.gameOverMenu.elements, mainMenuBtn)
+	s.gameOverMenu.menuBtn = mainMenuBtn
 
 	for _, element := range s.gameOverMenu.elements {
 		element.SetVisible(false)
 	}
 }
 
-func (s *PlayingScene) showGameOverMenu() {
+func (s *PlayingScene) showGameOverMenu(g *Game) {
+	s.gameOverMenu.resultLabel.Text = s.standingsText()
+	s.showEndMenu()
+}
+
+// standingsText lists the players in finishing order with their scores
+func (s *PlayingScene) standingsText() string {
+	names := make(map[string]string)
+	for _, p := range s.match.Players() {
+		names[p.ID] = p.Name
+	}
+
+	result := ""
+	for i, id := range s.match.FinishedOrder() {
+		if name, ok := names[id]; ok {
+			result += fmt.Sprintf("%d. %s (%d)   ", i+1, name, s.match.Score(id))
+		}
+	}
+	return strings.TrimSpace(result)
+}
+
+// showStoppedMenu tells the player why the match stopped early
+func (s *PlayingScene) showStoppedMenu(err error) {
+	s.gameOverMenu.titleLabel.Text = "MATCH STOPPED"
+	s.gameOverMenu.resultLabel.Text = err.Error()
+	s.showEndMenu()
+}
+
+func (s *PlayingScene) showEndMenu() {
+	s.stopTargeting()
+	s.clearHint()
+	s.statusLabel.Text = ""
+	s.curtainLabel.SetVisible(false)
+	s.curtainBtn.SetVisible(false)
 	s.gameOverMenu.visible = true
 	for _, element := range s.gameOverMenu.elements {
 		element.SetVisible(true)
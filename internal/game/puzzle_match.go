@@ -0,0 +1,97 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/puzzle"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*PuzzleMatch)(nil)
+
+var (
+	errNoPassInPuzzles  = errors.New("every play counts in a puzzle, there is no passing")
+	errNoHintsInPuzzles = errors.New("hints are not available in puzzles")
+)
+
+// PuzzleMatch plays a puzzle on Game.Engine. The opponents pass, the match
+// is over once the goal is reached or cannot be anymore. Only the stars of
+// a solve are kept.
+type PuzzleMatch struct {
+	*LocalMatch
+	puzzle   *puzzle.Puzzle
+	attempt  *puzzle.Attempt
+	improved bool // The solve beat the best stars of the puzzle
+	err      error
+}
+
+func NewPuzzleMatch(p *puzzle.Puzzle) *PuzzleMatch {
+	return &PuzzleMatch{
+		LocalMatch: &LocalMatch{bots: len(p.Opponents)},
+		puzzle:     p,
+	}
+}
+
+func (m *PuzzleMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	g.AIManager.Clear()
+	a, err := m.puzzle.Start(g.Engine)
+	if err != nil {
+		m.err = fmt.Errorf("puzzle %s: %w", m.puzzle.ID, err)
+		return nil, true
+	}
+
+	m.attempt = a
+	g.Players = a.Players
+	g.Player = a.Player()
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.matchInProgress = false
+
+	hands := []*ui.UIBotHand{}
+	for range a.Players[1:] {
+		hands = append(hands, g.newOpponentHand())
+	}
+	return hands, true
+}
+
+// Attempt is nil until the match is set up
+func (m *PuzzleMatch) Attempt() *puzzle.Attempt { return m.attempt }
+
+// Improved reports whether the solve beat the best stars of the puzzle,
+// known once the match ended
+func (m *PuzzleMatch) Improved() bool { return m.improved }
+
+// Update has nothing to drive, the opponents pass right after each play
+func (m *PuzzleMatch) Update() {}
+
+func (m *PuzzleMatch) Play(cardID string, target entity.Target) error {
+	if err := m.attempt.Play(cardID, target); err != nil {
+		fmt.Println("Error playing card:", err)
+		return err
+	}
+	if err := m.g.AssetManager.PlaySound(SoundPlay); err != nil {
+		fmt.Println("Error playing sound:", err)
+	}
+	return nil
+}
+
+func (m *PuzzleMatch) Pass() error          { return errNoPassInPuzzles }
+func (m *PuzzleMatch) Hint() (*Hint, error) { return nil, errNoHintsInPuzzles }
+func (m *PuzzleMatch) IsOver() bool         { return m.attempt != nil && m.attempt.Done() }
+func (m *PuzzleMatch) Err() error           { return m.err }
+func (m *PuzzleMatch) Leave()               {}
+
+// End keeps the stars of a solve when they beat the best ones
+func (m *PuzzleMatch) End() {
+	if !m.g.Puzzles.Record(m.puzzle.ID, m.attempt.Stars()) {
+		return
+	}
+	m.improved = true
+	if err := m.g.Puzzles.Save(m.g.Store); err != nil {
+		fmt.Println("Error saving puzzle progress:", err)
+	}
+}
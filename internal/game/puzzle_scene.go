@@ -0,0 +1,91 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/internal/puzzle"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*PuzzleScene)(nil)
+
+// PuzzleScene plays a puzzle, its goal and the plays left are shown beside
+// the table
+type PuzzleScene struct {
+	*PlayingScene
+	puzzle    *puzzle.Puzzle
+	match     *PuzzleMatch
+	goalLabel *ui.UILabel
+}
+
+func NewPuzzleScene(p *puzzle.Puzzle) *PuzzleScene {
+	m := NewPuzzleMatch(p)
+	return &PuzzleScene{
+		PlayingScene: NewMatchScene(m),
+		puzzle:       p,
+		match:        m,
+	}
+}
+
+func (s *PuzzleScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.goalLabel != nil { // Back from the settings
+		return
+	}
+
+	s.goalLabel = ui.NewUILabel(20, 110, "", g.TextFont(18))
+	s.goalLabel.Wrap = 300
+	s.uiManager.AddElement(s.goalLabel)
+	s.elements = append(s.elements, s.goalLabel)
+
+	s.gameOverMenu.newGameBtn.Text = "Retry"
+	s.gameOverMenu.newGameBtn.OnClick = func() {
+		g.ReplaceScene(NewPuzzleScene(s.puzzle))
+	}
+	s.gameOverMenu.menuBtn.Text = "Puzzles"
+	s.gameOverMenu.menuBtn.OnClick = func() {
+		g.PopScene()
+		g.PushScene(NewMainMenuScene())
+		g.PushScene(NewPuzzleSelectScene())
+	}
+}
+
+func (s *PuzzleScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+
+	a := s.match.Attempt()
+	if a == nil {
+		s.goalLabel.SetVisible(false)
+		return
+	}
+	s.goalLabel.SetVisible(!s.isPaused && !s.gameOverMenu.visible)
+	s.goalLabel.Text = s.goalText(a)
+	s.passBtn.SetVisible(false)
+	s.hintBtn.SetVisible(false)
+
+	if s.gameOverMenu.visible && s.match.Err() == nil {
+		s.gameOverMenu.titleLabel.Text = "NOT SOLVED"
+		s.gameOverMenu.resultLabel.Text = s.puzzle.Goal.Text()
+		if stars := a.Stars(); stars > 0 {
+			s.gameOverMenu.titleLabel.Text = "SOLVED"
+			s.gameOverMenu.resultLabel.Text = fmt.Sprintf("%d of 3 stars in %d plays", stars, a.Plays)
+			if s.match.Improved() {
+				s.gameOverMenu.resultLabel.Text += ", a new best"
+			}
+		}
+	}
+}
+
+func (s *PuzzleScene) goalText(a *puzzle.Attempt) string {
+	lines := []string{s.puzzle.Name, "", s.puzzle.Description, "", s.puzzle.Goal.Text()}
+	if s.puzzle.Goal.Type == puzzle.GoalDishes {
+		lines = append(lines, fmt.Sprintf("Dishes: %d of %d", a.Dishes(), s.puzzle.Goal.Count))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Plays left: %d", s.puzzle.Goal.Plays-a.Plays),
+		"",
+		fmt.Sprintf("Three stars in %d plays, two in %d", s.puzzle.Stars.Three, s.puzzle.Stars.Two),
+	)
+	return strings.Join(lines, "\n")
+}
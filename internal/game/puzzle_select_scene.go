@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/puzzle"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*PuzzleSelectScene)(nil)
+
+// PuzzleSelectScene lists the puzzles with the best stars earned on each
+type PuzzleSelectScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+}
+
+func NewPuzzleSelectScene() *PuzzleSelectScene {
+	return &PuzzleSelectScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *PuzzleSelectScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 100
+	btnW, btnH := 420, 50
+
+	makeBtn := func(label string, x, y, w int, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, btnH, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	puzzles, err := puzzle.List()
+	if err != nil {
+		fmt.Println("Error loading puzzles:", err)
+	}
+
+	title := ui.NewUILabel(cx, startY, fmt.Sprintf("PUZZLES %d/%d", g.Puzzles.Total(), 3*len(puzzles)), titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	if err != nil {
+		message := ui.NewUILabel(cx, startY+60, "The puzzles could not be loaded.", g.TextFont(18))
+		message.AlignCenter()
+		s.uiManager.AddElement(message)
+		s.elements = append(s.elements, message)
+	}
+
+	y := startY + 50
+	for i, p := range puzzles {
+		makeBtn(fmt.Sprintf("%d. %s", i+1, p.Name), cx-btnW/2, y, btnW, func() {
+			g.PopScene() // Leave the main menu below for the puzzle
+			g.ReplaceScene(NewPuzzleScene(p))
+		})
+
+		status := ui.NewUILabel(cx+btnW/2+20, y+33, puzzleStatus(g.Puzzles.Stars[p.ID]), g.TextFont(18))
+		status.TextColor = colTitle
+		s.uiManager.AddElement(status)
+		s.elements = append(s.elements, status)
+		y += btnH + 16
+	}
+
+	makeBtn("Back", cx-100, ScreenH-80, 200, func() {
+		g.PopScene()
+	})
+}
+
+func puzzleStatus(stars int) string {
+	if stars == 0 {
+		return "Not solved"
+	}
+	return fmt.Sprintf("%d of 3 stars", stars)
+}
+
+func (s *PuzzleSelectScene) Exit(g *Game) {
+}
+
+func (s *PuzzleSelectScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *PuzzleSelectScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *PuzzleSelectScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
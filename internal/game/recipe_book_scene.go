@@ -0,0 +1,263 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+	"golang.org/x/image/font"
+)
+
+var _ Scene = (*RecipeBookScene)(nil)
+
+const recipesPerPage = 8
+
+// RecipeBookScene lists the recipes of the loaded deck. Recipes the player
+// has never made are shown as silhouettes.
+type RecipeBookScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+
+	entries   []recipeEntry
+	page      int
+	pageLabel *ui.UILabel
+	prevBtn   *ui.UIButton
+	nextBtn   *ui.UIButton
+
+	titleFont font.Face
+	bodyFont  font.Face
+}
+
+type recipeEntry struct {
+	name         string
+	requirements []string
+	cooked       int
+}
+
+func NewRecipeBookScene() *RecipeBookScene {
+	return &RecipeBookScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *RecipeBookScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+	s.titleFont = defaultFont
+	s.bodyFont = g.TextFont(18)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+
+	title := ui.NewUILabel(cx, 80, "RECIPE BOOK", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+	s.uiManager.AddElement(title)
+	s.elements = append(s.elements, title)
+
+	s.loadEntries(g)
+
+	makeBtn := func(x, y, w int, label string, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, 50, label, defaultFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	s.prevBtn = makeBtn(cx-330, ScreenH-80, 120, "Prev", func() {
+		s.setPage(s.page - 1)
+	})
+	makeBtn(cx-100, ScreenH-80, 200, "Back", func() {
+		g.PopScene()
+	})
+	s.nextBtn = makeBtn(cx+210, ScreenH-80, 120, "Next", func() {
+		s.setPage(s.page + 1)
+	})
+
+	s.pageLabel = ui.NewUILabel(cx, ScreenH-95, "", s.bodyFont)
+	s.pageLabel.AlignCenter()
+	s.uiManager.AddElement(s.pageLabel)
+	s.elements = append(s.elements, s.pageLabel)
+
+	s.setPage(0)
+}
+
+// loadEntries reads the recipes of the deck in play, or of the default deck
+// outside of a match
+func (s *RecipeBookScene) loadEntries(g *Game) {
+	names := g.CardManager
+	if names.Theme == "" {
+		names = card.NewManager()
+		if err := names.LoadDeck("default"); err != nil {
+			fmt.Println("Error loading deck:", err)
+		}
+	}
+	ingredientNames := names.GetMapIngredientNames()
+
+	cfg, err := card.ReadDeckConfig(names.Theme)
+	if err != nil {
+		fmt.Println("Error reading deck config:", err)
+		return
+	}
+
+	// Dishes of the match in progress are only added to the profile at the end
+	cooked := make(map[string]int)
+	for id, n := range g.Profile.Dishes {
+		cooked[id] = n
+	}
+	if g.matchInProgress && g.Player != nil {
+		for _, id := range g.Engine.Dishes[g.Player.ID] {
+			cooked[id]++
+		}
+	}
+
+	s.entries = nil
+	for _, r := range cfg.Recipes.Recipes {
+		entry := recipeEntry{
+			name:   r.Name,
+			cooked: cooked[r.ID],
+		}
+		for _, req := range r.Requires {
+			name := ingredientNames[req.ID]
+			if req.Tag != "" {
+				name = "any " + req.Tag
+			}
+			if req.Count > 1 {
+				name = fmt.Sprintf("%s x%d", name, req.Count)
+			}
+			entry.requirements = append(entry.requirements, name)
+		}
+		s.entries = append(s.entries, entry)
+	}
+}
+
+func (s *RecipeBookScene) pages() int {
+	return max(1, (len(s.entries)+recipesPerPage-1)/recipesPerPage)
+}
+
+func (s *RecipeBookScene) setPage(page int) {
+	s.page = max(0, min(page, s.pages()-1))
+	s.pageLabel.Text = fmt.Sprintf("Page %d/%d", s.page+1, s.pages())
+	s.prevBtn.SetVisible(s.page > 0)
+	s.nextBtn.SetVisible(s.page < s.pages()-1)
+}
+
+func (s *RecipeBookScene) Exit(g *Game) {
+}
+
+func (s *RecipeBookScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		s.setPage(s.page - 1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		s.setPage(s.page + 1)
+	}
+}
+
+func (s *RecipeBookScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+
+	// Four tiles per row
+	tileW, tileH, gap := 270, 240, 20
+	left := ScreenW/2 - (4*tileW+3*gap)/2
+	top := 120
+	start := s.page * recipesPerPage
+	for i := start; i < min(start+recipesPerPage, len(s.entries)); i++ {
+		x := left + ((i-start)%4)*(tileW+gap)
+		y := top + ((i-start)/4)*(tileH+gap)
+		s.drawEntry(screen, s.entries[i], x, y, tileW, tileH)
+	}
+}
+
+func (s *RecipeBookScene) drawEntry(screen *ebiten.Image, e recipeEntry, x, y, w, h int) {
+	discovered := e.cooked > 0
+
+	bg := color.RGBA{0xFF, 0xF5, 0xCC, 0xFF} // Recipe: #FFF5CC
+	textColor := color.RGBA{0x44, 0x44, 0x44, 0xFF}
+	if !discovered {
+		bg = color.RGBA{0x2A, 0x2A, 0x2A, 0xE6}
+		textColor = color.RGBA{0x77, 0x77, 0x77, 0xFF}
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), bg, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(w), float32(h), 2, color.RGBA{0xAA, 0xAA, 0xAA, 0xFF}, false)
+
+	// Decks ship no recipe images, a disc with the first letter of the dish
+	// stands for it once cooked and a dark one with a question mark before
+	iconSize := 64
+	iconX, iconY := x+w/2-iconSize/2, y+12
+	disc := color.RGBA{0xD6, 0x86, 0x00, 0xFF} // #D68600
+	letter := strings.ToUpper(string([]rune(e.name)[:1]))
+	if !discovered {
+		disc = color.RGBA{0x11, 0x11, 0x11, 0xFF}
+		letter = "?"
+	}
+	r := float32(iconSize) / 2
+	vector.DrawFilledCircle(screen, float32(iconX)+r, float32(iconY)+r, r, disc, false)
+	bounds := text.BoundString(s.titleFont, letter)
+	text.Draw(screen, letter, s.titleFont, iconX+iconSize/2-bounds.Dx()/2, iconY+iconSize/2+bounds.Dy()/2, color.White)
+
+	name := e.name
+	if !discovered {
+		name = "???"
+	}
+	bounds = text.BoundString(s.titleFont, name)
+	text.Draw(screen, name, s.titleFont, x+w/2-bounds.Dx()/2, y+110, textColor)
+
+	lineY := y + 140
+	for _, req := range e.requirements {
+		if !discovered {
+			req = "?"
+		}
+		text.Draw(screen, "- "+req, s.bodyFont, x+16, lineY, textColor)
+		lineY += 20
+	}
+
+	text.Draw(screen, fmt.Sprintf("Cooked: %d", e.cooked), s.bodyFont, x+16, y+h-12, textColor)
+}
+
+func (s *RecipeBookScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
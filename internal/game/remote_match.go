@@ -0,0 +1,410 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/netplay"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*RemoteMatch)(nil)
+
+// Reconnecting after a lost connection is tried for a while, waiting longer
+// after each failure. The server lets a bot play the seat meanwhile.
+const (
+	reconnectWindow   = time.Minute
+	reconnectRetry    = 2 * time.Second
+	reconnectMaxRetry = 15 * time.Second
+	reclaimWait       = 10 * time.Second // For the server to answer a reclaim
+)
+
+// RemoteMatch mirrors a match hosted by a netplay server. The server runs
+// the rules, this side only shows the last state it sent and forwards the
+// player's intents.
+type RemoteMatch struct {
+	link  *serverLink
+	token string // Session token of our seat
+
+	reconnecting chan reconnectResult // Set while trying to reach the server again
+	reclaiming   bool                 // Seat reclaimed, waiting for the state of the match
+	left         chan struct{}        // Closed once the player is done with the match
+	leftOnce     sync.Once
+
+	view    *netplay.MatchView // Last state sent by the server
+	player  *entity.Player
+	players []*entity.Player
+	table   *entity.TableStack
+	cards   *card.Manager // Local copy of the deck, for recipe hints and names
+	pending bool          // An intent was sent and its answer has not arrived
+	err     error
+}
+
+// newRemoteMatch follows the match of the room the link just got a welcome
+// from
+func newRemoteMatch(link *serverLink) *RemoteMatch {
+	return &RemoteMatch{
+		link:  link,
+		token: link.client.Token,
+		left:  make(chan struct{}),
+		table: entity.NewTableStack(),
+		cards: card.NewManager(),
+	}
+}
+
+type reconnectResult struct {
+	link *serverLink
+	err  error
+}
+
+func (m *RemoteMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	if m.view == nil {
+		return nil, false
+	}
+
+	others := m.players
+	if m.player != nil {
+		others = m.players[1:]
+	}
+	hands := make([]*ui.UIBotHand, 0, len(others))
+	for range others {
+		hands = append(hands, g.newOpponentHand())
+	}
+	return hands, true
+}
+
+func (m *RemoteMatch) Update() {
+	if m.reconnecting != nil {
+		select {
+		case r := <-m.reconnecting:
+			m.reconnecting = nil
+			if r.err != nil {
+				m.err = fmt.Errorf("lost connection to the server: %w", r.err)
+				return
+			}
+			m.link = r.link
+			m.token = r.link.client.Token
+			m.reclaiming = true
+		default:
+			return
+		}
+	}
+
+	for m.err == nil && m.reconnecting == nil {
+		in, ok := m.link.poll()
+		if !ok {
+			return
+		}
+		m.handle(in)
+	}
+}
+
+func (m *RemoteMatch) handle(in linkMessage) {
+	if in.err != nil {
+		switch {
+		case m.IsOver():
+			// The server may hang up once the match is over
+		case m.token != "":
+			fmt.Println("Lost connection to the server, reconnecting:", in.err)
+			m.reconnect()
+		default:
+			m.err = fmt.Errorf("lost connection to the server: %w", in.err)
+		}
+		return
+	}
+
+	switch in.msg.Type {
+	case netplay.MsgWelcome:
+		m.token = in.msg.Token
+	case netplay.MsgState:
+		if in.msg.State == nil {
+			return
+		}
+		m.pending = false
+		m.reclaiming = false
+		m.apply(in.msg.State)
+	case netplay.MsgError:
+		if m.view == nil {
+			// No state to fall back to yet
+			m.err = errors.New(in.msg.Error)
+			return
+		}
+		fmt.Println("Server refused the move:", in.msg.Error)
+		m.pending = false
+		m.apply(m.view)
+	}
+}
+
+// reconnect tries to reach the server again in the background and reclaim
+// the seat with the session token
+func (m *RemoteMatch) reconnect() {
+	m.link.close()
+	old, token := m.link, m.token
+	result := make(chan reconnectResult, 1)
+	m.reconnecting = result
+
+	go func() {
+		deadline := time.Now().Add(reconnectWindow)
+		wait := reconnectRetry
+		for {
+			link, err := old.redial()
+			if err == nil {
+				if err = reclaimSeat(link, token); err == nil {
+					select {
+					case <-m.left:
+						link.close()
+					default:
+						result <- reconnectResult{link: link}
+					}
+					return
+				}
+				link.close()
+			}
+			fmt.Println("Reconnecting failed:", err)
+			if time.Now().After(deadline) {
+				result <- reconnectResult{err: err}
+				return
+			}
+
+			select {
+			case <-time.After(wait):
+				wait = min(2*wait, reconnectMaxRetry)
+			case <-m.left:
+				return
+			}
+		}
+	}()
+}
+
+// reclaimSeat asks for the seat of the session token on a fresh link and
+// waits for the welcome, the state of the match follows on the link
+func reclaimSeat(link *serverLink, token string) error {
+	if err := link.client.Reclaim(token); err != nil {
+		return err
+	}
+	timeout := time.After(reclaimWait)
+	for {
+		select {
+		case in := <-link.incoming:
+			switch {
+			case in.err != nil:
+				return in.err
+			case in.msg.Type == netplay.MsgWelcome:
+				return nil
+			case in.msg.Type == netplay.MsgError:
+				return errors.New(in.msg.Error)
+			}
+			// The lobby sent on the hello is of no use here
+		case <-timeout:
+			return errors.New("the server did not answer the reclaim")
+		}
+	}
+}
+
+// apply rebuilds the local players and table from a state of the server.
+// Opponents get placeholder cards, only how many they hold is known, unless
+// a spectator was sent every hand.
+func (m *RemoteMatch) apply(v *netplay.MatchView) {
+	m.view = v
+	if v.Deck != m.cards.Theme {
+		if err := m.cards.LoadDeck(v.Deck); err != nil {
+			fmt.Println("Error loading deck", v.Deck+":", err)
+		}
+	}
+
+	known := make(map[string]*entity.Player)
+	for _, p := range m.players {
+		known[p.ID] = p
+	}
+
+	// The local seat first, then the others in turn order. Spectators have
+	// no seat and see the turn order as is.
+	m.player = nil
+	start := 0
+	for i, pv := range v.Players {
+		if pv.ID == v.Seat {
+			start = i
+		}
+	}
+	m.players = m.players[:0]
+	for i := range v.Players {
+		pv := v.Players[(start+i)%len(v.Players)]
+		p, ok := known[pv.ID]
+		if !ok {
+			ttype := entity.TypePlayer
+			if pv.IsBot {
+				ttype = entity.TypeBot
+			}
+			p = &entity.Player{Entity: entity.Entity{ID: pv.ID, Name: pv.Name, EntityType: ttype}}
+		}
+		p.Name = pv.Name
+		if pv.Away {
+			p.Name += " (away)"
+		}
+		p.Hand = make(map[string]*entity.Card)
+		p.OrderHand = []string{}
+
+		switch {
+		case v.Seat != "" && pv.ID == v.Seat:
+			for _, c := range v.Hand {
+				p.AddCard(c)
+			}
+			m.player = p
+		case v.Hands != nil:
+			for _, c := range v.Hands[pv.ID] {
+				p.AddCard(c)
+			}
+		default:
+			for j := 0; j < pv.Cards; j++ {
+				p.AddCard(&entity.Card{Entity: entity.Entity{
+					ID:         fmt.Sprintf("%s-%d", pv.ID, j),
+					EntityType: entity.TypeCard,
+				}})
+			}
+		}
+		m.players = append(m.players, p)
+	}
+
+	m.table = entity.NewTableStack()
+	for _, tc := range v.Table {
+		m.table.AddCard(tc.Card, tc.PlayerID)
+	}
+	m.cards.TableStack = m.table
+}
+
+func (m *RemoteMatch) playerView(id string) *netplay.PlayerView {
+	if m.view == nil {
+		return nil
+	}
+	for i := range m.view.Players {
+		if m.view.Players[i].ID == id {
+			return &m.view.Players[i]
+		}
+	}
+	return nil
+}
+
+func (m *RemoteMatch) Player() *entity.Player    { return m.player }
+func (m *RemoteMatch) Players() []*entity.Player { return m.players }
+func (m *RemoteMatch) Table() *entity.TableStack { return m.table }
+func (m *RemoteMatch) IsOver() bool              { return m.view != nil && m.view.Over }
+func (m *RemoteMatch) Busy() bool {
+	return m.pending || m.reconnecting != nil || m.reclaiming
+}
+
+func (m *RemoteMatch) Status() string {
+	if m.reconnecting != nil || m.reclaiming {
+		return "Connection lost, reconnecting..."
+	}
+	return ""
+}
+func (m *RemoteMatch) Err() error { return m.err }
+
+func (m *RemoteMatch) Spectating() bool   { return m.view != nil && m.view.Seat == "" }
+func (m *RemoteMatch) HandsVisible() bool { return m.view != nil && m.view.Hands != nil }
+
+// Every device plays its own seat online
+func (m *RemoteMatch) Handover() string { return "" }
+func (m *RemoteMatch) TakeSeat()        {}
+
+func (m *RemoteMatch) Log() []string {
+	if m.view == nil {
+		return nil
+	}
+	return m.view.Log
+}
+
+func (m *RemoteMatch) Current() *rules.PlayerTurn {
+	if m.view == nil {
+		return nil
+	}
+	pv := m.playerView(m.view.Current)
+	if pv == nil {
+		return nil
+	}
+	return &rules.PlayerTurn{ID: pv.ID, IsBot: pv.IsBot, Passed: pv.Passed, Finished: pv.Finished}
+}
+
+func (m *RemoteMatch) Score(playerID string) int {
+	if pv := m.playerView(playerID); pv != nil {
+		return pv.Score
+	}
+	return 0
+}
+
+func (m *RemoteMatch) FinishedOrder() []string {
+	if m.view == nil {
+		return nil
+	}
+	return m.view.FinishedOrder
+}
+
+// Play sends the move and hides the card until the server answers
+func (m *RemoteMatch) Play(cardID string, target entity.Target) error {
+	if m.Spectating() {
+		return errSpectator
+	}
+	if m.pending {
+		return errors.New("waiting for the server")
+	}
+	if err := m.link.client.Play(cardID, target); err != nil {
+		m.err = fmt.Errorf("lost connection to the server: %w", err)
+		return err
+	}
+	m.pending = true
+	m.player.RemoveCard(cardID)
+	return nil
+}
+
+func (m *RemoteMatch) Pass() error {
+	if m.Spectating() {
+		return errSpectator
+	}
+	if m.pending {
+		return errors.New("waiting for the server")
+	}
+	if err := m.link.client.Pass(); err != nil {
+		m.err = fmt.Errorf("lost connection to the server: %w", err)
+		return err
+	}
+	m.pending = true
+	return nil
+}
+
+func (m *RemoteMatch) LegalTargets(cardID string) []entity.Target {
+	if m.view == nil {
+		return nil
+	}
+	return m.view.Targets[cardID]
+}
+
+func (m *RemoteMatch) CompletingRecipe(c *entity.Card) *entity.Card {
+	return m.cards.CompletingRecipe(c)
+}
+
+func (m *RemoteMatch) IngredientNames() map[string]string {
+	return m.cards.GetMapIngredientNames()
+}
+
+// Hint is not offered online, where everyone plays on their own
+func (m *RemoteMatch) Hint() (*Hint, error) {
+	return nil, errNoHintsHere
+}
+
+// End closes the connection, results of online matches are not recorded
+func (m *RemoteMatch) End() {
+	m.leftOnce.Do(func() { close(m.left) })
+	m.link.close()
+}
+
+// Leave gives the seat to a bot for good, the player is not coming back
+func (m *RemoteMatch) Leave() {
+	m.leftOnce.Do(func() { close(m.left) })
+	_ = m.link.client.LeaveRoom()
+	m.link.close()
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*RestaurantMatch)(nil)
+
+// Pace of the restaurant mode, in turns of the table
+const (
+	RestaurantEvery      = 4  // Turns between two customers
+	RestaurantPatience   = 10 // Turns a customer waits for each dish
+	RestaurantReputation = 5
+)
+
+// RestaurantMatch is a regular match with customers ordering dishes, tips
+// count as points. Game.Engine is back to the regular rules once the match
+// ends or is left.
+type RestaurantMatch struct {
+	*LocalMatch
+
+	tips       int
+	served     int
+	expired    int
+	reputation int
+}
+
+func NewRestaurantMatch() *RestaurantMatch {
+	return &RestaurantMatch{
+		LocalMatch: &LocalMatch{bots: DefaultBots},
+	}
+}
+
+func (m *RestaurantMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	return g.setupRestaurantGameData(m.bots), true
+}
+
+// Orders is the state of the restaurant, nil once the match is over
+func (m *RestaurantMatch) Orders() *rules.Orders { return m.g.Engine.Orders }
+
+// Result of the service, known once the match ended: the player's tips, the
+// orders served in full, the customers who left and the reputation kept
+func (m *RestaurantMatch) Result() (tips, served, expired, reputation int) {
+	return m.tips, m.served, m.expired, m.reputation
+}
+
+// End keeps the result and puts the engine back to the regular rules
+func (m *RestaurantMatch) End() {
+	o := m.g.Engine.Orders
+	m.tips = o.Tips[m.g.Player.ID]
+	m.served = o.Served
+	m.expired = o.Expired
+	m.reputation = o.Reputation
+	m.g.Engine.Orders = nil
+}
+
+// Leave gives the match up
+func (m *RestaurantMatch) Leave() {
+	m.g.Engine.Orders = nil
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*RestaurantScene)(nil)
+
+// Order cards of the queue, on the left of the table
+const (
+	orderX, orderY = 20, 130
+	orderW, orderH = 300, 92
+	orderGap       = 10
+)
+
+// RestaurantScene plays a restaurant match. The queue of orders waiting is
+// shown beside the table, oldest first, with the reputation and the tips.
+type RestaurantScene struct {
+	*PlayingScene
+	match *RestaurantMatch
+
+	infoLabel *ui.UILabel
+	slots     []orderSlot
+}
+
+// orderSlot shows one order of the queue
+type orderSlot struct {
+	customer *ui.UILabel
+	dishes   *ui.UILabel
+	wait     *ui.UILabel
+	urgent   bool
+}
+
+func NewRestaurantScene() *RestaurantScene {
+	m := NewRestaurantMatch()
+	return &RestaurantScene{
+		PlayingScene: NewMatchScene(m),
+		match:        m,
+	}
+}
+
+func (s *RestaurantScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.infoLabel != nil { // Back from the settings
+		return
+	}
+
+	makeLabel := func(x, y int, size float64) *ui.UILabel {
+		l := ui.NewUILabel(x, y, "", g.TextFont(size))
+		l.SetVisible(false)
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	s.infoLabel = makeLabel(orderX, orderY-12, 18)
+	s.infoLabel.TextColor = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	for i := range s.match.Orders().MaxOpen {
+		y := orderY + i*(orderH+orderGap)
+		slot := orderSlot{
+			customer: makeLabel(orderX+12, y+26, 20),
+			dishes:   makeLabel(orderX+12, y+52, 18),
+			wait:     makeLabel(orderX+12, y+78, 16),
+		}
+		slot.customer.TextColor = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		slot.dishes.TextColor = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		slot.dishes.Wrap = orderW - 24
+		s.slots = append(s.slots, slot)
+	}
+
+	s.gameOverMenu.newGameBtn.OnClick = func() {
+		g.ReplaceScene(NewRestaurantScene())
+	}
+}
+
+func (s *RestaurantScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+
+	o := s.match.Orders()
+	show := o != nil && !s.isPaused && !s.gameOverMenu.visible
+	s.infoLabel.SetVisible(show)
+	for i := range s.slots {
+		slot := &s.slots[i]
+		open := show && i < len(o.Open)
+		slot.customer.SetVisible(open)
+		slot.dishes.SetVisible(open)
+		slot.wait.SetVisible(open)
+		if !open {
+			continue
+		}
+
+		order := o.Open[i]
+		names := make([]string, 0, len(order.Dishes))
+		for _, d := range order.Dishes {
+			names = append(names, d.Name)
+		}
+		left := o.TurnsLeft(order)
+		slot.urgent = left < RestaurantEvery
+		slot.customer.Text = order.Customer
+		slot.dishes.Text = strings.Join(names, ", ")
+		slot.wait.Text = fmt.Sprintf("Waits %d more turns, tip %d a dish", left, order.Tip)
+		if left == 0 {
+			slot.wait.Text = fmt.Sprintf("Leaves after this turn, tip %d a dish", order.Tip)
+		}
+		slot.wait.TextColor = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		if slot.urgent {
+			slot.wait.TextColor = color.RGBA{0xC0, 0x39, 0x2B, 0xFF}
+		}
+	}
+	if show {
+		s.infoLabel.Text = fmt.Sprintf("Orders  Reputation %d of %d  Your tips %d", o.Reputation, o.MaxReputation, o.Tips[g.Player.ID])
+		if len(o.Open) == 0 {
+			s.infoLabel.Text += "\nNo customer is waiting"
+		}
+	}
+
+	if s.gameOverMenu.visible {
+		tips, served, expired, reputation := s.match.Result()
+		s.gameOverMenu.titleLabel.Text = "SERVICE OVER"
+		s.gameOverMenu.resultLabel.Text = strings.Join([]string{
+			s.standingsText(),
+			fmt.Sprintf("Your tips %d, orders served %d, customers lost %d, reputation %d of %d", tips, served, expired, reputation, RestaurantReputation),
+		}, "\n")
+	}
+}
+
+// Draw puts the order cards under their labels
+func (s *RestaurantScene) Draw(screen *ebiten.Image, g *Game) {
+	s.PlayingScene.Draw(screen, g)
+
+	for i, slot := range s.slots {
+		if !slot.customer.IsVisible() {
+			continue
+		}
+		y := float32(orderY + i*(orderH+orderGap))
+		border := color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		if slot.urgent {
+			border = color.RGBA{0xC0, 0x39, 0x2B, 0xFF}
+		}
+		vector.DrawFilledRect(screen, orderX, y, orderW, orderH, color.RGBA{0xF3, 0xE2, 0xC3, 0xF0}, false)
+		vector.StrokeRect(screen, orderX, y, orderW, orderH, 3, border, false)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/save"
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// SaveMatch writes the match in progress so it can be continued later
+func (g *Game) SaveMatch() error {
+	if !g.matchInProgress {
+		return nil
+	}
+
+	f, err := save.Capture(g.Engine, g.AIManager)
+	if err != nil {
+		return err
+	}
+	f.Match.Hints = g.HintsUsed
+	achievements := g.Achievements.Match
+	f.Match.Achievements = &achievements
+	data, err := save.Encode(f)
+	if err != nil {
+		return err
+	}
+	if err := g.Store.Save(save.Key, data); err != nil {
+		return err
+	}
+	g.saveAchievements() // Keeps what the achievements know about this match
+	return nil
+}
+
+// SuspendMatch saves the match in progress when leaving it without finishing
+func (g *Game) SuspendMatch() {
+	if err := g.SaveMatch(); err != nil {
+		fmt.Println("Error saving match:", err)
+	}
+	g.matchInProgress = false
+}
+
+// LoadMatch reads the saved match, it fails with save.ErrUnsupported when the
+// save was made by a version of the game that cannot be loaded
+func (g *Game) LoadMatch() (*save.File, error) {
+	data, err := g.Store.Load(save.Key)
+	if err != nil {
+		return nil, err
+	}
+	return save.Decode(data)
+}
+
+func (g *Game) HasSavedMatch() bool {
+	_, err := g.Store.Load(save.Key)
+	return err == nil
+}
+
+func (g *Game) DeleteSavedMatch() {
+	if err := g.Store.Delete(save.Key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		fmt.Println("Error deleting saved match:", err)
+	}
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/thanhfphan/ebitengj2025/internal/netplay"
+)
+
+// serverLink is a session with a match server. Messages are read on their
+// own goroutine and handed to whichever scene owns the link.
+type serverLink struct {
+	client   *netplay.Client
+	dial     func() (netplay.Conn, error)
+	name     string
+	incoming chan linkMessage
+	closed   chan struct{}
+	once     sync.Once
+}
+
+type linkMessage struct {
+	msg netplay.Message
+	err error
+}
+
+// dialServerLink connects to a server and says hello. It blocks, call it
+// off the game loop.
+func dialServerLink(dial func() (netplay.Conn, error), name string) (*serverLink, error) {
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	client := netplay.NewClient(conn)
+	if err := client.Hello(name); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	l := &serverLink{
+		client:   client,
+		dial:     dial,
+		name:     name,
+		incoming: make(chan linkMessage, 16),
+		closed:   make(chan struct{}),
+	}
+	go l.receive()
+	return l, nil
+}
+
+// redial opens a new session with the same server, for the same player
+func (l *serverLink) redial() (*serverLink, error) {
+	return dialServerLink(l.dial, l.name)
+}
+
+func (l *serverLink) receive() {
+	for {
+		msg, err := l.client.Receive()
+		select {
+		case l.incoming <- linkMessage{msg: msg, err: err}:
+		case <-l.closed:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// poll returns the next message without waiting, ok is false when there is
+// none
+func (l *serverLink) poll() (linkMessage, bool) {
+	select {
+	case in := <-l.incoming:
+		return in, true
+	default:
+		return linkMessage{}, false
+	}
+}
+
+func (l *serverLink) close() {
+	l.once.Do(func() {
+		close(l.closed)
+		l.client.Close()
+	})
+}
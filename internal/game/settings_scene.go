@@ -6,6 +6,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/settings"
 	"github.com/thanhfphan/ebitengj2025/internal/ui"
 )
 
@@ -56,68 +57,128 @@ func (s *SettingsScene) Enter(g *Game) {
 	s.uiManager.AddElement(title)
 	s.elements = append(s.elements, title)
 
+	makeBtn := func(x, y, w, h int, label string, onClick func()) *ui.UIButton {
+		b := ui.NewUIButton(x, y, w, h, label, smallFont)
+		b.BackgroundColor = colButtonBg
+		b.HoverColor = colButtonHover
+		b.PressedColor = colButtonPressed
+		b.TextColor = colButtonText
+		b.OnClick = onClick
+		s.uiManager.AddElement(b)
+		s.elements = append(s.elements, b)
+		return b
+	}
+
+	// Volumes on the left, other options on the right
+	leftX := cx - 250
+	rightX := cx + 250
 	y := startY + 100
 
 	// Music volume label + slider
-	musicLabel := ui.NewUILabel(cx, y, "Music Volume", defaultFont)
+	musicLabel := ui.NewUILabel(leftX, y, "Music Volume", defaultFont)
 	musicLabel.AlignCenter()
 	s.uiManager.AddElement(musicLabel)
 	s.elements = append(s.elements, musicLabel)
 
 	y += spacing
-	musicSlider := ui.NewUISlider(cx, y, 400, 30, g.AssetManager.GetMusicVolume())
+	musicSlider := ui.NewUISlider(leftX, y, 400, 30, g.AssetManager.GetMusicVolume())
 	musicSlider.OnChange = func(value float64) {
 		g.AssetManager.SetMusicVolume(value)
+		g.Settings.MusicVolume = value
 	}
 	s.uiManager.AddElement(musicSlider)
 	s.elements = append(s.elements, musicSlider)
 
 	// Sound Effects volume
 	y += spacing
-	soundLabel := ui.NewUILabel(cx, y, "Sound Effects Volume", defaultFont)
+	soundLabel := ui.NewUILabel(leftX, y, "Sound Effects Volume", defaultFont)
 	soundLabel.AlignCenter()
 	s.uiManager.AddElement(soundLabel)
 	s.elements = append(s.elements, soundLabel)
 
 	y += spacing
-	soundSlider := ui.NewUISlider(cx, y, 400, 30, g.AssetManager.GetMasterVolume())
+	soundSlider := ui.NewUISlider(leftX, y, 400, 30, g.AssetManager.GetMasterVolume())
 	soundSlider.OnChange = func(value float64) {
 		g.AssetManager.SetMasterVolume(value)
+		g.Settings.MasterVolume = value
 	}
 	s.uiManager.AddElement(soundSlider)
 	s.elements = append(s.elements, soundSlider)
 
 	// Test sound button
 	y += spacing + 10
-	testBtn := ui.NewUIButton(cx-100, y, 200, 40, "Test Sound", smallFont)
-	testBtn.BackgroundColor = colButtonBg
-	testBtn.HoverColor = colButtonHover
-	testBtn.PressedColor = colButtonPressed
-	testBtn.TextColor = colButtonText
-	testBtn.OnClick = func() {
+	makeBtn(leftX-100, y, 200, 40, "Test Sound", func() {
 		if err := g.AssetManager.PlaySound(SoundPlay); err != nil {
 			fmt.Println("Error playing sound:", err)
 		}
+	})
+
+	// Options cycle through their values on click
+	optW, optH := 360, 44
+	y = startY + 80
+	makeOption := func(label func() string, onClick func()) {
+		var b *ui.UIButton
+		b = makeBtn(rightX-optW/2, y, optW, optH, label(), func() {
+			onClick()
+			g.ApplySettings()
+			b.Text = label()
+		})
+		y += optH + 16
 	}
-	s.uiManager.AddElement(testBtn)
-	s.elements = append(s.elements, testBtn)
+
+	makeOption(func() string {
+		return "Window: " + windowModeNames[g.Settings.WindowMode]
+	}, func() {
+		g.Settings.WindowMode = settings.Next(settings.WindowModes, g.Settings.WindowMode)
+	})
+	makeOption(func() string {
+		return "Bot Speed: " + botSpeedNames[g.Settings.BotSpeed]
+	}, func() {
+		g.Settings.BotSpeed = settings.Next(settings.BotSpeeds, g.Settings.BotSpeed)
+	})
+	makeOption(func() string {
+		return "Hints: " + onOff(g.Settings.Hints)
+	}, func() {
+		g.Settings.Hints = !g.Settings.Hints
+	})
+	makeOption(func() string {
+		return "High Contrast: " + onOff(g.Settings.Accessibility.HighContrast)
+	}, func() {
+		g.Settings.Accessibility.HighContrast = !g.Settings.Accessibility.HighContrast
+	})
+	makeOption(func() string {
+		return "Large Text: " + onOff(g.Settings.Accessibility.LargeText)
+	}, func() {
+		g.Settings.Accessibility.LargeText = !g.Settings.Accessibility.LargeText
+	})
 
 	// Back button
-	y += spacing + 20
-	backBtn := ui.NewUIButton(cx-100, y, 200, 50, "Back", defaultFont)
-	backBtn.BackgroundColor = colButtonBg
-	backBtn.HoverColor = colButtonHover
-	backBtn.PressedColor = colButtonPressed
-	backBtn.TextColor = colButtonText
-	backBtn.OnClick = func() {
+	makeBtn(cx-100, ScreenH-120, 200, 50, "Back", func() {
 		g.PopScene()
+	})
+}
+
+var (
+	windowModeNames = map[string]string{
+		settings.WindowModeWindowed:   "Windowed",
+		settings.WindowModeFullscreen: "Fullscreen",
+	}
+	botSpeedNames = map[string]string{
+		settings.BotSpeedSlow:   "Slow",
+		settings.BotSpeedNormal: "Normal",
+		settings.BotSpeedFast:   "Fast",
 	}
-	s.uiManager.AddElement(backBtn)
-	s.elements = append(s.elements, backBtn)
+)
 
+func onOff(v bool) string {
+	if v {
+		return "On"
+	}
+	return "Off"
 }
 
 func (s *SettingsScene) Exit(g *Game) {
+	g.SaveSettings()
 }
 
 func (s *SettingsScene) Update(g *Game) {
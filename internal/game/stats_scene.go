@@ -0,0 +1,165 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+	"golang.org/x/image/font"
+)
+
+var _ Scene = (*StatsScene)(nil)
+
+// StatsScene shows the lifetime statistics of the local player
+type StatsScene struct {
+	elements  []ui.Element
+	bgImage   *ebiten.Image
+	overlay   *ebiten.Image
+	uiManager *ui.Manager
+}
+
+func NewStatsScene() *StatsScene {
+	return &StatsScene{
+		elements: []ui.Element{},
+	}
+}
+
+func (s *StatsScene) Enter(g *Game) {
+	s.uiManager = ui.NewManager()
+	g.CurrentUIManager = s.uiManager
+
+	s.bgImage = g.AssetManager.GetImage(ImageMainBG)
+	s.overlay = ebiten.NewImage(ScreenW, ScreenH)
+	s.overlay.Fill(color.RGBA{0, 0, 0, 150})
+
+	defaultFont := g.AssetManager.GetFont("nunito", 24)
+	titleFont := g.AssetManager.GetFont("nunito", 48)
+	textFont := g.TextFont(18)
+
+	var (
+		colButtonBg      = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+		colButtonHover   = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		colButtonPressed = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+		colButtonText    = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+		colTitle         = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF}
+	)
+
+	cx := ScreenW / 2
+	startY := 100
+
+	addLabel := func(x, y int, text string, face font.Face) *ui.UILabel {
+		l := ui.NewUILabel(x, y, text, face)
+		s.uiManager.AddElement(l)
+		s.elements = append(s.elements, l)
+		return l
+	}
+
+	title := addLabel(cx, startY, "STATS", titleFont)
+	title.AlignCenter()
+	title.TextColor = colTitle
+
+	recipeNames := map[string]string{}
+	if cfg, err := card.ReadDeckConfig("default"); err != nil {
+		fmt.Println("Error reading deck config:", err)
+	} else {
+		recipeNames = cfg.RecipeNames()
+	}
+	recipeName := func(id string) string {
+		if name, ok := recipeNames[id]; ok {
+			return name
+		}
+		return id
+	}
+
+	p := g.Profile
+	favourite := "-"
+	if id, n := p.FavouriteRecipe(); id != "" {
+		favourite = fmt.Sprintf("%s (%d)", recipeName(id), n)
+	}
+	average := "-"
+	if p.GamesPlayed > 0 {
+		average = fmt.Sprintf("%.2f", p.AveragePosition())
+	}
+
+	// Summary on the left, dishes per recipe on the right
+	leftX := cx - 420
+	y := startY + 90
+	lines := []string{
+		fmt.Sprintf("Games played: %d", p.GamesPlayed),
+		fmt.Sprintf("Wins: %d (%.0f%%)", p.Wins, p.WinRate()*100),
+		fmt.Sprintf("Average finish: %s", average),
+		fmt.Sprintf("Win streak: %d (best %d)", p.WinStreak, p.BestWinStreak),
+		fmt.Sprintf("Dishes made: %d", p.TotalDishes()),
+		fmt.Sprintf("Favourite recipe: %s", favourite),
+		fmt.Sprintf("Hints used: %d", p.HintsUsed),
+	}
+	for _, line := range lines {
+		addLabel(leftX, y, line, defaultFont)
+		y += 44
+	}
+
+	rightX := cx + 120
+	y = startY + 90
+	addLabel(rightX, y, "Dishes per recipe", defaultFont).TextColor = colTitle
+	y += 40
+
+	ids := make([]string, 0, len(recipeNames))
+	for id := range recipeNames {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		if c := cmp.Compare(p.Dishes[b], p.Dishes[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(recipeName(a), recipeName(b))
+	})
+	for _, id := range ids {
+		addLabel(rightX, y, fmt.Sprintf("%s: %d", recipeName(id), p.Dishes[id]), textFont)
+		y += 30
+	}
+
+	backBtn := ui.NewUIButton(cx-100, ScreenH-120, 200, 50, "Back", defaultFont)
+	backBtn.BackgroundColor = colButtonBg
+	backBtn.HoverColor = colButtonHover
+	backBtn.PressedColor = colButtonPressed
+	backBtn.TextColor = colButtonText
+	backBtn.OnClick = func() {
+		g.PopScene()
+	}
+	s.uiManager.AddElement(backBtn)
+	s.elements = append(s.elements, backBtn)
+}
+
+func (s *StatsScene) Exit(g *Game) {
+}
+
+func (s *StatsScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.PopScene()
+	}
+}
+
+func (s *StatsScene) Draw(screen *ebiten.Image, g *Game) {
+	if s.bgImage != nil {
+		op := &ebiten.DrawImageOptions{}
+
+		sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+		bw, bh := s.bgImage.Bounds().Dx(), s.bgImage.Bounds().Dy()
+
+		sx := float64(sw) / float64(bw)
+		sy := float64(sh) / float64(bh)
+
+		op.GeoM.Scale(sx, sy)
+		screen.DrawImage(s.bgImage, op)
+	}
+	screen.DrawImage(s.overlay, nil)
+}
+
+func (s *StatsScene) GetUIManager() *ui.Manager {
+	return s.uiManager
+}
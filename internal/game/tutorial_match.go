@@ -0,0 +1,160 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/tutorial"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Match = (*TutorialMatch)(nil)
+
+// opponentDelay lets the player see the table before a scripted opponent
+// plays
+const opponentDelay = time.Second
+
+var (
+	errNotThisStep   = errors.New("the tutorial asks for something else")
+	errNoHintsNeeded = errors.New("the tutorial shows the way")
+)
+
+// TutorialMatch plays a tutorial script on Game.Engine. Only the action of
+// the current step is allowed and the script plays for the opponents. It is
+// neither saved nor recorded.
+type TutorialMatch struct {
+	*LocalMatch
+	script  *tutorial.Script
+	players []*entity.Player // Indexed like the players of the script
+	step    int
+	due     time.Time // When the opponent of the current step plays
+	err     error
+}
+
+func NewTutorialMatch(s *tutorial.Script) *TutorialMatch {
+	return &TutorialMatch{
+		LocalMatch: &LocalMatch{bots: len(s.Players) - 1},
+		script:     s,
+	}
+}
+
+func (m *TutorialMatch) Setup(g *Game) ([]*ui.UIBotHand, bool) {
+	m.g = g
+	g.AIManager.Clear()
+	players, err := m.script.Deal(g.Engine)
+	if err != nil {
+		m.err = fmt.Errorf("tutorial %s: %w", m.script.ID, err)
+		return nil, true
+	}
+
+	m.players = players
+	g.Players = players
+	g.Player = players[0]
+	g.Achievements.StartMatch("", g.CardManager.Theme)
+	g.MatchLog.Reset()
+	g.HintsUsed = 0
+	g.matchInProgress = false
+
+	hands := []*ui.UIBotHand{}
+	for range players[1:] {
+		hands = append(hands, g.newOpponentHand())
+	}
+	return hands, true
+}
+
+// Step returns the current step, nil once the tutorial is done
+func (m *TutorialMatch) Step() *tutorial.Step {
+	if m.step >= len(m.script.Steps) {
+		return nil
+	}
+	return &m.script.Steps[m.step]
+}
+
+func (m *TutorialMatch) advance() {
+	m.step++
+	m.due = time.Time{}
+}
+
+// Update plays the opponent of the current step once the delay is over
+func (m *TutorialMatch) Update() {
+	st := m.Step()
+	if st == nil || st.Action != tutorial.ActionOpponent || m.err != nil {
+		return
+	}
+	if m.due.IsZero() {
+		m.due = time.Now().Add(opponentDelay)
+	}
+	if time.Now().Before(m.due) {
+		return
+	}
+
+	p, c, err := st.Expect(m.g.Engine, m.players)
+	if err == nil {
+		if c != nil {
+			err = m.g.PlayCardWithTarget(p.ID, c.ID, entity.Target{})
+		} else {
+			err = m.g.Engine.Pass(p.ID)
+		}
+	}
+	if err != nil {
+		m.err = fmt.Errorf("tutorial %s: step %d: %w", m.script.ID, m.step+1, err)
+		return
+	}
+	m.advance()
+}
+
+// Next moves past a step that only asks to read it
+func (m *TutorialMatch) Next() {
+	if st := m.Step(); st != nil && (st.Action == tutorial.ActionNext || st.Action == tutorial.ActionFinish) {
+		m.advance()
+	}
+}
+
+// CanSelect reports whether the player may select the card, only the card
+// of the current step can be
+func (m *TutorialMatch) CanSelect(cardID string) bool {
+	st := m.Step()
+	if st == nil || (st.Action != tutorial.ActionSelect && st.Action != tutorial.ActionPlay) {
+		return false
+	}
+	c := m.g.Player.GetCard(cardID)
+	return c != nil && c.Name == st.Card
+}
+
+// Select moves past a select step once its card was selected
+func (m *TutorialMatch) Select(cardID string) {
+	if st := m.Step(); st != nil && st.Action == tutorial.ActionSelect && m.CanSelect(cardID) {
+		m.advance()
+	}
+}
+
+func (m *TutorialMatch) Play(cardID string, target entity.Target) error {
+	st := m.Step()
+	if st == nil || st.Action != tutorial.ActionPlay || !m.CanSelect(cardID) {
+		return errNotThisStep
+	}
+	if err := m.g.PlayCardWithTarget(m.g.Player.ID, cardID, target); err != nil {
+		return err
+	}
+	m.advance()
+	return nil
+}
+
+func (m *TutorialMatch) Pass() error {
+	if st := m.Step(); st == nil || st.Action != tutorial.ActionPass {
+		return errNotThisStep
+	}
+	if err := m.g.Engine.Pass(m.g.Player.ID); err != nil {
+		return err
+	}
+	m.advance()
+	return nil
+}
+
+func (m *TutorialMatch) Hint() (*Hint, error) { return nil, errNoHintsNeeded }
+func (m *TutorialMatch) IsOver() bool         { return m.Step() == nil }
+func (m *TutorialMatch) Err() error           { return m.err }
+func (m *TutorialMatch) End()                 {}
+func (m *TutorialMatch) Leave()               {}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/thanhfphan/ebitengj2025/internal/tutorial"
+	"github.com/thanhfphan/ebitengj2025/internal/ui"
+)
+
+var _ Scene = (*TutorialScene)(nil)
+
+// TutorialScene plays a tutorial script, the prompt of each step is shown
+// beside the table and only its action is available
+type TutorialScene struct {
+	*PlayingScene
+	tutorial    *TutorialMatch
+	promptLabel *ui.UILabel
+	nextBtn     *ui.UIButton
+}
+
+func NewTutorialScene(s *tutorial.Script) *TutorialScene {
+	m := NewTutorialMatch(s)
+	return &TutorialScene{
+		PlayingScene: NewMatchScene(m),
+		tutorial:     m,
+	}
+}
+
+func (s *TutorialScene) Enter(g *Game) {
+	s.PlayingScene.Enter(g)
+	if s.promptLabel != nil { // Back from the settings
+		return
+	}
+
+	s.playerHand.SetSelectable(s.tutorial.CanSelect)
+
+	s.promptLabel = ui.NewUILabel(20, 110, "", g.TextFont(18))
+	s.promptLabel.Wrap = 300
+	s.uiManager.AddElement(s.promptLabel)
+	s.elements = append(s.elements, s.promptLabel)
+
+	nextBtn := ui.NewUIButton(20, 440, 140, 44, "Next", g.AssetManager.GetFont("nunito", 24))
+	nextBtn.BackgroundColor = color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}
+	nextBtn.HoverColor = color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+	nextBtn.PressedColor = color.RGBA{0xD9, 0xC3, 0x90, 0xFF}
+	nextBtn.TextColor = color.RGBA{0x36, 0x55, 0x34, 0xFF}
+	nextBtn.OnClick = func() {
+		s.tutorial.Next()
+	}
+	nextBtn.SetVisible(false)
+	s.uiManager.AddElement(nextBtn)
+	s.elements = append(s.elements, nextBtn)
+	s.nextBtn = nextBtn
+}
+
+func (s *TutorialScene) Update(g *Game) {
+	s.PlayingScene.Update(g)
+
+	if s.gameOverMenu.visible && s.tutorial.Err() == nil {
+		s.gameOverMenu.titleLabel.Text = "TUTORIAL COMPLETE"
+		s.gameOverMenu.resultLabel.Text = "Start a real match with New Game"
+	}
+
+	st := s.tutorial.Step()
+	if st == nil || s.isPaused || s.gameOverMenu.visible {
+		s.promptLabel.SetVisible(false)
+		s.nextBtn.SetVisible(false)
+		return
+	}
+
+	if st.Action == tutorial.ActionSelect {
+		if id := s.playerHand.GetSelectedCardID(); id != "" {
+			s.tutorial.Select(id)
+			st = s.tutorial.Step()
+		}
+	}
+
+	reading := st.Action == tutorial.ActionNext || st.Action == tutorial.ActionFinish
+	if reading && (inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace)) {
+		s.tutorial.Next()
+		return
+	}
+
+	s.promptLabel.Text = st.Text
+	s.promptLabel.SetVisible(true)
+	s.nextBtn.Text = "Next"
+	if st.Action == tutorial.ActionFinish {
+		s.nextBtn.Text = "Done"
+	}
+	s.nextBtn.SetVisible(reading)
+
+	// Only the action of the step is offered, its card is pointed out
+	s.playBtn.SetVisible(s.playBtn.IsVisible() && st.Action == tutorial.ActionPlay)
+	s.passBtn.SetVisible(s.passBtn.IsVisible() && st.Action == tutorial.ActionPass)
+	s.hintBtn.SetVisible(false)
+	hinted := ""
+	if st.Action == tutorial.ActionSelect || st.Action == tutorial.ActionPlay {
+		if c := tutorial.CardNamed(g.Player, st.Card); c != nil {
+			hinted = c.ID
+		}
+	}
+	s.playerHand.SetHinted(hinted)
+}
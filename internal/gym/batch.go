@@ -0,0 +1,95 @@
+package gym
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Batch steps many environments in parallel, one goroutine each. A finished
+// environment is reset with the next seed on its following step, so a batch
+// can be stepped forever.
+type Batch struct {
+	Envs []*Env
+
+	nextSeed int64
+	pending  []bool // Env finished, reset it instead of stepping it
+}
+
+// NewBatch creates n environments with the same config
+func NewBatch(cfg Config, n int) (*Batch, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", n)
+	}
+
+	b := &Batch{Envs: make([]*Env, n), pending: make([]bool, n)}
+	for i := range b.Envs {
+		env, err := NewEnv(cfg)
+		if err != nil {
+			return nil, err
+		}
+		b.Envs[i] = env
+	}
+	return b, nil
+}
+
+// Reset deals a match in every environment, with seeds seed, seed+1, ...
+func (b *Batch) Reset(seed int64) ([]Step, error) {
+	b.nextSeed = seed
+	steps := make([]Step, len(b.Envs))
+	err := b.each(func(i int, env *Env) (Step, error) {
+		return env.Reset(seed + int64(i))
+	}, steps)
+	b.nextSeed += int64(len(b.Envs))
+	clear(b.pending)
+	return steps, err
+}
+
+// Step plays one action in each environment. An environment that was done
+// after the previous step is reset instead, its action is ignored and its
+// step has no reward.
+func (b *Batch) Step(actions []int) ([]Step, error) {
+	if len(actions) != len(b.Envs) {
+		return nil, fmt.Errorf("got %d actions for %d environments", len(actions), len(b.Envs))
+	}
+
+	seeds := make([]int64, len(b.Envs))
+	for i, reset := range b.pending {
+		if reset {
+			seeds[i] = b.nextSeed
+			b.nextSeed++
+		}
+	}
+
+	steps := make([]Step, len(b.Envs))
+	err := b.each(func(i int, env *Env) (Step, error) {
+		if b.pending[i] {
+			return env.Reset(seeds[i])
+		}
+		return env.Step(actions[i])
+	}, steps)
+	for i, s := range steps {
+		b.pending[i] = s.Done
+	}
+	return steps, err
+}
+
+// each runs f for every environment in parallel and joins the errors
+func (b *Batch) each(f func(i int, env *Env) (Step, error), steps []Step) error {
+	errs := make([]error, len(b.Envs))
+	var wg sync.WaitGroup
+	for i, env := range b.Envs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			step, err := f(i, env)
+			if err != nil {
+				errs[i] = fmt.Errorf("env %d: %w", i, err)
+				return
+			}
+			steps[i] = step
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
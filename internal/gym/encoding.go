@@ -0,0 +1,223 @@
+package gym
+
+import (
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// MaxPlayers is the largest table the encoding has room for
+const MaxPlayers = 6
+
+// seatFeatures are encoded for every seat, starting from the agent
+const seatFeatures = 6
+
+// Vocab numbers the kinds of cards of a deck. Card IDs change with every
+// deal, observations and actions refer to cards by kind instead.
+type Vocab struct {
+	Keys []string // Recipe, ingredient and special IDs in deck order
+
+	index         map[string]int
+	specialByName map[string]string // Special cards do not carry their ID
+}
+
+// NewVocab lists the card kinds of a deck, every ruleset of the deck shares
+// it so a policy trained on one can play the others
+func NewVocab(theme string) (*Vocab, error) {
+	cfg, err := card.ReadDeckConfig(theme)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Vocab{index: make(map[string]int), specialByName: make(map[string]string)}
+	add := func(key string) {
+		if _, ok := v.index[key]; !ok {
+			v.index[key] = len(v.Keys)
+			v.Keys = append(v.Keys, key)
+		}
+	}
+	for _, r := range cfg.Recipes.Recipes {
+		add(r.ID)
+	}
+	for _, ing := range cfg.Ingredients.Ingredients {
+		add(ing.ID)
+	}
+	for _, sp := range cfg.Specials.Specials {
+		add(sp.ID)
+		v.specialByName[sp.Name] = sp.ID
+	}
+	return v, nil
+}
+
+// Kind returns the index of the card's kind, -1 for a card of another deck
+func (v *Vocab) Kind(c *entity.Card) int {
+	key := c.IngredientID
+	switch c.Type {
+	case entity.CardTypeRecipe:
+		key = c.RecipeID
+	case entity.CardTypeWildcard, entity.CardTypeAction:
+		key = v.specialByName[c.Name]
+	}
+	if i, ok := v.index[key]; ok {
+		return i
+	}
+	return -1
+}
+
+// ObsSize is the length of an observation: the kinds in hand, on the table
+// and on the table from the agent, then a few numbers for every seat
+func (v *Vocab) ObsSize() int {
+	return 3*len(v.Keys) + MaxPlayers*seatFeatures
+}
+
+// targetSlots is how many targets a card kind can be played on: none, an
+// opponent by their seat after the agent, or a table card by its kind
+func (v *Vocab) targetSlots() int {
+	return 1 + (MaxPlayers - 1) + len(v.Keys)
+}
+
+// ActionSize is the number of actions. Action 0 passes, the others play a
+// card kind on a target slot.
+func (v *Vocab) ActionSize() int {
+	return 1 + len(v.Keys)*v.targetSlots()
+}
+
+// seats returns the players starting from the given one, in turn order
+func seats(e *rules.Engine, playerID string) []*entity.Player {
+	start := 0
+	for i, p := range e.Players {
+		if p.ID == playerID {
+			start = i
+		}
+	}
+	out := make([]*entity.Player, 0, len(e.Players))
+	for i := range e.Players {
+		out = append(out, e.Players[(start+i)%len(e.Players)])
+	}
+	return out
+}
+
+// Observe encodes what the player knows of the match
+func (v *Vocab) Observe(e *rules.Engine, playerID string) []float32 {
+	n := len(v.Keys)
+	obs := make([]float32, v.ObsSize())
+
+	if p := e.GetPlayer(playerID); p != nil {
+		for _, c := range p.Hand {
+			if k := v.Kind(c); k >= 0 {
+				obs[k]++
+			}
+		}
+	}
+	for _, entry := range e.Cards.TableStack.GetEntriesInOrder() {
+		k := v.Kind(entry.Card)
+		if k < 0 {
+			continue
+		}
+		obs[n+k]++
+		if entry.PlayerID == playerID {
+			obs[2*n+k]++
+		}
+	}
+
+	current := e.Turns.Current()
+	for i, p := range seats(e, playerID) {
+		if i >= MaxPlayers {
+			break
+		}
+		turn := e.Turns.GetPlayerByID(p.ID)
+		f := obs[3*n+i*seatFeatures:]
+		f[0] = 1
+		f[1] = float32(len(p.Hand)) / 10
+		f[2] = boolFeature(turn != nil && turn.Passed)
+		f[3] = boolFeature(turn != nil && turn.Finished)
+		f[4] = float32(e.Score(p.ID)) / 10
+		f[5] = boolFeature(current != nil && current.ID == p.ID)
+	}
+	return obs
+}
+
+func boolFeature(b bool) float32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Mask returns which actions are legal for the player, passing always is
+func (v *Vocab) Mask(e *rules.Engine, playerID string) []bool {
+	mask := make([]bool, v.ActionSize())
+	mask[0] = true
+	p := e.GetPlayer(playerID)
+	if p == nil {
+		return mask
+	}
+
+	order := seats(e, playerID)
+	for _, id := range p.OrderHand {
+		k := v.Kind(p.GetCard(id))
+		if k < 0 {
+			continue
+		}
+		for _, t := range e.LegalTargets(playerID, id) {
+			if slot := v.slot(e, order, t); slot >= 0 {
+				mask[1+k*v.targetSlots()+slot] = true
+			}
+		}
+	}
+	return mask
+}
+
+// slot returns the target slot of a target, -1 when it has none
+func (v *Vocab) slot(e *rules.Engine, order []*entity.Player, t entity.Target) int {
+	switch {
+	case t.PlayerID != "":
+		for i, p := range order {
+			if p.ID == t.PlayerID && i > 0 && i < MaxPlayers {
+				return i
+			}
+		}
+		return -1
+	case t.CardID != "":
+		tc := e.Cards.TableStack.GetCard(t.CardID)
+		if tc == nil {
+			return -1
+		}
+		if k := v.Kind(tc.Card); k >= 0 {
+			return MaxPlayers + k
+		}
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Decode turns an action into a card of the player and its target, pass is
+// true for action 0. ok is false for an action that is not legal.
+func (v *Vocab) Decode(e *rules.Engine, playerID string, action int) (cardID string, target entity.Target, pass, ok bool) {
+	if action == 0 {
+		return "", entity.Target{}, true, true
+	}
+	if action < 0 || action >= v.ActionSize() {
+		return "", entity.Target{}, false, false
+	}
+	p := e.GetPlayer(playerID)
+	if p == nil {
+		return "", entity.Target{}, false, false
+	}
+
+	kind := (action - 1) / v.targetSlots()
+	slot := (action - 1) % v.targetSlots()
+	order := seats(e, playerID)
+	for _, id := range p.OrderHand {
+		if v.Kind(p.GetCard(id)) != kind {
+			continue
+		}
+		for _, t := range e.LegalTargets(playerID, id) {
+			if v.slot(e, order, t) == slot {
+				return id, t, false, true
+			}
+		}
+	}
+	return "", entity.Target{}, false, false
+}
@@ -0,0 +1,99 @@
+package gym
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+)
+
+func TestMaskDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		deck    string
+		ruleset string
+		players int
+	}{
+		{name: "duel", deck: "default", ruleset: card.RulesetStandard, players: 2},
+		{name: "four seats", deck: "default", ruleset: card.RulesetStandard, players: 4},
+		{name: "full table", deck: "default", ruleset: card.RulesetNoActions, players: MaxPlayers},
+		{name: "street food", deck: "street", ruleset: card.RulesetStandard, players: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := NewEnv(Config{Deck: tt.deck, Ruleset: tt.ruleset, Players: tt.players, RotateSeat: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for seed := range int64(3) {
+				step, err := env.Reset(seed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for turn := 0; !step.Done; turn++ {
+					if turn > 500 {
+						t.Fatalf("seed %d: the episode did not end", seed)
+					}
+					checkMask(t, env, step.Mask)
+
+					// Play the last legal action, so cards rather than passes
+					action := 0
+					for a, legal := range step.Mask {
+						if legal {
+							action = a
+						}
+					}
+					if step, err = env.Step(action); err != nil {
+						t.Fatalf("seed %d: step %d: %v", seed, action, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkMask checks the mask allows exactly the actions Decode accepts, and
+// that every card the agent can play maps to an allowed action that decodes
+// to a card of the same kind on the same target
+func checkMask(t *testing.T, env *Env, mask []bool) {
+	t.Helper()
+	e, v := env.engine, env.Vocab
+	if len(mask) != v.ActionSize() || !mask[0] {
+		t.Fatalf("mask of %d actions, pass allowed %v", len(mask), len(mask) > 0 && mask[0])
+	}
+
+	for a, legal := range mask {
+		cardID, target, pass, ok := v.Decode(e, env.agent, a)
+		if ok != legal {
+			t.Fatalf("action %d: mask says %v, Decode says %v", a, legal, ok)
+		}
+		if !ok || pass {
+			continue
+		}
+		kind := (a - 1) / v.targetSlots()
+		if got := v.Kind(e.GetPlayer(env.agent).GetCard(cardID)); got != kind {
+			t.Fatalf("action %d decodes to a card of kind %d, want %d", a, got, kind)
+		}
+		if !slices.Contains(e.LegalTargets(env.agent, cardID), target) {
+			t.Fatalf("action %d decodes to an illegal target %+v", a, target)
+		}
+	}
+
+	order := seats(e, env.agent)
+	p := e.GetPlayer(env.agent)
+	for _, id := range p.OrderHand {
+		kind := v.Kind(p.GetCard(id))
+		for _, target := range e.LegalTargets(env.agent, id) {
+			a := 1 + kind*v.targetSlots() + v.slot(e, order, target)
+			if !mask[a] {
+				t.Fatalf("card %s on %+v is legal but action %d is masked", id, target, a)
+			}
+			_, got, _, _ := v.Decode(e, env.agent, a)
+			if v.slot(e, order, got) != v.slot(e, order, target) {
+				t.Fatalf("action %d decodes to target %+v, want the slot of %+v", a, got, target)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package gym exposes the rules engine as a reinforcement learning
+// environment: one agent seat against bots, fixed-size observations and
+// action masks
+package gym
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// Config of an environment
+type Config struct {
+	Deck     string
+	Ruleset  string
+	Players  int        // Agent included, 2 to MaxPlayers
+	Opponent ai.Factory // Bot of the other seats, the easy bot by default
+	// RotateSeat seats the agent by the seed, otherwise it always plays first
+	RotateSeat bool
+	Rewards    Rewards
+}
+
+// Rewards shape what the agent is paid for. The finish reward goes from
+// Finish for the first place to -Finish for the last one.
+type Rewards struct {
+	Dish   float64 // For each dish the agent completes
+	Score  float64 // For each point of score from effects
+	Finish float64
+}
+
+// DefaultRewards mostly pay for the finish position
+var DefaultRewards = Rewards{Dish: 0.1, Score: 0.05, Finish: 1}
+
+// Step is what an action led to
+type Step struct {
+	Obs    []float32
+	Mask   []bool
+	Reward float64
+	Done   bool // The agent finished, or the match is over
+}
+
+// Env is one match, the agent plays one seat and bots the others. It is not
+// safe for concurrent use, Batch runs many of them in parallel.
+type Env struct {
+	cfg   Config
+	Vocab *Vocab
+
+	engine *rules.Engine
+	game   *ai.EngineGame
+	agent  string
+	bots   map[string]ai.Bot
+	score  int // Agent's score and dishes after the last step
+	dishes int
+	done   bool
+}
+
+func NewEnv(cfg Config) (*Env, error) {
+	if cfg.Deck == "" {
+		cfg.Deck = "default"
+	}
+	if cfg.Ruleset == "" {
+		cfg.Ruleset = card.RulesetStandard
+	}
+	if cfg.Players == 0 {
+		cfg.Players = 4
+	}
+	if cfg.Opponent == nil {
+		cfg.Opponent = func() ai.Bot { return ai.NewEasyBot() }
+	}
+	if cfg.Rewards == (Rewards{}) {
+		cfg.Rewards = DefaultRewards
+	}
+	if cfg.Players < 2 || cfg.Players > MaxPlayers {
+		return nil, fmt.Errorf("players must be between 2 and %d, got %d", MaxPlayers, cfg.Players)
+	}
+
+	vocab, err := NewVocab(cfg.Deck)
+	if err != nil {
+		return nil, err
+	}
+	return &Env{cfg: cfg, Vocab: vocab, done: true}, nil
+}
+
+// Reset deals a new match, the same seed deals the same cards
+func (env *Env) Reset(seed int64) (Step, error) {
+	cards := card.NewManager()
+	cards.SetRandState(rng.State{Seed: seed})
+	if err := cards.LoadDeckRuleset(env.cfg.Deck, env.cfg.Ruleset); err != nil {
+		return Step{}, fmt.Errorf("load deck: %w", err)
+	}
+	env.engine = rules.NewEngine(cards, rules.NewTurnManager())
+	env.game = &ai.EngineGame{Engine: env.engine}
+
+	seat := 0
+	if env.cfg.RotateSeat {
+		seat = int(uint64(seed) % uint64(env.cfg.Players))
+	}
+	players := make([]*entity.Player, env.cfg.Players)
+	env.bots = make(map[string]ai.Bot)
+	for i := range players {
+		if i == seat {
+			players[i] = entity.NewPlayer("Agent", entity.TypePlayer)
+			env.agent = players[i].ID
+			continue
+		}
+		players[i] = entity.NewPlayer(fmt.Sprintf("Bot %d", len(env.bots)+1), entity.TypeBot)
+		env.bots[players[i].ID] = env.cfg.Opponent()
+	}
+	env.engine.Start(players)
+	env.score, env.dishes, env.done = 0, 0, false
+
+	reward, err := env.advance()
+	if err != nil {
+		return Step{}, err
+	}
+	return env.step(reward), nil
+}
+
+// Step plays an action of the agent, then lets the bots play until the
+// agent's next turn. An illegal action is an error and changes nothing.
+func (env *Env) Step(action int) (Step, error) {
+	if env.done {
+		return Step{}, errors.New("the episode is over, call Reset")
+	}
+
+	cardID, target, pass, ok := env.Vocab.Decode(env.engine, env.agent, action)
+	if !ok {
+		return Step{}, fmt.Errorf("illegal action %d", action)
+	}
+	var err error
+	if pass {
+		err = env.engine.Pass(env.agent)
+	} else {
+		err = env.engine.PlayCard(env.agent, cardID, target)
+	}
+	if err != nil {
+		return Step{}, err
+	}
+
+	reward := env.shaped()
+	more, err := env.advance()
+	if err != nil {
+		return Step{}, err
+	}
+	return env.step(reward + more), nil
+}
+
+// Engine returns the match, for inspection
+func (env *Env) Engine() *rules.Engine {
+	return env.engine
+}
+
+// advance lets the bots play until it is the agent's turn or the agent is
+// done, and returns the reward the agent got meanwhile
+func (env *Env) advance() (float64, error) {
+	reward := 0.0
+	for turns := 0; ; turns++ {
+		if turns > 10000 {
+			return 0, errors.New("the bots did not give the turn back")
+		}
+		if pos := env.position(); pos > 0 || env.engine.IsOver() {
+			env.done = true
+			return reward + env.shaped() + env.finishReward(pos), nil
+		}
+
+		current := env.engine.Turns.Current()
+		if current == nil {
+			return 0, errors.New("nobody is left to play")
+		}
+		if current.ID == env.agent {
+			return reward + env.shaped(), nil
+		}
+
+		// Same as the server: a bot that cannot or does not play passes
+		err := errors.New("no cards in hand")
+		if len(env.engine.GetPlayer(current.ID).Hand) > 0 {
+			err = env.bots[current.ID].PlayTurn(env.game, current.ID)
+		}
+		if err != nil {
+			_ = env.engine.Pass(current.ID)
+		}
+		reward += env.shaped()
+	}
+}
+
+// shaped returns the reward for the dishes and score the agent got since the
+// last call
+func (env *Env) shaped() float64 {
+	score := env.engine.Score(env.agent)
+	dishes := len(env.engine.Dishes[env.agent])
+	r := env.cfg.Rewards.Score*float64(score-env.score) + env.cfg.Rewards.Dish*float64(dishes-env.dishes)
+	env.score, env.dishes = score, dishes
+	return r
+}
+
+func (env *Env) position() int {
+	for i, id := range env.engine.Turns.FinishedOrder() {
+		if id == env.agent {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (env *Env) finishReward(pos int) float64 {
+	n := len(env.engine.Players)
+	if pos == 0 {
+		pos = n
+	}
+	return env.cfg.Rewards.Finish * (1 - 2*float64(pos-1)/float64(n-1))
+}
+
+func (env *Env) step(reward float64) Step {
+	return Step{
+		Obs:    env.Vocab.Observe(env.engine, env.agent),
+		Mask:   env.Vocab.Mask(env.engine, env.agent),
+		Reward: reward,
+		Done:   env.done,
+	}
+}
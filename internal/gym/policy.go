@@ -0,0 +1,136 @@
+package gym
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+)
+
+var _ ai.Bot = (*PolicyBot)(nil)
+
+// Policy picks an action from an observation, among the actions the mask
+// allows. A policy is shared by all the bots playing it, so it must be safe
+// for concurrent use.
+type Policy interface {
+	Act(obs []float32, mask []bool) int
+}
+
+// PolicyBot plays a seat with a trained policy. It needs the rules engine to
+// observe the match, on games that do not hand it out an EasyBot plays.
+type PolicyBot struct {
+	Policy Policy
+	Vocab  *Vocab
+
+	fallback *ai.EasyBot
+}
+
+func NewPolicyBot(p Policy, vocab *Vocab) *PolicyBot {
+	return &PolicyBot{Policy: p, Vocab: vocab, fallback: ai.NewEasyBot()}
+}
+
+func (b *PolicyBot) PlayTurn(g ai.GameLike, botID string) error {
+	src, ok := g.(ai.EngineSource)
+	if !ok {
+		return b.fallback.PlayTurn(g, botID)
+	}
+	e := src.RulesEngine()
+
+	action := b.Policy.Act(b.Vocab.Observe(e, botID), b.Vocab.Mask(e, botID))
+	cardID, target, pass, ok := b.Vocab.Decode(e, botID, action)
+	if !ok {
+		fmt.Println("Policy picked illegal action", action, "playing for it this turn")
+		return b.fallback.PlayTurn(g, botID)
+	}
+	if pass {
+		g.Pass(botID)
+		return nil
+	}
+	return g.PlayCardWithTarget(botID, cardID, target)
+}
+
+// LinearPolicy scores each action with a linear function of the
+// observation and picks the best legal one. It is the format trained
+// policies are saved in:
+//
+//	{"deck":"default","weights":[[...], ...],"bias":[...]}
+//
+// with one row of weights and one bias per action.
+type LinearPolicy struct {
+	Deck    string      `json:"deck"`
+	Weights [][]float32 `json:"weights"`
+	Bias    []float32   `json:"bias"`
+}
+
+// LoadLinearPolicy reads a policy file and checks it fits the vocabulary of
+// its deck, which it also returns
+func LoadLinearPolicy(path string) (*LinearPolicy, *Vocab, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var p LinearPolicy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if p.Deck == "" {
+		p.Deck = "default"
+	}
+
+	vocab, err := NewVocab(p.Deck)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(p.Weights) != vocab.ActionSize() || len(p.Bias) != vocab.ActionSize() {
+		return nil, nil, fmt.Errorf("%s has %d weight rows and %d biases, the %s deck has %d actions",
+			path, len(p.Weights), len(p.Bias), p.Deck, vocab.ActionSize())
+	}
+	for i, row := range p.Weights {
+		if len(row) != vocab.ObsSize() {
+			return nil, nil, fmt.Errorf("%s weight row %d has %d values, observations have %d", path, i, len(row), vocab.ObsSize())
+		}
+	}
+	return &p, vocab, nil
+}
+
+// Act implements Policy.
+func (p *LinearPolicy) Act(obs []float32, mask []bool) int {
+	best, bestScore := 0, float32(math.Inf(-1)) // Passing is always legal
+	for a, legal := range mask {
+		if !legal {
+			continue
+		}
+		score := p.Bias[a]
+		for i, w := range p.Weights[a] {
+			score += w * obs[i]
+		}
+		if score > bestScore {
+			best, bestScore = a, score
+		}
+	}
+	return best
+}
+
+// RegisterPolicy loads a linear policy and registers it as a bot, so the
+// simulator and the tournament runner can seat it by name
+func RegisterPolicy(name, path string) error {
+	p, vocab, err := LoadLinearPolicy(path)
+	if err != nil {
+		return err
+	}
+	return ai.Register(name, func() ai.Bot { return NewPolicyBot(p, vocab) })
+}
+
+// RegisterPolicySpec registers a policy given as name=path, the form the
+// command line tools take
+func RegisterPolicySpec(spec string) error {
+	name, path, ok := strings.Cut(spec, "=")
+	name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+	if !ok || name == "" || path == "" {
+		return fmt.Errorf("invalid policy %q, expected name=path", spec)
+	}
+	return RegisterPolicy(name, path)
+}
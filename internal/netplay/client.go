@@ -0,0 +1,115 @@
+package netplay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// Client speaks the protocol on the player's side of a connection
+type Client struct {
+	conn    Conn
+	Name    string // As the server knows the player, once it answered the hello
+	Version int    // Protocol version agreed with the server
+	Seat    string // Player ID, known once the server sent its welcome
+	Token   string // Session token of the seat, to reclaim it after a lost connection
+}
+
+func NewClient(conn Conn) *Client {
+	return &Client{conn: conn}
+}
+
+// Hello opens the session and waits for the server to agree on a protocol
+// version. It must be called before anything else.
+func (c *Client) Hello(name string) error {
+	err := c.conn.Send(Message{
+		Type:       MsgHello,
+		Version:    ProtocolVersion,
+		MinVersion: MinProtocolVersion,
+		Name:       name,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg, err := c.conn.Receive()
+	if err != nil {
+		return err
+	}
+	switch msg.Type {
+	case MsgHello:
+		c.Name = msg.Name
+		c.Version = msg.Version
+		return nil
+	case MsgError:
+		return errors.New(msg.Error)
+	default:
+		return fmt.Errorf("unexpected %q message before the hello", msg.Type)
+	}
+}
+
+func (c *Client) ListRooms() error {
+	return c.conn.Send(Message{Type: MsgListRooms})
+}
+
+func (c *Client) CreateRoom(opts RoomOptions) error {
+	return c.conn.Send(Message{Type: MsgCreateRoom, Options: &opts})
+}
+
+func (c *Client) JoinRoom(id string, spectator bool) error {
+	return c.conn.Send(Message{Type: MsgJoinRoom, RoomID: id, Spectator: spectator})
+}
+
+// Reclaim takes back the seat of a session token, the server answers with a
+// welcome and the state of the match
+func (c *Client) Reclaim(token string) error {
+	return c.conn.Send(Message{Type: MsgReclaim, Token: token})
+}
+
+func (c *Client) LeaveRoom() error {
+	return c.conn.Send(Message{Type: MsgLeaveRoom})
+}
+
+func (c *Client) SetReady(ready bool) error {
+	return c.conn.Send(Message{Type: MsgReady, Ready: ready})
+}
+
+// Start asks the server to deal, only the host of the room may
+func (c *Client) Start() error {
+	return c.conn.Send(Message{Type: MsgStart})
+}
+
+func (c *Client) Play(cardID string, target entity.Target) error {
+	return c.conn.Send(Message{Type: MsgPlay, CardID: cardID, Target: &target})
+}
+
+func (c *Client) Pass() error {
+	return c.conn.Send(Message{Type: MsgPass})
+}
+
+// Receive waits for the next message of the server. Pings are answered on
+// the way and never returned.
+func (c *Client) Receive() (Message, error) {
+	for {
+		msg, err := c.conn.Receive()
+		if err != nil {
+			return msg, err
+		}
+		switch msg.Type {
+		case MsgPing:
+			if err := c.conn.Send(Message{Type: MsgPong}); err != nil {
+				return msg, err
+			}
+			continue
+		case MsgWelcome:
+			c.Seat = msg.Seat
+			c.Token = msg.Token
+		}
+		return msg, nil
+	}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,63 @@
+package netplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxMessageSize bounds what a peer may send in one message
+const maxMessageSize = 1 << 20
+
+// Conn carries protocol messages between a client and the server. Send may
+// be called from several goroutines, Receive from one.
+type Conn interface {
+	Send(msg Message) error
+	Receive() (Message, error)
+	Close() error
+}
+
+var _ Conn = (*streamConn)(nil)
+
+// streamConn sends one JSON message per line over a byte stream such as a
+// TCP connection or a net.Pipe
+type streamConn struct {
+	rwc     io.ReadWriteCloser
+	scanner *bufio.Scanner
+	mu      sync.Mutex
+}
+
+func NewStreamConn(rwc io.ReadWriteCloser) Conn {
+	scanner := bufio.NewScanner(rwc)
+	scanner.Buffer(make([]byte, 0, 4096), maxMessageSize)
+	return &streamConn{rwc: rwc, scanner: scanner}
+}
+
+func (c *streamConn) Send(msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.rwc.Write(append(data, '\n'))
+	return err
+}
+
+func (c *streamConn) Receive() (Message, error) {
+	var msg Message
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return msg, err
+		}
+		return msg, io.EOF
+	}
+	err := json.Unmarshal(c.scanner.Bytes(), &msg)
+	return msg, err
+}
+
+func (c *streamConn) Close() error {
+	return c.rwc.Close()
+}
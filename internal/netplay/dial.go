@@ -0,0 +1,45 @@
+//go:build !js
+
+package netplay
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// DialWebSocket connects to a match server, rawURL looks like
+// ws://host:port/ws
+func DialWebSocket(rawURL string) (Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" {
+		return nil, fmt.Errorf("websocket: unsupported scheme %q", u.Scheme)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	path := u.RequestURI()
+	c, err := clientHandshake(conn, u.Host, path)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// DialTCP connects to the plain TCP port of a match server
+func DialTCP(addr string) (Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewStreamConn(conn), nil
+}
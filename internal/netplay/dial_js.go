@@ -0,0 +1,135 @@
+//go:build js
+
+package netplay
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+var _ Conn = (*browserConn)(nil)
+
+// browserConn is a websocket opened by the browser, the only kind of
+// connection a page may make
+type browserConn struct {
+	ws       js.Value
+	incoming chan []byte
+	closed   chan struct{}
+	once     sync.Once
+	funcs    []js.Func
+}
+
+// DialWebSocket connects to a match server, rawURL looks like
+// ws://host:port/ws
+func DialWebSocket(rawURL string) (Conn, error) {
+	ctor := js.Global().Get("WebSocket")
+	if !ctor.Truthy() {
+		return nil, errors.New("websocket: not supported by this browser")
+	}
+
+	c := &browserConn{
+		ws:       ctor.New(rawURL),
+		incoming: make(chan []byte, 64),
+		closed:   make(chan struct{}),
+	}
+	opened := make(chan bool, 1)
+
+	c.on("open", func(js.Value) {
+		opened <- true
+	})
+	c.on("error", func(js.Value) {
+		select {
+		case opened <- false:
+		default:
+		}
+	})
+	c.on("message", func(ev js.Value) {
+		select {
+		case c.incoming <- []byte(ev.Get("data").String()):
+		case <-c.closed:
+		}
+	})
+	c.on("close", func(js.Value) {
+		c.shutdown()
+	})
+
+	select {
+	case ok := <-opened:
+		if !ok {
+			c.Close()
+			return nil, errors.New("websocket: could not connect to " + rawURL)
+		}
+	case <-time.After(dialTimeout):
+		c.Close()
+		return nil, errors.New("websocket: timed out connecting to " + rawURL)
+	}
+	return c, nil
+}
+
+// DialTCP is not available in the browser
+func DialTCP(addr string) (Conn, error) {
+	return nil, errors.New("tcp connections are not supported in the browser")
+}
+
+func (c *browserConn) on(event string, f func(ev js.Value)) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		ev := js.Undefined()
+		if len(args) > 0 {
+			ev = args[0]
+		}
+		// Callbacks must not block the browser's event loop
+		go f(ev)
+		return nil
+	})
+	c.funcs = append(c.funcs, fn)
+	c.ws.Call("addEventListener", event, fn)
+}
+
+func (c *browserConn) Send(msg Message) error {
+	select {
+	case <-c.closed:
+		return io.ErrClosedPipe
+	default:
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.ws.Call("send", string(data))
+	return nil
+}
+
+func (c *browserConn) Receive() (Message, error) {
+	var msg Message
+	select {
+	case data := <-c.incoming:
+		err := json.Unmarshal(data, &msg)
+		return msg, err
+	case <-c.closed:
+		// Deliver what arrived before the close first
+		select {
+		case data := <-c.incoming:
+			err := json.Unmarshal(data, &msg)
+			return msg, err
+		default:
+			return msg, io.EOF
+		}
+	}
+}
+
+func (c *browserConn) shutdown() {
+	c.once.Do(func() { close(c.closed) })
+}
+
+func (c *browserConn) Close() error {
+	c.shutdown()
+	c.ws.Call("close")
+	return nil
+}
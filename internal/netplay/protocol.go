@@ -0,0 +1,139 @@
+package netplay
+
+import "github.com/thanhfphan/ebitengj2025/internal/entity"
+
+// ProtocolVersion is the newest version of the protocol this build speaks,
+// MinProtocolVersion the oldest one it still understands. Both sides send
+// their range in the hello exchange and use the newest version they share,
+// so clients and servers can be upgraded one at a time.
+//
+// Version 2 adds the ping and pong heartbeat.
+const (
+	ProtocolVersion    = 2
+	MinProtocolVersion = 1
+)
+
+// heartbeatVersion is the first version whose clients answer pings
+const heartbeatVersion = 2
+
+// Message types sent by clients
+const (
+	MsgHello      = "hello"       // Version, MinVersion, Name. Must be the first message
+	MsgListRooms  = "list_rooms"  //
+	MsgCreateRoom = "create_room" // Options. The creator joins as the host
+	MsgJoinRoom   = "join_room"   // RoomID, Spectator
+	MsgReclaim    = "reclaim"     // Token. Takes back a seat after a lost connection
+	MsgLeaveRoom  = "leave_room"  //
+	MsgReady      = "ready"       // Ready
+	MsgStart      = "start"       // Host only, once every player is ready
+	MsgPlay       = "play"        // CardID, Target
+	MsgPass       = "pass"        //
+	MsgPong       = "pong"        // Answers a ping
+)
+
+// Message types sent by the server
+const (
+	// MsgHello answers the hello of a client with the version both use
+	MsgRooms   = "rooms"   // Rooms, sent on request and whenever a room changes to clients in the lobby
+	MsgRoom    = "room"    // Room, sent to its members whenever it changes
+	MsgWelcome = "welcome" // Seat and Token, empty for spectators. The match started or a seat was reclaimed
+	MsgState   = "state"   // State
+	MsgError   = "error"   // Error
+	MsgPing    = "ping"    // Every ping interval, a client silent for three of them is disconnected
+)
+
+// Message is the single envelope of the protocol, each type only uses a few
+// of the fields. Unknown fields are ignored so newer peers can add some
+// without bumping the version.
+type Message struct {
+	Type string `json:"type"`
+
+	Version    int    `json:"version,omitempty"`
+	MinVersion int    `json:"min_version,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	Options   *RoomOptions `json:"options,omitempty"`
+	RoomID    string       `json:"room_id,omitempty"`
+	Spectator bool         `json:"spectator,omitempty"`
+	Ready     bool         `json:"ready,omitempty"`
+	Token     string       `json:"token,omitempty"` // Session token of a seat, keep it to reclaim the seat
+
+	CardID string         `json:"card_id,omitempty"`
+	Target *entity.Target `json:"target,omitempty"`
+
+	Rooms []RoomInfo `json:"rooms,omitempty"`
+	Room  *RoomInfo  `json:"room,omitempty"`
+	Seat  string     `json:"seat,omitempty"` // Player ID of the receiver
+	State *MatchView `json:"state,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+// RoomOptions are chosen by the host when creating a room
+type RoomOptions struct {
+	Name    string `json:"name"`
+	Deck    string `json:"deck"`
+	Ruleset string `json:"ruleset"`
+	Seats   int    `json:"seats"`    // 2 to 6
+	BotFill bool   `json:"bot_fill"` // Bots take the seats nobody joined, otherwise the table shrinks
+	GodView bool   `json:"god_view"` // Spectators see every hand, for coaching
+}
+
+// RoomInfo is a room as shown in the lobby and to its members
+type RoomInfo struct {
+	ID         string       `json:"id"`
+	Options    RoomOptions  `json:"options"`
+	Members    []MemberInfo `json:"members"`
+	Spectators int          `json:"spectators"`
+	Started    bool         `json:"started"`
+}
+
+type MemberInfo struct {
+	Name  string `json:"name"`
+	Host  bool   `json:"host"`
+	Ready bool   `json:"ready"`
+}
+
+// MatchView is the match as one seat may see it, only its own hand is sent.
+// Spectators of a room with god view get every hand instead.
+type MatchView struct {
+	Deck          string                     `json:"deck"`
+	Seat          string                     `json:"seat"`
+	Players       []PlayerView               `json:"players"`
+	Hand          []*entity.Card             `json:"hand"`
+	Targets       map[string][]entity.Target `json:"targets"` // Card ID -> legal targets, none when it cannot be played
+	Table         []TableCard                `json:"table"`   // Oldest to newest
+	Current       string                     `json:"current"`
+	FinishedOrder []string                   `json:"finished_order"`
+	DishesMade    int                        `json:"dishes_made"`
+	Over          bool                       `json:"over"`
+	Log           []string                   `json:"log,omitempty"`   // Recent events, oldest first
+	Hands         map[string][]*entity.Card  `json:"hands,omitempty"` // Player ID -> hand, god view only
+}
+
+type PlayerView struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	IsBot    bool   `json:"is_bot"`
+	Cards    int    `json:"cards"` // Cards in hand
+	Passed   bool   `json:"passed"`
+	Finished bool   `json:"finished"`
+	Score    int    `json:"score"`
+	Away     bool   `json:"away,omitempty"` // Lost connection, a bot plays until the player is back
+}
+
+type TableCard struct {
+	Card     *entity.Card `json:"card"`
+	PlayerID string       `json:"player_id"`
+}
+
+// negotiateVersion returns the newest version both ranges share
+func negotiateVersion(version, minVersion int) (int, bool) {
+	if minVersion == 0 {
+		minVersion = version
+	}
+	v := min(version, ProtocolVersion)
+	if v < max(minVersion, MinProtocolVersion) {
+		return 0, false
+	}
+	return v, true
+}
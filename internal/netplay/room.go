@@ -0,0 +1,496 @@
+package netplay
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// normalize fills the defaults of the options and checks them
+func (o *RoomOptions) normalize(host string) error {
+	o.Name = strings.TrimSpace(o.Name)
+	if o.Name == "" {
+		o.Name = host + "'s room"
+	}
+	if o.Deck == "" {
+		o.Deck = "default"
+	}
+	if o.Ruleset == "" {
+		o.Ruleset = card.RulesetStandard
+	}
+	if o.Seats == 0 {
+		o.Seats = 4
+	}
+
+	var errs []error
+	if !slices.Contains(card.Themes, o.Deck) {
+		errs = append(errs, fmt.Errorf("unknown deck %q", o.Deck))
+	}
+	if !slices.Contains(card.Rulesets, o.Ruleset) {
+		errs = append(errs, fmt.Errorf("unknown ruleset %q", o.Ruleset))
+	}
+	if o.Seats < 2 || o.Seats > 6 {
+		errs = append(errs, fmt.Errorf("seats must be between 2 and 6, got %d", o.Seats))
+	}
+	return errors.Join(errs...)
+}
+
+// room gathers players until its host starts the match, then runs it. It
+// lives on the server goroutine like everything else.
+type room struct {
+	s    *Server
+	id   string
+	opts RoomOptions
+
+	host       *client
+	players    []*client // In join order, which is the seating order
+	spectators []*client
+
+	engine     *rules.Engine
+	log        *rules.Log
+	bots       *ai.Manager
+	botSeats   map[string]bool        // Seats played by a bot, from the start or after their client left
+	tokens     map[string]string      // Session token -> seat, for players who may reclaim their seat
+	away       map[string]*time.Timer // Seat -> timer handing it to the bot for good
+	started    bool
+	botPending bool
+	closed     bool // Set once the server dropped the room
+}
+
+func newRoom(s *Server, id string, opts RoomOptions) *room {
+	r := &room{
+		s:        s,
+		id:       id,
+		opts:     opts,
+		engine:   rules.NewEngine(card.NewManager(), rules.NewTurnManager()),
+		log:      rules.NewLog(rules.DefaultLogSize),
+		bots:     ai.NewManager(),
+		botSeats: make(map[string]bool),
+		tokens:   make(map[string]string),
+		away:     make(map[string]*time.Timer),
+	}
+	r.engine.OnEvent = func(ev entity.Event) {
+		r.log.Record(r.engine, ev)
+	}
+	return r
+}
+
+func (r *room) info() RoomInfo {
+	info := RoomInfo{
+		ID:         r.id,
+		Options:    r.opts,
+		Members:    make([]MemberInfo, 0, len(r.players)),
+		Spectators: len(r.spectators),
+		Started:    r.started,
+	}
+	for _, c := range r.players {
+		info.Members = append(info.Members, MemberInfo{Name: c.name, Host: c == r.host, Ready: c.ready})
+	}
+	return info
+}
+
+func (r *room) join(c *client, spectator bool) error {
+	if !spectator {
+		if r.started {
+			return errors.New("the match has already started, join as a spectator")
+		}
+		if len(r.players) >= r.opts.Seats {
+			return errors.New("the room is full")
+		}
+	}
+
+	c.room = r
+	c.spectator = spectator
+	c.ready = false
+	c.seat = ""
+	if spectator {
+		r.spectators = append(r.spectators, c)
+	} else {
+		r.players = append(r.players, c)
+		if r.host == nil {
+			r.host = c
+		}
+	}
+	fmt.Printf("%s joined room %s\n", c.name, r.id)
+
+	r.changed()
+	if r.started {
+		c.send(Message{Type: MsgWelcome})
+		c.send(Message{Type: MsgState, State: r.view("")})
+	}
+	return nil
+}
+
+// leave takes a client out of the room. A bot takes over the seat of a
+// player who leaves a running match for good.
+func (r *room) leave(c *client) {
+	seat := c.seat
+	r.remove(c)
+	fmt.Printf("%s left room %s\n", c.name, r.id)
+
+	if seat != "" {
+		r.forgetSeat(seat)
+	}
+	if r.closeIfEmpty() {
+		return
+	}
+	if r.started && seat != "" && !r.engine.IsOver() {
+		r.handToBot(seat)
+		r.afterChange()
+	}
+	r.changed()
+}
+
+// drop handles a lost connection. A bot plays the seat until the player
+// reclaims it, or for good once the reclaim timeout is over.
+func (r *room) drop(c *client) {
+	seat := c.seat
+	if !r.started || seat == "" || r.engine.IsOver() {
+		r.leave(c)
+		return
+	}
+
+	r.remove(c)
+	fmt.Printf("%s lost connection to room %s\n", c.name, r.id)
+	r.handToBot(seat)
+	r.away[seat] = time.AfterFunc(r.s.cfg.ReclaimTimeout, func() {
+		r.s.do(func() { r.expire(seat) })
+	})
+	r.afterChange()
+	r.changed()
+}
+
+// expire hands the seat of a player who did not come back to the bot for
+// good
+func (r *room) expire(seat string) {
+	if _, ok := r.away[seat]; !ok || r.closed {
+		return
+	}
+	delete(r.away, seat)
+	r.forgetSeat(seat)
+	fmt.Printf("Seat %s of room %s now belongs to its bot\n", seat, r.id)
+
+	if !r.closeIfEmpty() {
+		r.broadcast()
+	}
+}
+
+// reclaim gives a seat back to a player who lost their connection. The
+// server may not have noticed yet, then the token wins over the connection
+// still holding the seat, which is most likely dead.
+func (r *room) reclaim(c *client, seat string) error {
+	if timer, ok := r.away[seat]; ok {
+		timer.Stop()
+		delete(r.away, seat)
+		delete(r.botSeats, seat)
+	} else {
+		i := slices.IndexFunc(r.players, func(p *client) bool { return p.seat == seat })
+		if i < 0 {
+			return errors.New("the seat is no longer yours, a bot took it over")
+		}
+		old := r.players[i]
+		r.remove(old)
+		old.seat = ""
+		old.gone = true
+		go old.conn.Close()
+		fmt.Printf("%s took over the seat of %s in room %s\n", c.name, old.name, r.id)
+	}
+
+	c.room = r
+	c.spectator = false
+	c.seat = seat
+	r.players = append(r.players, c)
+	if r.host == nil {
+		r.host = c
+	}
+	fmt.Printf("%s reclaimed their seat in room %s\n", c.name, r.id)
+
+	token := ""
+	for t, s := range r.tokens {
+		if s == seat {
+			token = t
+		}
+	}
+	c.send(Message{Type: MsgWelcome, Seat: seat, Token: token})
+	r.changed()
+	// Everyone sees the seat is back, the player gets the full state
+	r.afterChange()
+	return nil
+}
+
+// remove takes a client out of the member lists
+func (r *room) remove(c *client) {
+	r.players = slices.DeleteFunc(r.players, func(o *client) bool { return o == c })
+	r.spectators = slices.DeleteFunc(r.spectators, func(o *client) bool { return o == c })
+	c.room = nil
+	if r.host == c {
+		r.host = nil
+		if len(r.players) > 0 {
+			r.host = r.players[0]
+		}
+	}
+}
+
+func (r *room) handToBot(seat string) {
+	r.botSeats[seat] = true
+	if r.bots.GetBot(seat) == nil {
+		r.bots.RegisterBot(seat, ai.NewEasyBot())
+	}
+}
+
+// forgetSeat makes the session token of a seat useless
+func (r *room) forgetSeat(seat string) {
+	for token, s := range r.tokens {
+		if s == seat {
+			delete(r.tokens, token)
+		}
+	}
+}
+
+// closeIfEmpty closes the room once nobody is in it or may come back
+func (r *room) closeIfEmpty() bool {
+	if len(r.players) > 0 || len(r.spectators) > 0 || len(r.away) > 0 {
+		return false
+	}
+	r.s.closeRoom(r)
+	r.s.lobbyChanged()
+	return true
+}
+
+func (r *room) handleMessage(c *client, msg Message) error {
+	// Spectators only watch, whatever they send
+	if c.spectator {
+		return errors.New("spectators cannot send actions")
+	}
+
+	switch msg.Type {
+	case MsgReady:
+		if r.started {
+			return errors.New("the match has already started")
+		}
+		c.ready = msg.Ready
+		r.changed()
+		return nil
+	case MsgStart:
+		return r.start(c)
+	}
+
+	if !r.started {
+		return errors.New("the match has not started yet")
+	}
+	if c.seat == "" {
+		return errors.New("you have no seat in this match")
+	}
+
+	var err error
+	switch msg.Type {
+	case MsgPlay:
+		target := entity.Target{}
+		if msg.Target != nil {
+			target = *msg.Target
+		}
+		err = r.engine.PlayCard(c.seat, msg.CardID, target)
+	case MsgPass:
+		err = r.engine.Pass(c.seat)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.afterChange()
+	return nil
+}
+
+func (r *room) start(c *client) error {
+	if c != r.host {
+		return errors.New("only the host can start the match")
+	}
+	if r.started {
+		return errors.New("the match has already started")
+	}
+	for _, p := range r.players {
+		if p != r.host && !p.ready {
+			return fmt.Errorf("%s is not ready", p.name)
+		}
+	}
+	if len(r.players) < 2 && !r.opts.BotFill {
+		return errors.New("at least two players are needed without bots")
+	}
+
+	// Everything that can fail comes before seats and tokens are handed out
+	if err := r.engine.Cards.LoadDeckRuleset(r.opts.Deck, r.opts.Ruleset); err != nil {
+		return fmt.Errorf("load deck: %w", err)
+	}
+	tokens := make(map[*client]string)
+	for _, p := range r.players {
+		token, err := newToken()
+		if err != nil {
+			return err
+		}
+		tokens[p] = token
+	}
+
+	players := []*entity.Player{}
+	for _, p := range r.players {
+		player := entity.NewPlayer(p.name, entity.TypePlayer)
+		p.seat = player.ID
+		players = append(players, player)
+		r.tokens[tokens[p]] = p.seat
+	}
+	for i := 1; r.opts.BotFill && len(players) < r.opts.Seats; i++ {
+		p := entity.NewPlayer(fmt.Sprintf("Bot %d", i), entity.TypeBot)
+		r.botSeats[p.ID] = true
+		r.bots.RegisterBot(p.ID, ai.NewEasyBot())
+		players = append(players, p)
+	}
+
+	r.engine.Start(players)
+	r.started = true
+	fmt.Printf("Room %s started a match with %d players\n", r.id, len(players))
+
+	r.changed()
+	for _, p := range r.players {
+		p.send(Message{Type: MsgWelcome, Seat: p.seat, Token: tokens[p]})
+	}
+	for _, sp := range r.spectators {
+		sp.send(Message{Type: MsgWelcome})
+	}
+	r.afterChange()
+	return nil
+}
+
+// changed tells the members and the lobby that the room changed
+func (r *room) changed() {
+	info := r.info()
+	for _, c := range r.members() {
+		c.send(Message{Type: MsgRoom, Room: &info})
+	}
+	r.s.lobbyChanged()
+}
+
+func (r *room) members() []*client {
+	return append(slices.Clip(r.players), r.spectators...)
+}
+
+func (r *room) afterChange() {
+	r.broadcast()
+	if !r.engine.IsOver() {
+		r.driveBots()
+	}
+}
+
+func (r *room) broadcast() {
+	for _, c := range r.members() {
+		c.send(Message{Type: MsgState, State: r.view(c.seat)})
+	}
+}
+
+// driveBots schedules the turn of the current player when a bot plays it
+func (r *room) driveBots() {
+	if r.botPending || r.engine.IsOver() {
+		return
+	}
+	current := r.engine.Turns.Current()
+	if current == nil || !r.botSeats[current.ID] {
+		return
+	}
+
+	r.botPending = true
+	id := current.ID
+	time.AfterFunc(r.s.cfg.BotDelay, func() {
+		r.s.do(func() {
+			r.botPending = false
+			if !r.closed {
+				r.playBot(id)
+			}
+		})
+	})
+}
+
+func (r *room) playBot(id string) {
+	current := r.engine.Turns.Current()
+	if current == nil || current.ID != id || !r.botSeats[id] || r.engine.IsOver() {
+		r.driveBots()
+		return
+	}
+
+	player := r.engine.GetPlayer(id)
+	err := errors.New("no cards in hand")
+	if len(player.Hand) > 0 {
+		err = r.bots.GetBot(id).PlayTurn(&ai.EngineGame{Engine: r.engine}, id)
+	}
+	if err != nil {
+		_ = r.engine.Pass(id)
+	}
+	r.afterChange()
+}
+
+// view builds what one seat may see of the match, spectators have no seat
+func (r *room) view(seat string) *MatchView {
+	e := r.engine
+	v := &MatchView{
+		Deck:          e.Cards.Theme,
+		Seat:          seat,
+		Targets:       make(map[string][]entity.Target),
+		FinishedOrder: append([]string{}, e.Turns.FinishedOrder()...),
+		DishesMade:    e.DishesMade,
+		Over:          e.IsOver(),
+		Log:           slices.Clone(r.log.Lines),
+	}
+	if current := e.Turns.Current(); current != nil {
+		v.Current = current.ID
+	}
+
+	for _, p := range e.Players {
+		turn := e.Turns.GetPlayerByID(p.ID)
+		v.Players = append(v.Players, PlayerView{
+			ID:       p.ID,
+			Name:     p.Name,
+			IsBot:    r.botSeats[p.ID],
+			Cards:    len(p.Hand),
+			Passed:   turn.Passed,
+			Finished: turn.Finished,
+			Score:    e.Score(p.ID),
+			Away:     r.away[p.ID] != nil,
+		})
+	}
+
+	if p := e.GetPlayer(seat); p != nil {
+		for _, id := range p.OrderHand {
+			v.Hand = append(v.Hand, p.GetCard(id))
+			v.Targets[id] = e.LegalTargets(seat, id)
+		}
+	}
+
+	if seat == "" && r.opts.GodView {
+		v.Hands = make(map[string][]*entity.Card)
+		for _, p := range e.Players {
+			for _, id := range p.OrderHand {
+				v.Hands[p.ID] = append(v.Hands[p.ID], p.GetCard(id))
+			}
+		}
+	}
+
+	for _, entry := range e.Cards.TableStack.GetEntriesInOrder() {
+		v.Table = append(v.Table, TableCard{Card: entry.Card, PlayerID: entry.PlayerID})
+	}
+	return v
+}
+
+// newToken returns a random session token
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,420 @@
+package netplay
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config of a match server
+type Config struct {
+	MaxRooms       int           // Rooms open at the same time
+	BotDelay       time.Duration // Pause before a bot plays so people can follow
+	ReclaimTimeout time.Duration // How long a bot keeps the seat of a lost player before it is theirs for good
+	Origins        []string      // Web pages of other hosts allowed to open a websocket, such as https://example.com
+	PingInterval   time.Duration // Between two pings, a client silent for three of them is disconnected
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxRooms:       16,
+		BotDelay:       800 * time.Millisecond,
+		ReclaimTimeout: 2 * time.Minute,
+		PingInterval:   10 * time.Second,
+	}
+}
+
+func (c Config) validate() error {
+	var errs []error
+	if c.MaxRooms < 1 {
+		errs = append(errs, fmt.Errorf("max rooms must be at least 1, got %d", c.MaxRooms))
+	}
+	if c.ReclaimTimeout < 0 {
+		errs = append(errs, fmt.Errorf("reclaim timeout cannot be negative, got %s", c.ReclaimTimeout))
+	}
+	if c.PingInterval <= 0 {
+		errs = append(errs, fmt.Errorf("ping interval must be positive, got %s", c.PingInterval))
+	}
+	return errors.Join(errs...)
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// Server keeps a lobby of rooms, each hosting one match, and is the only
+// one to run the rules. Clients send intents, the server checks them against
+// the engine and sends every seat its own view of the result. All state is
+// owned by a single goroutine, everything else posts functions to its inbox.
+type Server struct {
+	cfg Config
+
+	clients  map[*client]bool // Every client past the hello
+	rooms    map[string]*room
+	order    []string // Room IDs, oldest first
+	nextRoom int
+
+	inbox     chan func()
+	closed    chan struct{} // Closed by Close
+	closeOnce sync.Once
+}
+
+// sendQueue is how many messages may wait for a client. One that falls
+// further behind is disconnected rather than holding up the server.
+const sendQueue = 64
+
+type client struct {
+	conn      Conn
+	out       chan Message // Messages waiting for the writer goroutine
+	gone      bool         // Disconnected or too slow, nothing more is queued
+	name      string
+	version   int   // Protocol version agreed in the hello
+	room      *room // Nil while in the lobby
+	spectator bool
+	ready     bool
+	seat      string // Player ID once the match started
+}
+
+func newClient(conn Conn, name string, version int) *client {
+	c := &client{
+		conn:    conn,
+		out:     make(chan Message, sendQueue),
+		name:    name,
+		version: version,
+	}
+	go c.write()
+	return c
+}
+
+// write sends the queued messages until the queue is closed. Once the
+// connection fails the rest of the queue is dropped.
+func (c *client) write() {
+	for msg := range c.out {
+		if err := c.conn.Send(msg); err != nil {
+			_ = c.conn.Close()
+			for range c.out {
+			}
+			return
+		}
+	}
+}
+
+// send queues a message for the client without waiting for it to be
+// written. Only the server goroutine calls it. The message is encoded later
+// by the writer goroutine, so it must not share state the server changes
+// afterwards: views only share cards, which never change once dealt.
+func (c *client) send(msg Message) {
+	if c.gone {
+		return
+	}
+	select {
+	case c.out <- msg:
+	default:
+		c.gone = true
+		fmt.Println(c.name, "is not keeping up, disconnecting")
+		// Closing a websocket writes a frame, which could block as well
+		go c.conn.Close()
+	}
+}
+
+func NewServer(cfg Config) (*Server, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		cfg:     cfg,
+		clients: make(map[*client]bool),
+		rooms:   make(map[string]*room),
+		inbox:   make(chan func(), 64),
+		closed:  make(chan struct{}),
+	}
+	go s.loop()
+	return s, nil
+}
+
+func (s *Server) loop() {
+	for {
+		select {
+		case f := <-s.inbox:
+			f()
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// do runs f on the server goroutine
+func (s *Server) do(f func()) {
+	select {
+	case s.inbox <- f:
+	case <-s.closed:
+	}
+}
+
+// Close disconnects every client and stops the server
+func (s *Server) Close() {
+	s.do(func() {
+		for c := range s.clients {
+			c.conn.Close()
+		}
+	})
+	s.closeOnce.Do(func() { close(s.closed) })
+}
+
+// Serve accepts clients speaking line delimited JSON on a TCP listener
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-s.closed:
+				return nil
+			default:
+				return err
+			}
+		}
+		go s.HandleConn(NewStreamConn(conn))
+	}
+}
+
+// ServeHTTP accepts websocket clients
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := UpgradeWebSocket(w, r, s.cfg.Origins)
+	if err != nil {
+		return
+	}
+	s.HandleConn(conn)
+}
+
+// HandleConn serves one client until it disconnects. Any Conn works, which
+// lets tests connect clients through a net.Pipe.
+func (s *Server) HandleConn(conn Conn) {
+	defer conn.Close()
+
+	msg, err := conn.Receive()
+	if err != nil {
+		return
+	}
+	if msg.Type != MsgHello {
+		_ = conn.Send(Message{Type: MsgError, Error: "the first message must be a hello"})
+		return
+	}
+	version, ok := negotiateVersion(msg.Version, msg.MinVersion)
+	if !ok {
+		_ = conn.Send(Message{Type: MsgError, Error: fmt.Sprintf(
+			"protocol version %d to %d is not supported, this server speaks %d to %d",
+			msg.MinVersion, msg.Version, MinProtocolVersion, ProtocolVersion)})
+		return
+	}
+
+	c := newClient(conn, msg.Name, version)
+	s.do(func() { s.hello(c) })
+
+	// Unix time in nanoseconds of the last message, older clients are
+	// never pinged
+	var heard atomic.Int64
+	heard.Store(time.Now().UnixNano())
+	if version >= heartbeatVersion {
+		stop := make(chan struct{})
+		defer close(stop)
+		go s.heartbeat(c, &heard, stop)
+	}
+
+	for {
+		msg, err := conn.Receive()
+		if err != nil {
+			s.do(func() { s.disconnect(c) })
+			return
+		}
+		heard.Store(time.Now().UnixNano())
+		if msg.Type == MsgPong {
+			continue
+		}
+		s.do(func() { s.handleMessage(c, msg) })
+	}
+}
+
+// heartbeat pings a client until stop is closed and hangs up once it stays
+// silent for three intervals. A connection lost without a word would
+// otherwise keep its seat from the bot forever.
+func (s *Server) heartbeat(c *client, heard *atomic.Int64, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if time.Since(time.Unix(0, heard.Load())) > 3*s.cfg.PingInterval {
+				s.do(func() {
+					fmt.Println(c.name, "stopped answering, disconnecting")
+					c.gone = true
+					go c.conn.Close()
+				})
+				return
+			}
+			s.do(func() { c.send(Message{Type: MsgPing}) })
+		case <-stop:
+			return
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *Server) hello(c *client) {
+	c.name = s.uniqueName(strings.TrimSpace(c.name))
+	s.clients[c] = true
+	fmt.Printf("%s connected with protocol %d\n", c.name, c.version)
+
+	c.send(Message{Type: MsgHello, Version: c.version, Name: c.name})
+	s.sendRooms(c)
+}
+
+// uniqueName numbers a name already taken so clients can tell each other
+// apart
+func (s *Server) uniqueName(name string) string {
+	if name == "" {
+		name = "Player"
+	}
+	taken := func(n string) bool {
+		for c := range s.clients {
+			if c.name == n {
+				return true
+			}
+		}
+		return false
+	}
+
+	unique := name
+	for i := 2; taken(unique); i++ {
+		unique = fmt.Sprintf("%s %d", name, i)
+	}
+	return unique
+}
+
+func (s *Server) disconnect(c *client) {
+	delete(s.clients, c)
+	c.gone = true
+	close(c.out)
+	if c.room != nil {
+		c.room.drop(c)
+	}
+	fmt.Println(c.name, "disconnected")
+}
+
+func (s *Server) handleMessage(c *client, msg Message) {
+	var err error
+	switch msg.Type {
+	case MsgListRooms:
+		s.sendRooms(c)
+	case MsgCreateRoom:
+		err = s.createRoom(c, msg.Options)
+	case MsgJoinRoom:
+		err = s.joinRoom(c, msg.RoomID, msg.Spectator)
+	case MsgReclaim:
+		err = s.reclaim(c, msg.Token)
+	case MsgLeaveRoom:
+		if c.room == nil {
+			err = errors.New("not in a room")
+			break
+		}
+		c.room.leave(c)
+	case MsgReady, MsgStart, MsgPlay, MsgPass:
+		if c.room == nil {
+			err = errors.New("not in a room")
+			break
+		}
+		err = c.room.handleMessage(c, msg)
+	default:
+		err = fmt.Errorf("unknown message type %q", msg.Type)
+	}
+	if err != nil {
+		c.send(Message{Type: MsgError, Error: err.Error()})
+	}
+}
+
+func (s *Server) createRoom(c *client, opts *RoomOptions) error {
+	if c.room != nil {
+		return errors.New("already in a room")
+	}
+	if len(s.rooms) >= s.cfg.MaxRooms {
+		return errors.New("the server is full, try again later")
+	}
+	if opts == nil {
+		opts = &RoomOptions{}
+	}
+	o := *opts
+	if err := o.normalize(c.name); err != nil {
+		return err
+	}
+
+	s.nextRoom++
+	r := newRoom(s, fmt.Sprintf("%d", s.nextRoom), o)
+	s.rooms[r.id] = r
+	s.order = append(s.order, r.id)
+	fmt.Printf("%s opened room %s (%s)\n", c.name, r.id, o.Name)
+
+	return r.join(c, false)
+}
+
+func (s *Server) joinRoom(c *client, id string, spectator bool) error {
+	if c.room != nil {
+		return errors.New("already in a room")
+	}
+	r, ok := s.rooms[id]
+	if !ok {
+		return fmt.Errorf("no room %q", id)
+	}
+	return r.join(c, spectator)
+}
+
+// reclaim seats a client back in the match it lost the connection to
+func (s *Server) reclaim(c *client, token string) error {
+	if c.room != nil {
+		return errors.New("already in a room")
+	}
+	for _, r := range s.rooms {
+		if seat, ok := r.tokens[token]; ok {
+			return r.reclaim(c, seat)
+		}
+	}
+	return errors.New("the seat is no longer yours, a bot took it over")
+}
+
+// closeRoom removes a room nobody is left in
+func (s *Server) closeRoom(r *room) {
+	r.closed = true
+	delete(s.rooms, r.id)
+	for i, id := range s.order {
+		if id == r.id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	fmt.Println("Closed room", r.id)
+}
+
+func (s *Server) roomList() []RoomInfo {
+	rooms := make([]RoomInfo, 0, len(s.order))
+	for _, id := range s.order {
+		rooms = append(rooms, s.rooms[id].info())
+	}
+	return rooms
+}
+
+func (s *Server) sendRooms(c *client) {
+	c.send(Message{Type: MsgRooms, Rooms: s.roomList()})
+}
+
+// lobbyChanged tells the clients in the lobby that the rooms changed
+func (s *Server) lobbyChanged() {
+	rooms := s.roomList()
+	for c := range s.clients {
+		if c.room == nil {
+			c.send(Message{Type: MsgRooms, Rooms: rooms})
+		}
+	}
+}
@@ -0,0 +1,478 @@
+package netplay
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+const testTimeout = 5 * time.Second
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.BotDelay = time.Millisecond
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// pipe connects a loopback client to the server and returns its end
+func pipe(t *testing.T, s *Server) Conn {
+	t.Helper()
+	server, client := net.Pipe()
+	go s.HandleConn(NewStreamConn(server))
+	conn := NewStreamConn(client)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// testClient reads everything the server sends in the background, so the
+// server is never held up by a test that is not reading yet
+type testClient struct {
+	*Client
+	msgs chan Message
+}
+
+func connect(t *testing.T, s *Server, name string) *testClient {
+	t.Helper()
+	c := &testClient{Client: NewClient(pipe(t, s)), msgs: make(chan Message, 1024)}
+	if err := c.Hello(name); err != nil {
+		t.Fatalf("hello: %v", err)
+	}
+	go func() {
+		defer close(c.msgs)
+		for {
+			msg, err := c.Receive()
+			if err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	return c
+}
+
+// expect skips messages until one matches
+func (c *testClient) expect(t *testing.T, what string, match func(Message) bool) Message {
+	t.Helper()
+	deadline := time.After(testTimeout)
+	for {
+		select {
+		case msg, ok := <-c.msgs:
+			if !ok {
+				t.Fatalf("%s: disconnected while waiting for %s", c.Name, what)
+			}
+			if match(msg) {
+				return msg
+			}
+		case <-deadline:
+			t.Fatalf("%s: no %s", c.Name, what)
+		}
+	}
+}
+
+func (c *testClient) expectType(t *testing.T, typ string) Message {
+	t.Helper()
+	return c.expect(t, typ, func(msg Message) bool { return msg.Type == typ })
+}
+
+// startMatch opens a room of the host, seats the guests and deals. It
+// returns the first state each client got, in the same order.
+func startMatch(t *testing.T, opts RoomOptions, host *testClient, guests ...*testClient) []*MatchView {
+	t.Helper()
+	if err := host.CreateRoom(opts); err != nil {
+		t.Fatal(err)
+	}
+	id := host.expectType(t, MsgRoom).Room.ID
+	for i, g := range guests {
+		if err := g.JoinRoom(id, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.SetReady(true); err != nil {
+			t.Fatal(err)
+		}
+		host.expect(t, "ready guest", func(msg Message) bool {
+			return msg.Type == MsgRoom && len(msg.Room.Members) > i+1 && msg.Room.Members[i+1].Ready
+		})
+	}
+	if err := host.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	var states []*MatchView
+	for _, c := range append([]*testClient{host}, guests...) {
+		seat := c.expectType(t, MsgWelcome).Seat
+		if seat == "" {
+			t.Fatalf("%s got no seat", c.Name)
+		}
+		states = append(states, c.expectType(t, MsgState).State)
+	}
+	return states
+}
+
+func TestHello(t *testing.T) {
+	tests := []struct {
+		name    string
+		hello   Message
+		version int    // Agreed version, 0 when the hello is refused
+		error   string // Part of the error of a refused hello
+	}{
+		{name: "same version", hello: Message{Type: MsgHello, Version: ProtocolVersion, MinVersion: MinProtocolVersion, Name: "Ann"}, version: ProtocolVersion},
+		{name: "newer client", hello: Message{Type: MsgHello, Version: ProtocolVersion + 5, MinVersion: MinProtocolVersion, Name: "Ann"}, version: ProtocolVersion},
+		{name: "no minimum", hello: Message{Type: MsgHello, Version: ProtocolVersion, Name: "Ann"}, version: ProtocolVersion},
+		{name: "too new", hello: Message{Type: MsgHello, Version: ProtocolVersion + 5, MinVersion: ProtocolVersion + 1}, error: "not supported"},
+		{name: "not a hello", hello: Message{Type: MsgListRooms}, error: "first message must be a hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := pipe(t, newTestServer(t))
+			if err := conn.Send(tt.hello); err != nil {
+				t.Fatal(err)
+			}
+			msg, err := conn.Receive()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.version == 0 {
+				if msg.Type != MsgError || !strings.Contains(msg.Error, tt.error) {
+					t.Fatalf("got %+v, want an error about %q", msg, tt.error)
+				}
+				return
+			}
+			if msg.Type != MsgHello || msg.Version != tt.version || msg.Name != tt.hello.Name {
+				t.Fatalf("got %+v, want hello with version %d", msg, tt.version)
+			}
+			if next, err := conn.Receive(); err != nil || next.Type != MsgRooms {
+				t.Fatalf("after the hello got %+v, %v, want the rooms", next, err)
+			}
+		})
+	}
+}
+
+func TestHelloNamesAreUnique(t *testing.T) {
+	s := newTestServer(t)
+	var names []string
+	for range 3 {
+		names = append(names, connect(t, s, "Ann").Name)
+	}
+	if want := []string{"Ann", "Ann 2", "Ann 3"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestHandsStayPrivate(t *testing.T) {
+	s := newTestServer(t)
+	host, guest := connect(t, s, "Host"), connect(t, s, "Guest")
+	states := startMatch(t, RoomOptions{Seats: 2}, host, guest)
+
+	seen := make(map[string]string) // Card ID -> seat it was sent to
+	for _, v := range states {
+		if v.Hands != nil {
+			t.Errorf("seat %s got every hand", v.Seat)
+		}
+		if len(v.Hand) == 0 {
+			t.Fatalf("seat %s got no hand", v.Seat)
+		}
+		for _, c := range v.Hand {
+			if other, ok := seen[c.ID]; ok {
+				t.Errorf("card %s sent to seats %s and %s", c.ID, other, v.Seat)
+			}
+			seen[c.ID] = v.Seat
+		}
+		for _, p := range v.Players {
+			if p.ID == v.Seat && p.Cards != len(v.Hand) {
+				t.Errorf("seat %s holds %d cards, was sent %d", p.ID, p.Cards, len(v.Hand))
+			}
+		}
+		for id := range v.Targets {
+			if seen[id] != v.Seat {
+				t.Errorf("seat %s got the targets of card %s it does not hold", v.Seat, id)
+			}
+		}
+	}
+}
+
+func TestIllegalMovesAreRejected(t *testing.T) {
+	s := newTestServer(t)
+	host, guest := connect(t, s, "Host"), connect(t, s, "Guest")
+	states := startMatch(t, RoomOptions{Seats: 2, Ruleset: "classic"}, host, guest)
+
+	current, waiting := host, guest
+	currentState, waitingState := states[0], states[1]
+	if states[0].Current != states[0].Seat {
+		current, waiting = guest, host
+		currentState, waitingState = states[1], states[0]
+	}
+
+	tests := []struct {
+		name  string
+		by    *testClient
+		send  func(c *testClient) error
+		error string
+	}{
+		{
+			name:  "pass out of turn",
+			by:    waiting,
+			send:  func(c *testClient) error { return c.Pass() },
+			error: "not the turn",
+		},
+		{
+			name:  "play out of turn",
+			by:    waiting,
+			send:  func(c *testClient) error { return c.Play(waitingState.Hand[0].ID, entity.Target{}) },
+			error: "not the turn",
+		},
+		{
+			name:  "unknown card",
+			by:    current,
+			send:  func(c *testClient) error { return c.Play("no-such-card", entity.Target{}) },
+			error: "invalid card",
+		},
+		{
+			name:  "card of another hand",
+			by:    current,
+			send:  func(c *testClient) error { return c.Play(waitingState.Hand[0].ID, entity.Target{}) },
+			error: "invalid card",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.send(tt.by); err != nil {
+				t.Fatal(err)
+			}
+			msg := tt.by.expect(t, "error", func(msg Message) bool {
+				if msg.Type == MsgState {
+					t.Fatalf("the move was played: %+v", msg.State)
+				}
+				return msg.Type == MsgError
+			})
+			if !strings.Contains(msg.Error, tt.error) {
+				t.Errorf("error = %q, want it to mention %q", msg.Error, tt.error)
+			}
+		})
+	}
+
+	// The rejected moves changed nothing, the current seat can still play
+	if err := current.Pass(); err != nil {
+		t.Fatal(err)
+	}
+	v := current.expectType(t, MsgState).State
+	if v.Current == currentState.Seat {
+		t.Error("the pass of the current seat was not played")
+	}
+}
+
+func TestBotsFillEmptySeats(t *testing.T) {
+	s := newTestServer(t)
+	host := connect(t, s, "Host")
+	v := startMatch(t, RoomOptions{Seats: 3, BotFill: true}, host)[0]
+
+	var bots []string
+	for _, p := range v.Players {
+		if p.IsBot {
+			bots = append(bots, p.Name)
+		}
+	}
+	if len(v.Players) != 3 || len(bots) != 2 {
+		t.Fatalf("players %+v, want the host and 2 bots", v.Players)
+	}
+
+	// The bots play their turns on their own, the host passes on theirs
+	for {
+		for _, line := range v.Log {
+			if strings.HasPrefix(line, bots[0]) || strings.HasPrefix(line, bots[1]) {
+				return
+			}
+		}
+		if v.Over {
+			t.Fatal("the match ended without a move of a bot")
+		}
+		if v.Current == v.Seat {
+			if err := host.Pass(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		v = host.expectType(t, MsgState).State
+	}
+}
+
+func TestSlowClientDoesNotBlockTheServer(t *testing.T) {
+	s := newTestServer(t)
+
+	// Never reads anything the server sends
+	stalled := pipe(t, s)
+	if err := stalled.Send(Message{Type: MsgHello, Version: ProtocolVersion, Name: "Stalled"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each room opened and left is news for the lobby, the stalled client
+	// included, until its queue overflows
+	fast := connect(t, s, "Fast")
+	for i := range 2 * sendQueue {
+		if err := fast.CreateRoom(RoomOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		fast.expectType(t, MsgRoom)
+		if err := fast.LeaveRoom(); err != nil {
+			t.Fatal(err)
+		}
+		fast.expect(t, "lobby after leaving", func(msg Message) bool {
+			return msg.Type == MsgRooms && len(msg.Rooms) == 0
+		})
+		if t.Failed() {
+			t.Fatalf("round %d", i)
+		}
+	}
+
+	// The stalled client was disconnected, what was queued ends with EOF
+	done := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := stalled.Receive(); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(testTimeout):
+		t.Fatal("the stalled client is still connected")
+	}
+}
+
+func TestFailedStartHandsOutNothing(t *testing.T) {
+	s := newTestServer(t)
+	host, guest := &client{name: "Ann"}, &client{name: "Bob", ready: true}
+	r := newRoom(s, "test", RoomOptions{Deck: "missing", Ruleset: "standard", Seats: 4, BotFill: true})
+	r.host = host
+	r.players = []*client{host, guest}
+
+	if err := r.start(host); err == nil {
+		t.Fatal("a match with a missing deck started")
+	}
+	if r.started || len(r.tokens) > 0 || len(r.botSeats) > 0 {
+		t.Errorf("started %v with %d tokens and %d bot seats", r.started, len(r.tokens), len(r.botSeats))
+	}
+	for _, c := range r.players {
+		if c.seat != "" {
+			t.Errorf("%s was seated at %s", c.name, c.seat)
+		}
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		answers bool // Whether the client answers pings
+		pinged  bool
+		dropped bool
+	}{
+		{name: "answering client", version: ProtocolVersion, answers: true, pinged: true},
+		{name: "silent client", version: ProtocolVersion, pinged: true, dropped: true},
+		{name: "client without heartbeat", version: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PingInterval = 20 * time.Millisecond
+			s, err := NewServer(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(s.Close)
+
+			conn := pipe(t, s)
+			if err := conn.Send(Message{Type: MsgHello, Version: tt.version, Name: "Ann"}); err != nil {
+				t.Fatal(err)
+			}
+			pinged := make(chan struct{}, 1)
+			dropped := make(chan struct{})
+			go func() {
+				defer close(dropped)
+				for {
+					msg, err := conn.Receive()
+					if err != nil {
+						return
+					}
+					if msg.Type != MsgPing {
+						continue
+					}
+					select {
+					case pinged <- struct{}{}:
+					default:
+					}
+					if tt.answers {
+						if err := conn.Send(Message{Type: MsgPong}); err != nil {
+							return
+						}
+					}
+				}
+			}()
+
+			select {
+			case <-dropped:
+				if !tt.dropped {
+					t.Fatal("the client was disconnected")
+				}
+			case <-time.After(20 * cfg.PingInterval):
+				if tt.dropped {
+					t.Fatal("the client is still connected")
+				}
+			}
+			select {
+			case <-pinged:
+				if !tt.pinged {
+					t.Error("the client was pinged")
+				}
+			default:
+				if tt.pinged {
+					t.Error("the client was never pinged")
+				}
+			}
+		})
+	}
+}
+
+func TestReclaimTakesOverAStaleConnection(t *testing.T) {
+	s := newTestServer(t)
+	host, guest := connect(t, s, "Ann"), connect(t, s, "Bob")
+	states := startMatch(t, RoomOptions{Seats: 2}, host, guest)
+	seat, token := states[1].Seat, guest.Token
+
+	// The server has not noticed the first connection of Bob is gone
+	again := connect(t, s, "Bob")
+	if err := again.Reclaim(token); err != nil {
+		t.Fatal(err)
+	}
+	if got := again.expectType(t, MsgWelcome).Seat; got != seat {
+		t.Fatalf("reclaimed seat %q, want %q", got, seat)
+	}
+	if got := again.expectType(t, MsgState).State.Seat; got != seat {
+		t.Errorf("state of seat %q, want %q", got, seat)
+	}
+
+	deadline := time.After(testTimeout)
+	for {
+		select {
+		case _, ok := <-guest.msgs:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("the stale connection is still open")
+		}
+	}
+}
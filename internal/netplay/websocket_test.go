@@ -0,0 +1,69 @@
+package netplay
+
+import (
+	"bufio"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		origins []string
+		allowed bool
+	}{
+		{name: "native client", origin: "", allowed: true},
+		{name: "same host", origin: "http://play.example.com", allowed: true},
+		{name: "other host", origin: "https://evil.example.net", allowed: false},
+		{name: "listed", origin: "https://friends.example.net", origins: []string{"https://friends.example.net/"}, allowed: true},
+		{name: "listed with another scheme", origin: "http://friends.example.net", origins: []string{"https://friends.example.net"}, allowed: false},
+		{name: "malformed", origin: "://", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://play.example.com/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := originAllowed(r, tt.origins); got != tt.allowed {
+				t.Errorf("originAllowed(%q) = %v, want %v", tt.origin, got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestFrameMasking(t *testing.T) {
+	tests := []struct {
+		name     string
+		masks    bool // Whether the sender masks its frames
+		receiver bool // Whether the receiver is the client
+		ok       bool
+	}{
+		{name: "masked to server", masks: true, receiver: false, ok: true},
+		{name: "unmasked to server", masks: false, receiver: false, ok: false},
+		{name: "unmasked to client", masks: false, receiver: true, ok: true},
+		{name: "masked to client", masks: true, receiver: true, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := net.Pipe()
+			t.Cleanup(func() {
+				a.Close()
+				b.Close()
+			})
+			sender := &wsConn{conn: a, br: bufio.NewReader(a), client: tt.masks}
+			receiver := &wsConn{conn: b, br: bufio.NewReader(b), client: tt.receiver}
+
+			go func() { _ = sender.Send(Message{Type: MsgPass}) }()
+			msg, err := receiver.Receive()
+			if tt.ok && (err != nil || msg.Type != MsgPass) {
+				t.Fatalf("Receive() = %+v, %v, want a pass", msg, err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("Receive() = %+v, want an error", msg)
+			}
+		})
+	}
+}
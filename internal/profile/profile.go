@@ -0,0 +1,117 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the profile
+const Key = "profile.json"
+
+// Profile keeps the lifetime statistics of the local player
+type Profile struct {
+	GamesPlayed    int            `json:"games_played"`
+	Wins           int            `json:"wins"`
+	TotalPositions int            `json:"total_positions"` // Sum of finishing positions, for the average
+	Dishes         map[string]int `json:"dishes"`          // RecipeID -> times made
+	WinStreak      int            `json:"win_streak"`
+	BestWinStreak  int            `json:"best_win_streak"`
+	HintsUsed      int            `json:"hints_used"`
+}
+
+// MatchResult is what a finished match adds to the profile
+type MatchResult struct {
+	Position int      // 1 for the first player to finish
+	Players  int      // Players in the match
+	Dishes   []string // Recipe IDs of the dishes the player made
+	Hints    int      // Hints the player asked for
+}
+
+func New() *Profile {
+	return &Profile{
+		Dishes: make(map[string]int),
+	}
+}
+
+// Load reads the saved profile, a missing profile gives an empty one
+func Load(store storage.Store) (*Profile, error) {
+	p := New()
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return New(), fmt.Errorf("read profile: %w", err)
+	}
+	if p.Dishes == nil {
+		p.Dishes = make(map[string]int)
+	}
+	return p, nil
+}
+
+func (p *Profile) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
+
+// Record adds a finished match to the statistics
+func (p *Profile) Record(r MatchResult) {
+	p.GamesPlayed++
+	p.TotalPositions += r.Position
+	p.HintsUsed += r.Hints
+	for _, id := range r.Dishes {
+		p.Dishes[id]++
+	}
+
+	if r.Position == 1 {
+		p.Wins++
+		p.WinStreak++
+		p.BestWinStreak = max(p.BestWinStreak, p.WinStreak)
+	} else {
+		p.WinStreak = 0
+	}
+}
+
+// AveragePosition returns the average finishing position, 0 before any match
+func (p *Profile) AveragePosition() float64 {
+	if p.GamesPlayed == 0 {
+		return 0
+	}
+	return float64(p.TotalPositions) / float64(p.GamesPlayed)
+}
+
+func (p *Profile) WinRate() float64 {
+	if p.GamesPlayed == 0 {
+		return 0
+	}
+	return float64(p.Wins) / float64(p.GamesPlayed)
+}
+
+func (p *Profile) TotalDishes() int {
+	total := 0
+	for _, n := range p.Dishes {
+		total += n
+	}
+	return total
+}
+
+// FavouriteRecipe returns the recipe made the most, ties go to the smallest
+// ID so the result is stable. It returns "" before any dish was made.
+func (p *Profile) FavouriteRecipe() (string, int) {
+	best, count := "", 0
+	for id, n := range p.Dishes {
+		if n > count || (n == count && id < best) {
+			best, count = id, n
+		}
+	}
+	return best, count
+}
@@ -0,0 +1,69 @@
+package puzzle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the puzzle progress
+const Key = "puzzles.json"
+
+// Progress keeps the best stars of each solved puzzle
+type Progress struct {
+	Stars map[string]int `json:"stars"` // Puzzle ID -> best stars
+}
+
+func NewProgress() *Progress {
+	return &Progress{
+		Stars: make(map[string]int),
+	}
+}
+
+// LoadProgress reads the saved progress, a missing one gives an empty one
+func LoadProgress(store storage.Store) (*Progress, error) {
+	p := NewProgress()
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return NewProgress(), fmt.Errorf("read puzzle progress: %w", err)
+	}
+	if p.Stars == nil {
+		p.Stars = make(map[string]int)
+	}
+	return p, nil
+}
+
+func (p *Progress) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
+
+// Record keeps the stars of a solve if they beat the best ones, it reports
+// whether they did
+func (p *Progress) Record(id string, stars int) bool {
+	if stars <= p.Stars[id] {
+		return false
+	}
+	p.Stars[id] = stars
+	return true
+}
+
+// Total returns the stars earned over every puzzle
+func (p *Progress) Total() int {
+	n := 0
+	for _, s := range p.Stars {
+		n += s
+	}
+	return n
+}
@@ -0,0 +1,317 @@
+// Package puzzle reads puzzles: a preset table and hands with a goal to
+// reach in a number of plays. The opponents hold their cards and pass, they
+// are only there to be targeted.
+package puzzle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/thanhfphan/ebitengj2025/assets/configs"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// Goal types
+const (
+	GoalDishes = "dishes" // Make Count dishes
+	GoalFinish = "finish" // Empty the hand with none of your cards left on the table
+)
+
+var errOver = errors.New("the puzzle is over")
+
+// Puzzle is one preset table
+type Puzzle struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Deck        string           `json:"deck"`
+	Ruleset     string           `json:"ruleset,omitempty"`
+	Seed        int64            `json:"seed,omitempty"` // Seeds random effects
+	Hand        []string         `json:"hand"`
+	Opponents   [][]string       `json:"opponents"`       // Hand of each opponent
+	Table       []card.Placement `json:"table,omitempty"` // Player 0 is the player, 1 the first opponent
+	Goal        Goal             `json:"goal"`
+	Stars       Stars            `json:"stars"`
+	Solution    []Move           `json:"solution"` // Checked to earn three stars when loading
+}
+
+// Goal must be reached within Plays cards played
+type Goal struct {
+	Type  string `json:"type"`
+	Count int    `json:"count,omitempty"` // Dishes for GoalDishes
+	Plays int    `json:"plays"`
+}
+
+// Text describes the goal to the player
+func (g Goal) Text() string {
+	if g.Type == GoalFinish {
+		return fmt.Sprintf("Empty your hand in %d plays", g.Plays)
+	}
+	return fmt.Sprintf("Make %d dishes in %d plays", g.Count, g.Plays)
+}
+
+// Stars are earned by solving the puzzle in at most this many plays, a
+// solve within the goal always earns one
+type Stars struct {
+	Three int `json:"three"`
+	Two   int `json:"two"`
+}
+
+// Move plays the first card of the hand with the given name
+type Move struct {
+	Card      string `json:"card"`
+	Opponent  int    `json:"opponent,omitempty"`   // 1 for the first opponent
+	TableCard string `json:"table_card,omitempty"` // The oldest table card with this name
+}
+
+// List returns every puzzle, ordered by file name
+func List() ([]*Puzzle, error) {
+	files, err := fs.Glob(configs.PuzzlesFS, "puzzles/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var puzzles []*Puzzle
+	for _, name := range files {
+		p, err := read(name)
+		if err != nil {
+			return nil, err
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles, nil
+}
+
+func read(name string) (*Puzzle, error) {
+	data, err := configs.PuzzlesFS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Puzzle{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	stars, err := p.Verify(p.Solution)
+	if err != nil {
+		return nil, fmt.Errorf("%s: solution: %w", path.Base(name), err)
+	}
+	if stars < 3 {
+		return nil, fmt.Errorf("%s: the solution only earns %d stars", path.Base(name), stars)
+	}
+	return p, nil
+}
+
+// Validate checks a puzzle statically and reports every problem found
+func (p *Puzzle) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if p.ID == "" {
+		fail("puzzle %q has no id", p.Name)
+	}
+	if len(p.Hand) == 0 {
+		fail("puzzle %s: the hand is empty", p.ID)
+	}
+	if len(p.Opponents) == 0 || len(p.Opponents) > 5 {
+		fail("puzzle %s needs 1 to 5 opponents, got %d", p.ID, len(p.Opponents))
+	}
+
+	switch p.Goal.Type {
+	case GoalDishes:
+		if p.Goal.Count < 1 {
+			fail("puzzle %s: %s needs a count", p.ID, p.Goal.Type)
+		}
+	case GoalFinish:
+	default:
+		fail("puzzle %s: unknown goal %q", p.ID, p.Goal.Type)
+	}
+	if p.Goal.Plays < 1 {
+		fail("puzzle %s: the goal needs plays", p.ID)
+	}
+	if p.Stars.Three < 1 || p.Stars.Three > p.Stars.Two || p.Stars.Two > p.Goal.Plays {
+		fail("puzzle %s: stars need 1 <= three <= two <= %d plays", p.ID, p.Goal.Plays)
+	}
+	if len(p.Solution) == 0 {
+		fail("puzzle %s has no solution", p.ID)
+	}
+	return errors.Join(errs...)
+}
+
+// Attempt is a puzzle being played
+type Attempt struct {
+	Puzzle  *Puzzle
+	Engine  *rules.Engine
+	Players []*entity.Player // The player first, then the opponents
+	Plays   int
+}
+
+// Start sets the puzzle up on the engine
+func (p *Puzzle) Start(e *rules.Engine) (*Attempt, error) {
+	e.Cards.SetRandState(rng.State{Seed: p.Seed})
+	ruleset := p.Ruleset
+	if ruleset == "" {
+		ruleset = card.RulesetStandard
+	}
+	if err := e.Cards.LoadDeckRuleset(p.Deck, ruleset); err != nil {
+		return nil, err
+	}
+
+	players := []*entity.Player{entity.NewPlayer("You", entity.TypePlayer)}
+	for i := range p.Opponents {
+		players = append(players, entity.NewPlayer(fmt.Sprintf("Chef %d", i+1), entity.TypeBot))
+	}
+	hands := append([][]string{p.Hand}, p.Opponents...)
+	if err := e.Cards.DealScripted(players, hands, p.Table); err != nil {
+		return nil, err
+	}
+	e.StartDealt(players)
+	return &Attempt{Puzzle: p, Engine: e, Players: players}, nil
+}
+
+func (a *Attempt) Player() *entity.Player {
+	return a.Players[0]
+}
+
+// Play plays a card of the player, then the opponents pass until it is the
+// player's turn again
+func (a *Attempt) Play(cardID string, target entity.Target) error {
+	if a.Done() {
+		return errOver
+	}
+	if err := a.Engine.PlayCard(a.Player().ID, cardID, target); err != nil {
+		return err
+	}
+	a.Plays++
+
+	for range len(a.Players) {
+		current := a.Engine.Turns.Current()
+		if current == nil || current.ID == a.Player().ID || a.Engine.IsOver() {
+			break
+		}
+		if err := a.Engine.Pass(current.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dishes made by the player
+func (a *Attempt) Dishes() int {
+	return len(a.Engine.Dishes[a.Player().ID])
+}
+
+func (a *Attempt) Solved() bool {
+	p := a.Player()
+	switch a.Puzzle.Goal.Type {
+	case GoalDishes:
+		return a.Dishes() >= a.Puzzle.Goal.Count
+	case GoalFinish:
+		return len(p.Hand) == 0 && !a.Engine.Cards.TableStack.HasPlayerCards(p.ID)
+	}
+	return false
+}
+
+// Failed is true once the goal cannot be reached anymore
+func (a *Attempt) Failed() bool {
+	if a.Solved() {
+		return false
+	}
+	if a.Plays >= a.Puzzle.Goal.Plays || a.Engine.IsOver() {
+		return true
+	}
+	current := a.Engine.Turns.Current()
+	if current == nil || current.ID != a.Player().ID || current.Finished {
+		return true
+	}
+	for id := range a.Player().Hand {
+		if len(a.Engine.LegalTargets(a.Player().ID, id)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Attempt) Done() bool {
+	return a.Solved() || a.Failed()
+}
+
+// Stars earned so far, 0 until solved
+func (a *Attempt) Stars() int {
+	switch {
+	case !a.Solved():
+		return 0
+	case a.Plays <= a.Puzzle.Stars.Three:
+		return 3
+	case a.Plays <= a.Puzzle.Stars.Two:
+		return 2
+	}
+	return 1
+}
+
+// Verify plays the moves on an engine of its own and returns the stars they
+// earn, an error if they are not legal or do not solve the puzzle
+func (p *Puzzle) Verify(moves []Move) (int, error) {
+	a, err := p.Start(rules.NewEngine(card.NewManager(), rules.NewTurnManager()))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, m := range moves {
+		c, target, err := a.resolve(m)
+		if err == nil {
+			err = a.Play(c.ID, target)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("move %d: %w", i+1, err)
+		}
+	}
+	if !a.Solved() {
+		return 0, errors.New("the moves do not reach the goal")
+	}
+	return a.Stars(), nil
+}
+
+func (a *Attempt) resolve(m Move) (*entity.Card, entity.Target, error) {
+	var c *entity.Card
+	for _, id := range a.Player().OrderHand {
+		if hc := a.Player().GetCard(id); hc != nil && hc.Name == m.Card {
+			c = hc
+			break
+		}
+	}
+	if c == nil {
+		return nil, entity.Target{}, fmt.Errorf("no %s in hand", m.Card)
+	}
+
+	var target entity.Target
+	if m.Opponent > 0 {
+		if m.Opponent >= len(a.Players) {
+			return nil, target, fmt.Errorf("no opponent %d", m.Opponent)
+		}
+		target.PlayerID = a.Players[m.Opponent].ID
+	}
+	if m.TableCard != "" {
+		for _, tc := range a.Engine.Cards.TableStack.GetAllCardsInOrder() {
+			if tc.Name == m.TableCard {
+				target.CardID = tc.ID
+				break
+			}
+		}
+		if target.CardID == "" {
+			return nil, target, fmt.Errorf("no %s on the table", m.TableCard)
+		}
+	}
+	return c, target, nil
+}
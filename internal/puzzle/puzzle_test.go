@@ -0,0 +1,112 @@
+package puzzle
+
+import (
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+func TestAttemptStars(t *testing.T) {
+	p := &Puzzle{
+		Goal:  Goal{Type: GoalFinish, Plays: 5},
+		Stars: Stars{Three: 2, Two: 4},
+	}
+
+	tests := []struct {
+		name   string
+		plays  int
+		solved bool
+		want   int
+	}{
+		{name: "unsolved", plays: 1, want: 0},
+		{name: "best solve", plays: 1, solved: true, want: 3},
+		{name: "three stars", plays: 2, solved: true, want: 3},
+		{name: "two stars", plays: 3, solved: true, want: 2},
+		{name: "last two stars", plays: 4, solved: true, want: 2},
+		{name: "within the goal", plays: 5, solved: true, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The player finishes a finish goal with an empty hand
+			player := entity.NewPlayer("You", entity.TypePlayer)
+			if !tt.solved {
+				player.AddCard(entity.NewCard("Beef", entity.CardTypeIngredient))
+			}
+			a := &Attempt{
+				Puzzle:  p,
+				Engine:  rules.NewEngine(card.NewManager(), rules.NewTurnManager()),
+				Players: []*entity.Player{player},
+				Plays:   tt.plays,
+			}
+			if got := a.Stars(); got != tt.want {
+				t.Errorf("Stars() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	puzzles, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(puzzles) == 0 {
+		t.Fatal("no puzzles")
+	}
+
+	for _, p := range puzzles {
+		t.Run(p.ID, func(t *testing.T) {
+			tests := []struct {
+				name  string
+				moves []Move
+				stars int // 0 when the moves must be refused
+			}{
+				{name: "solution", moves: p.Solution, stars: 3},
+				{name: "one move short", moves: p.Solution[:len(p.Solution)-1]},
+				{name: "card not in hand", moves: []Move{{Card: "Durian"}}},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					stars, err := p.Verify(tt.moves)
+					if tt.stars == 0 {
+						if err == nil {
+							t.Errorf("Verify() = %d stars, want an error", stars)
+						}
+						return
+					}
+					if err != nil || stars != tt.stars {
+						t.Errorf("Verify() = %d, %v, want %d stars", stars, err, tt.stars)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestProgressRecord(t *testing.T) {
+	p := NewProgress()
+	steps := []struct {
+		id    string
+		stars int
+		want  bool
+		total int
+	}{
+		{id: "a", stars: 1, want: true, total: 1},
+		{id: "a", stars: 1, want: false, total: 1},
+		{id: "a", stars: 3, want: true, total: 3},
+		{id: "a", stars: 2, want: false, total: 3},
+		{id: "b", stars: 2, want: true, total: 5},
+	}
+
+	for i, s := range steps {
+		if got := p.Record(s.id, s.stars); got != s.want {
+			t.Errorf("step %d: Record(%s, %d) = %v, want %v", i, s.id, s.stars, got, s.want)
+		}
+		if got := p.Total(); got != s.total {
+			t.Errorf("step %d: Total() = %d, want %d", i, got, s.total)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package rng
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	mrand "math/rand"
+)
+
+var _ mrand.Source64 = (*Source)(nil)
+
+// Source is a math/rand source whose state can be saved. It remembers its
+// seed and how many values were drawn, restoring replays the draws.
+type Source struct {
+	seed  int64
+	calls uint64
+	src   mrand.Source64
+}
+
+// State is the saved form of a Source
+type State struct {
+	Seed  int64  `json:"seed"`
+	Calls uint64 `json:"calls"`
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{
+		seed: seed,
+		src:  mrand.NewSource(seed).(mrand.Source64),
+	}
+}
+
+// RandomSeed returns a seed from the system's secure random generator
+func RandomSeed() int64 {
+	var seed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return seed
+}
+
+// New returns a rand.Rand backed by a Source, and the Source itself
+func New(seed int64) (*mrand.Rand, *Source) {
+	src := NewSource(seed)
+	return mrand.New(src), src
+}
+
+func (s *Source) Int63() int64 {
+	s.calls++
+	return s.src.Int63()
+}
+
+func (s *Source) Uint64() uint64 {
+	s.calls++
+	return s.src.Uint64()
+}
+
+func (s *Source) Seed(seed int64) {
+	s.seed = seed
+	s.calls = 0
+	s.src.Seed(seed)
+}
+
+func (s *Source) State() State {
+	return State{Seed: s.seed, Calls: s.calls}
+}
+
+// Restore puts the source back into a saved state
+func (s *Source) Restore(st State) {
+	s.Seed(st.Seed)
+	for range st.Calls {
+		s.src.Uint64()
+	}
+	s.calls = st.Calls
+}
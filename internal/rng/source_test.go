@@ -0,0 +1,54 @@
+package rng
+
+import (
+	mrand "math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name  string
+		seed  int64
+		drawn int // Values drawn before saving
+	}{
+		{name: "fresh", seed: 1, drawn: 0},
+		{name: "some draws", seed: 1, drawn: 17},
+		{name: "negative seed", seed: -42, drawn: 100},
+	}
+
+	// draw mixes the calls a rand.Rand makes on its source
+	draw := func(r *mrand.Rand) []int {
+		var values []int
+		for i := range 10 {
+			values = append(values, r.Intn(1000+i))
+			values = append(values, int(r.Float64()*1000))
+			values = append(values, r.Perm(3)...)
+		}
+		return values
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, src := New(tt.seed)
+			for range tt.drawn {
+				r.Int63()
+			}
+			st := src.State()
+			if st.Seed != tt.seed || st.Calls != uint64(tt.drawn) {
+				t.Errorf("State() = %+v, want seed %d after %d calls", st, tt.seed, tt.drawn)
+			}
+			want := draw(r)
+
+			restoredRand, restored := New(tt.seed + 1)
+			restoredRand.Uint64()
+			restored.Restore(st)
+			if got := restored.State(); got != st {
+				t.Errorf("restored State() = %+v, want %+v", got, st)
+			}
+			if got := draw(restoredRand); !slices.Equal(got, want) {
+				t.Errorf("restored source draws %v, want %v", got, want)
+			}
+		})
+	}
+}
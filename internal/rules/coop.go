@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+var errNotCoop = errors.New("signals are only given in a co-op match")
+
+// Coop is the cooperative ruleset. Every seat works toward one target of
+// dishes and the table finishes together: as soon as the target is cooked,
+// or when nobody can play anymore. Finish positions mean nothing, on_finish
+// effects do not run.
+type Coop struct {
+	Target     int // Dishes the table must cook before the hands run out
+	MaxSignals int // Signals the table starts with, a dish gives one back
+
+	Signals   int               // Signals the table has left
+	Signalled map[string]string // PlayerID -> ID of the card they pointed out
+}
+
+func NewCoop(target, signals int) *Coop {
+	return &Coop{
+		Target:     target,
+		MaxSignals: signals,
+		Signals:    signals,
+		Signalled:  make(map[string]string),
+	}
+}
+
+func (c *Coop) reset() {
+	c.Signals = c.MaxSignals
+	c.Signalled = make(map[string]string)
+}
+
+// CoopWon reports whether the table cooked the target
+func (e *Engine) CoopWon() bool {
+	return e.Coop != nil && e.DishesMade >= e.Coop.Target
+}
+
+// TeamScore is the shared score of a co-op table: the points of every seat,
+// and one more for each signal left once the target is cooked
+func (e *Engine) TeamScore() int {
+	n := 0
+	for _, p := range e.Players {
+		n += e.Scores[p.ID]
+	}
+	if e.CoopWon() {
+		n += e.Coop.Signals
+	}
+	return n
+}
+
+// Signal points out an ingredient of the player's hand to the table, which
+// takes the player's turn. A player has one signal standing at a time, a
+// new one replaces it.
+func (e *Engine) Signal(playerID, cardID string) error {
+	if e.Coop == nil {
+		return errNotCoop
+	}
+	if err := e.checkTurn(playerID); err != nil {
+		return err
+	}
+	if e.Coop.Signals == 0 {
+		return errors.New("the table has no signals left")
+	}
+
+	c := e.GetPlayer(playerID).GetCard(cardID)
+	if c == nil || !c.IsIngredient() {
+		return fmt.Errorf("only an ingredient in hand can be signalled: %s", cardID)
+	}
+
+	e.Coop.Signals--
+	e.Coop.Signalled[playerID] = cardID
+	e.emit(entity.Event{Type: entity.EventSignal, PlayerID: playerID, Card: c.Name})
+
+	e.Turns.Next()
+	return nil
+}
+
+// SignalledCard returns the card a player pointed out while it is still in
+// their hand
+func (e *Engine) SignalledCard(playerID string) *entity.Card {
+	if e.Coop == nil {
+		return nil
+	}
+	p := e.GetPlayer(playerID)
+	if p == nil {
+		return nil
+	}
+	return p.GetCard(e.Coop.Signalled[playerID])
+}
+
+// finishTable ends a co-op match for every seat at once
+func (e *Engine) finishTable() {
+	if e.IsOver() || len(e.Players) == 0 {
+		return
+	}
+	e.Turns.MarkFinished(e.Players[0].ID)
+	for i, id := range e.Turns.FinishedOrder() {
+		e.emit(entity.Event{Type: entity.EventFinish, PlayerID: id, Position: i + 1})
+	}
+	e.emit(entity.Event{Type: entity.EventMatchOver})
+}
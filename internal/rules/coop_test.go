@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// newCoopTable is newTable at a co-op table
+func newCoopTable(t *testing.T, target, signals int, hands ...int) (*Engine, []*entity.Player) {
+	t.Helper()
+	e, players := newTable(t, hands...)
+	e.Coop = NewCoop(target, signals)
+	e.StartDealt(players)
+	return e, players
+}
+
+func testRecipe(ingredientID string) *entity.Card {
+	c := entity.NewCard("Recipe", entity.CardTypeRecipe)
+	c.RecipeID = "R_" + ingredientID
+	c.Requirements = []entity.Requirement{{IngredientID: ingredientID}}
+	return c
+}
+
+func TestSignal(t *testing.T) {
+	tests := []struct {
+		name    string
+		coop    bool
+		signals int
+		player  int    // Seat signalling
+		card    string // "hand", "action" or "missing"
+		error   string // Part of the error, empty when the signal is given
+	}{
+		{name: "ingredient", coop: true, signals: 2, card: "hand"},
+		{name: "not co-op", signals: 2, card: "hand", error: "only given in a co-op match"},
+		{name: "out of turn", coop: true, signals: 2, player: 1, card: "hand", error: "not the turn"},
+		{name: "no signals left", coop: true, card: "hand", error: "no signals left"},
+		{name: "action card", coop: true, signals: 2, card: "action", error: "only an ingredient"},
+		{name: "card not in hand", coop: true, signals: 2, card: "missing", error: "only an ingredient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, 2, 2)
+			if tt.coop {
+				e, players = newCoopTable(t, 5, tt.signals, 2, 2)
+			}
+			p := players[tt.player]
+			cardID := p.OrderHand[0]
+			switch tt.card {
+			case "action":
+				action := testAction(onPlay(entity.Op{Type: entity.OpSkip}))
+				p.AddCard(action)
+				cardID = action.ID
+			case "missing":
+				cardID = players[1-tt.player].OrderHand[0]
+			}
+
+			err := e.Signal(p.ID, cardID)
+			if tt.error != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.error) {
+					t.Fatalf("Signal() = %v, want an error about %q", err, tt.error)
+				}
+				if e.Turns.Current().ID != players[0].ID {
+					t.Error("a refused signal took the turn")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Coop.Signals != tt.signals-1 {
+				t.Errorf("%d signals left, want %d", e.Coop.Signals, tt.signals-1)
+			}
+			if c := e.SignalledCard(p.ID); c == nil || c.ID != cardID {
+				t.Errorf("SignalledCard() = %v, want %s", c, cardID)
+			}
+			if e.Turns.Current().ID != players[1].ID {
+				t.Error("the signal did not take the turn")
+			}
+
+			// The signal is forgotten once the card leaves the hand
+			p.RemoveCard(cardID)
+			if c := e.SignalledCard(p.ID); c != nil {
+				t.Errorf("SignalledCard() = %s after the card was played", c.ID)
+			}
+		})
+	}
+}
+
+func TestCoopFinishTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   int
+		scores   []int
+		signals  int
+		over     bool
+		won      bool
+		team     int
+		finished []int
+	}{
+		{name: "target cooked", target: 1, signals: 1, scores: []int{2, 3, 0}, over: true, won: true, team: 7, finished: []int{0, 1, 2}},
+		{name: "target not cooked", target: 2, signals: 1, scores: []int{2, 3, 0}, team: 5, finished: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newCoopTable(t, tt.target, 2, 1, 1, 1)
+			var events []string
+			e.OnEvent = func(ev entity.Event) { events = append(events, ev.Type) }
+			e.Coop.Signals = tt.signals
+
+			// P0 cooks a dish with the recipe P1 left on the table, with
+			// their last card
+			recipe := testRecipe(players[0].GetCard(players[0].OrderHand[0]).IngredientID)
+			e.Cards.TableStack.AddCard(recipe, players[1].ID)
+			if err := e.PlayCard(players[0].ID, players[0].OrderHand[0], entity.Target{}); err != nil {
+				t.Fatal(err)
+			}
+			for i, s := range tt.scores {
+				e.Scores[players[i].ID] = s
+			}
+
+			if e.DishesMade != 1 {
+				t.Fatalf("%d dishes made, want 1", e.DishesMade)
+			}
+			if e.Coop.Signals != tt.signals+1 {
+				t.Errorf("%d signals, want a dish to give one back", e.Coop.Signals)
+			}
+			if e.IsOver() != tt.over || e.CoopWon() != tt.won {
+				t.Errorf("over %v won %v, want %v %v", e.IsOver(), e.CoopWon(), tt.over, tt.won)
+			}
+			if got := e.TeamScore(); got != tt.team {
+				t.Errorf("TeamScore() = %d, want %d", got, tt.team)
+			}
+
+			want := make([]string, 0, len(tt.finished))
+			for _, i := range tt.finished {
+				want = append(want, players[i].ID)
+			}
+			if got := e.Turns.FinishedOrder(); !slices.Equal(got, want) {
+				t.Errorf("finished %v, want %v", got, want)
+			}
+			if over := slices.Contains(events, entity.EventMatchOver); over != tt.over {
+				t.Errorf("match over event sent %v, want %v", over, tt.over)
+			}
+		})
+	}
+}
+
+func TestCoopStalemate(t *testing.T) {
+	tests := []struct {
+		name string
+		hold bool // Whether P1 still holds a card to play
+		over bool
+	}{
+		{name: "someone can play", hold: true},
+		{name: "nobody can play", over: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand := 0
+			if tt.hold {
+				hand = 1
+			}
+			e, players := newCoopTable(t, 5, 2, 1, hand, 0)
+
+			// P0 plays their last card, the empty hands do not finish alone
+			if err := e.PlayCard(players[0].ID, players[0].OrderHand[0], entity.Target{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.IsOver() != tt.over {
+				t.Errorf("IsOver() = %v, want %v", e.IsOver(), tt.over)
+			}
+			if n := len(e.Turns.FinishedOrder()); tt.over && n != len(players) || !tt.over && n != 0 {
+				t.Errorf("%d players finished", n)
+			}
+		})
+	}
+}
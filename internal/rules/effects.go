@@ -0,0 +1,196 @@
+package rules
+
+import (
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// TargetKind tells what the player has to choose when playing a card
+type TargetKind int
+
+const (
+	TargetNone TargetKind = iota
+	TargetOpponent
+	TargetTableCard
+)
+
+// ChosenTarget returns what the player must pick when playing the card,
+// based on the targets of its on_play effects
+func ChosenTarget(c *entity.Card) TargetKind {
+	if c == nil {
+		return TargetNone
+	}
+
+	for _, e := range c.Effects {
+		if e.Trigger != entity.TriggerOnPlay {
+			continue
+		}
+		for _, op := range e.Do {
+			switch op.Target {
+			case entity.TargetOpponent:
+				return TargetOpponent
+			case entity.TargetTableCard:
+				return TargetTableCard
+			}
+		}
+	}
+	return TargetNone
+}
+
+// LegalTargets lists the targets the card can be played on right now. Cards
+// without a target yield a single empty target, an empty result means the
+// card cannot be played.
+func (e *Engine) LegalTargets(playerID, cardID string) []entity.Target {
+	player := e.GetPlayer(playerID)
+	if player == nil {
+		return nil
+	}
+	c := player.GetCard(cardID)
+	if c == nil {
+		return nil
+	}
+
+	switch ChosenTarget(c) {
+	case TargetOpponent:
+		targets := []entity.Target{}
+		for _, p := range e.Players {
+			if p.ID == playerID || e.isFinished(p.ID) {
+				continue
+			}
+			targets = append(targets, entity.Target{PlayerID: p.ID})
+		}
+		return targets
+	case TargetTableCard:
+		targets := []entity.Target{}
+		for _, tc := range e.Cards.TableStack.GetAllCardsInOrder() {
+			targets = append(targets, entity.Target{CardID: tc.ID})
+		}
+		return targets
+	default:
+		return []entity.Target{{}}
+	}
+}
+
+func (e *Engine) isLegalTarget(player *entity.Player, c *entity.Card, target entity.Target) bool {
+	for _, t := range e.LegalTargets(player.ID, c.ID) {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) isFinished(playerID string) bool {
+	p := e.Turns.GetPlayerByID(playerID)
+	return p == nil || p.Finished
+}
+
+// trigger is the situation an effect is evaluated in
+type trigger struct {
+	name     string
+	player   *entity.Player // The acting player, or the one who finished
+	target   entity.Target  // Chosen when the card was played
+	position int            // Finish position, for on_finish
+}
+
+func (e *Engine) runEffects(effects []entity.Effect, t trigger) {
+	for _, effect := range effects {
+		if effect.Trigger != t.name || !e.conditionsHold(effect.Conditions, t) {
+			continue
+		}
+		for _, op := range effect.Do {
+			e.applyOp(op, t)
+		}
+	}
+}
+
+func (e *Engine) conditionsHold(conds []entity.Condition, t trigger) bool {
+	for _, c := range conds {
+		var ok bool
+		switch c.Type {
+		case entity.CondHandSizeAtMost:
+			ok = len(t.player.Hand) <= c.Value
+		case entity.CondHandSizeAtLeast:
+			ok = len(t.player.Hand) >= c.Value
+		case entity.CondTableSizeAtLeast:
+			ok = len(e.Cards.TableStack.GetAllCardsInOrder()) >= c.Value
+		case entity.CondFinishPosition:
+			ok = t.position == c.Value
+		case entity.CondFirstDish:
+			ok = e.DishesMade == 1
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Engine) applyOp(op entity.Op, t trigger) {
+	amount := max(op.Amount, 1)
+
+	switch op.Type {
+	case entity.OpExtraTurn:
+		e.extraTurn = true
+	case entity.OpSkip:
+		e.Turns.SkipNext()
+	case entity.OpReclaim:
+		_ = e.Cards.ReturnToOwner(t.target.CardID, e.Players)
+	}
+
+	for _, p := range e.opTargets(op.Target, t) {
+		switch op.Type {
+		case entity.OpDraw:
+			e.Cards.DrawCards(p, amount)
+		case entity.OpDiscard:
+			e.Cards.DiscardRandomCards(p, amount)
+		case entity.OpSteal:
+			e.Cards.TakeRandomCards(p, t.player, amount)
+		case entity.OpSwap:
+			e.Cards.SwapRandomCards(t.player, p)
+		case entity.OpScore:
+			e.Scores[p.ID] += op.Amount
+		}
+	}
+}
+
+// opTargets resolves the target of an op to players
+func (e *Engine) opTargets(target string, t trigger) []*entity.Player {
+	switch target {
+	case "", entity.TargetSelf:
+		return []*entity.Player{t.player}
+	case entity.TargetOpponent:
+		if p := e.GetPlayer(t.target.PlayerID); p != nil {
+			return []*entity.Player{p}
+		}
+	case entity.TargetNext:
+		if p := e.nextPlayer(t.player); p != nil {
+			return []*entity.Player{p}
+		}
+	case entity.TargetAllOpponents:
+		var result []*entity.Player
+		for _, p := range e.Players {
+			if p != t.player && !e.isFinished(p.ID) {
+				result = append(result, p)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// nextPlayer returns the first unfinished player seated after the given one
+func (e *Engine) nextPlayer(player *entity.Player) *entity.Player {
+	seat := 0
+	for i, p := range e.Players {
+		if p == player {
+			seat = i
+		}
+	}
+	for i := 1; i < len(e.Players); i++ {
+		p := e.Players[(seat+i)%len(e.Players)]
+		if !e.isFinished(p.ID) {
+			return p
+		}
+	}
+	return nil
+}
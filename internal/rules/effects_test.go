@@ -0,0 +1,245 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// newTable seats players holding the given numbers of ingredients that
+// make no dish together. The deck effects are left out so tests only see
+// the cards they set up.
+func newTable(t *testing.T, hands ...int) (*Engine, []*entity.Player) {
+	t.Helper()
+	e := NewEngine(card.NewManager(), NewTurnManager())
+	e.Cards.DeckEffects = nil
+
+	var players []*entity.Player
+	for i, n := range hands {
+		p := entity.NewPlayer(fmt.Sprintf("P%d", i), entity.TypePlayer)
+		for j := range n {
+			p.AddCard(testIngredient(fmt.Sprintf("I_%d_%d", i, j)))
+		}
+		players = append(players, p)
+	}
+	e.StartDealt(players)
+	return e, players
+}
+
+func testIngredient(id string) *entity.Card {
+	c := entity.NewCard(id, entity.CardTypeIngredient)
+	c.IngredientID = id
+	return c
+}
+
+func testAction(effects ...entity.Effect) *entity.Card {
+	c := entity.NewCard("Action", entity.CardTypeAction)
+	c.Effects = effects
+	return c
+}
+
+func onPlay(ops ...entity.Op) entity.Effect {
+	return entity.Effect{Trigger: entity.TriggerOnPlay, Do: ops}
+}
+
+func TestActionEffects(t *testing.T) {
+	tests := []struct {
+		name    string
+		effect  entity.Effect
+		target  int  // Seat of the opponent to target, -1 for none
+		onTable bool // Whether P1 has a card on the table to target
+
+		hands   []int          // Cards in hand after the play
+		scores  map[string]int // By player name
+		current int            // Seat to play next
+	}{
+		{
+			name:    "draw",
+			effect:  onPlay(entity.Op{Type: entity.OpDraw, Amount: 2}),
+			target:  -1,
+			hands:   []int{4, 3, 3},
+			current: 1,
+		},
+		{
+			name:    "draw more than the discard pile holds",
+			effect:  onPlay(entity.Op{Type: entity.OpDraw, Amount: 9}),
+			target:  -1,
+			hands:   []int{5, 3, 3},
+			current: 1,
+		},
+		{
+			name:    "discard of the next player",
+			effect:  onPlay(entity.Op{Type: entity.OpDiscard, Target: entity.TargetNext}),
+			target:  -1,
+			hands:   []int{2, 2, 3},
+			current: 1,
+		},
+		{
+			name:    "steal from a chosen opponent",
+			effect:  onPlay(entity.Op{Type: entity.OpSteal, Amount: 2, Target: entity.TargetOpponent}),
+			target:  2,
+			hands:   []int{4, 3, 1},
+			current: 1,
+		},
+		{
+			name:    "swap",
+			effect:  onPlay(entity.Op{Type: entity.OpSwap, Target: entity.TargetOpponent}),
+			target:  1,
+			hands:   []int{2, 3, 3},
+			current: 1,
+		},
+		{
+			name:    "score of all opponents",
+			effect:  onPlay(entity.Op{Type: entity.OpScore, Amount: -2, Target: entity.TargetAllOpponents}),
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			scores:  map[string]int{"P1": -2, "P2": -2},
+			current: 1,
+		},
+		{
+			name:    "extra turn",
+			effect:  onPlay(entity.Op{Type: entity.OpExtraTurn}),
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			current: 0,
+		},
+		{
+			name:    "skip",
+			effect:  onPlay(entity.Op{Type: entity.OpSkip}),
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			current: 2,
+		},
+		{
+			name:    "reclaim",
+			effect:  onPlay(entity.Op{Type: entity.OpReclaim, Target: entity.TargetTableCard}),
+			onTable: true,
+			target:  -1,
+			hands:   []int{2, 4, 3},
+			current: 1,
+		},
+		{
+			name: "condition holds",
+			effect: entity.Effect{
+				Trigger:    entity.TriggerOnPlay,
+				Conditions: []entity.Condition{{Type: entity.CondHandSizeAtLeast, Value: 2}},
+				Do:         []entity.Op{{Type: entity.OpScore, Amount: 3}},
+			},
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			scores:  map[string]int{"P0": 3},
+			current: 1,
+		},
+		{
+			name: "condition fails",
+			effect: entity.Effect{
+				Trigger:    entity.TriggerOnPlay,
+				Conditions: []entity.Condition{{Type: entity.CondHandSizeAtMost, Value: 1}},
+				Do:         []entity.Op{{Type: entity.OpScore, Amount: 3}},
+			},
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			current: 1,
+		},
+		{
+			name: "other trigger",
+			effect: entity.Effect{
+				Trigger: entity.TriggerOnDishMade,
+				Do:      []entity.Op{{Type: entity.OpScore, Amount: 3}},
+			},
+			target:  -1,
+			hands:   []int{2, 3, 3},
+			current: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, 2, 3, 3)
+			e.Cards.DiscardPile = []*entity.Card{testIngredient("D1"), testIngredient("D2"), testIngredient("D3")}
+			action := testAction(tt.effect)
+			players[0].AddCard(action)
+
+			var target entity.Target
+			if tt.target >= 0 {
+				target.PlayerID = players[tt.target].ID
+			}
+			if tt.onTable {
+				onTable := testIngredient("T")
+				e.Cards.TableStack.AddCard(onTable, players[1].ID)
+				target.CardID = onTable.ID
+			}
+
+			if err := e.PlayCard(players[0].ID, action.ID, target); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, p := range players {
+				if len(p.Hand) != tt.hands[i] {
+					t.Errorf("%s holds %d cards, want %d", p.Name, len(p.Hand), tt.hands[i])
+				}
+				if p.GetCard(action.ID) != nil {
+					t.Errorf("%s got the action card back", p.Name)
+				}
+			}
+			scores := make(map[string]int)
+			for _, p := range players {
+				if s := e.Score(p.ID); s != 0 {
+					scores[p.Name] = s
+				}
+			}
+			if tt.scores == nil {
+				tt.scores = map[string]int{}
+			}
+			if !maps.Equal(scores, tt.scores) {
+				t.Errorf("scores = %v, want %v", scores, tt.scores)
+			}
+			if got := e.Turns.Current().ID; got != players[tt.current].ID {
+				t.Errorf("%s plays next, want %s", e.GetPlayer(got).Name, players[tt.current].Name)
+			}
+		})
+	}
+}
+
+func TestLegalTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		effect   entity.Effect
+		finished bool // Whether P2 finished
+		onTable  int  // Cards on the table
+		want     int
+	}{
+		{name: "no target", effect: onPlay(entity.Op{Type: entity.OpSkip}), want: 1},
+		{name: "opponents", effect: onPlay(entity.Op{Type: entity.OpSteal, Target: entity.TargetOpponent}), want: 2},
+		{name: "finished opponent", effect: onPlay(entity.Op{Type: entity.OpSteal, Target: entity.TargetOpponent}), finished: true, want: 1},
+		{name: "table cards", effect: onPlay(entity.Op{Type: entity.OpReclaim, Target: entity.TargetTableCard}), onTable: 2, want: 2},
+		{name: "empty table", effect: onPlay(entity.Op{Type: entity.OpReclaim, Target: entity.TargetTableCard}), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, 1, 1, 1)
+			action := testAction(tt.effect)
+			players[0].AddCard(action)
+			if tt.finished {
+				e.Turns.MarkFinished(players[2].ID)
+			}
+			for i := range tt.onTable {
+				e.Cards.TableStack.AddCard(testIngredient(fmt.Sprint("T", i)), players[1].ID)
+			}
+
+			targets := e.LegalTargets(players[0].ID, action.ID)
+			if len(targets) != tt.want {
+				t.Errorf("got %d targets %v, want %d", len(targets), targets, tt.want)
+			}
+			for _, target := range targets {
+				if target.PlayerID == players[0].ID {
+					t.Error("the player can target themselves")
+				}
+			}
+		})
+	}
+}
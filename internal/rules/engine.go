@@ -0,0 +1,260 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// Engine resolves plays and passes on top of the card and turn managers. It
+// has no dependency on rendering so it can run headless.
+type Engine struct {
+	Cards   *card.Manager
+	Turns   *TurnManager
+	Players []*entity.Player
+
+	Scores     map[string]int      // PlayerID -> points from effects
+	Dishes     map[string][]string // PlayerID -> recipe IDs of the dishes they completed
+	DishesMade int
+	Coop       *Coop   // Set before starting a co-op match, nil for a regular one
+	Orders     *Orders // Set before starting a restaurant match, nil for a regular one
+
+	OnEvent func(e entity.Event) // Optional listener of what happens in the match
+
+	extraTurn bool
+}
+
+func NewEngine(cards *card.Manager, turns *TurnManager) *Engine {
+	return &Engine{
+		Cards:   cards,
+		Turns:   turns,
+		Players: []*entity.Player{},
+		Scores:  make(map[string]int),
+		Dishes:  make(map[string][]string),
+	}
+}
+
+// Start seats the players in order and deals the loaded deck to them
+func (e *Engine) Start(players []*entity.Player) {
+	e.StartDealt(players)
+	e.Cards.DealHands(players)
+}
+
+// StartDealt seats players who already hold their cards, such as from a
+// scripted deal
+func (e *Engine) StartDealt(players []*entity.Player) {
+	e.Players = players
+	e.Scores = make(map[string]int)
+	e.Dishes = make(map[string][]string)
+	e.DishesMade = 0
+	e.extraTurn = false
+	e.Turns.Reset()
+	e.Turns.SetShared(e.Coop != nil)
+	if e.Coop != nil {
+		e.Coop.reset()
+	}
+	for _, p := range players {
+		e.Turns.AddPlayer(p.ID, p.IsBot())
+	}
+	if e.Orders != nil {
+		e.Orders.reset()
+	}
+}
+
+func (e *Engine) emit(ev entity.Event) {
+	if e.OnEvent != nil {
+		e.OnEvent(ev)
+	}
+}
+
+func (e *Engine) GetPlayer(id string) *entity.Player {
+	for _, p := range e.Players {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// IsOver reports whether every player has finished
+func (e *Engine) IsOver() bool {
+	return len(e.Players) > 0 && len(e.Turns.FinishedOrder()) == len(e.Players)
+}
+
+func (e *Engine) checkTurn(playerID string) error {
+	current := e.Turns.Current()
+	if current == nil || current.ID != playerID || current.Finished {
+		return fmt.Errorf("not the turn of player %s", playerID)
+	}
+	return nil
+}
+
+func (e *Engine) Pass(playerID string) error {
+	if err := e.checkTurn(playerID); err != nil {
+		return err
+	}
+
+	if err := e.Turns.Pass(playerID); err != nil {
+		return err
+	}
+	e.emit(entity.Event{Type: entity.EventPass, PlayerID: playerID})
+	e.tickOrders()
+
+	e.Turns.Next()
+	return nil
+}
+
+// PlayCard puts a card of the current player into play. Action cards are
+// discarded once their effects are resolved, other cards go to the table and
+// may complete dishes.
+func (e *Engine) PlayCard(playerID, cardID string, target entity.Target) error {
+	if err := e.checkTurn(playerID); err != nil {
+		return err
+	}
+
+	player := e.GetPlayer(playerID)
+	played := player.GetCard(cardID)
+	if played == nil {
+		return fmt.Errorf("invalid card id: %s", cardID)
+	}
+	if !e.isLegalTarget(player, played, target) {
+		return fmt.Errorf("invalid target for %s: %+v", played.Name, target)
+	}
+
+	isAction := played.Type == entity.CardTypeAction
+	var err error
+	if isAction {
+		_, err = e.Cards.TakeActionCard(player, cardID)
+	} else {
+		err = e.Cards.PlayCard(player, cardID)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Turns.MarkAllUnpassed()
+	e.emit(entity.Event{Type: entity.EventCardPlayed, PlayerID: playerID, Card: played.Name})
+
+	onPlay := trigger{name: entity.TriggerOnPlay, player: player, target: target}
+	e.runEffects(played.Effects, onPlay)
+	e.runEffects(e.Cards.DeckEffects, onPlay)
+	if isAction {
+		// Only discarded now so its own effects cannot draw it back
+		e.Cards.Discard(played)
+	}
+	e.updateHands()
+
+	dishes := 0
+	for dish := e.Cards.TryMakeDish(); dish != nil; dish = e.Cards.TryMakeDish() {
+		dishes++
+		e.DishesMade++
+		e.Dishes[playerID] = append(e.Dishes[playerID], dish.Recipe.RecipeID)
+		e.emit(entity.Event{Type: entity.EventDishMade, PlayerID: playerID, RecipeID: dish.Recipe.RecipeID, Card: dish.Recipe.Name})
+		if e.Coop != nil {
+			e.Coop.Signals = min(e.Coop.Signals+1, e.Coop.MaxSignals)
+		}
+		e.serveOrder(playerID, dish.Recipe)
+
+		onDish := trigger{name: entity.TriggerOnDishMade, player: player}
+		e.runEffects(dish.Recipe.Effects, onDish)
+		for _, ing := range dish.Ingredients {
+			e.runEffects(ing.Effects, onDish)
+		}
+		e.runEffects(e.Cards.DeckEffects, onDish)
+		e.updateHands()
+	}
+
+	e.emit(entity.Event{Type: entity.EventPlayResolved, PlayerID: playerID, Count: dishes})
+	if e.CoopWon() {
+		e.finishTable()
+	}
+	e.checkStalemate()
+	e.tickOrders()
+
+	extraTurn := e.extraTurn
+	e.extraTurn = false
+	if len(player.Hand) == 0 || !(dishes > 0 || extraTurn) {
+		e.Turns.Next()
+	}
+
+	return nil
+}
+
+// Score returns the points a player got from effects
+func (e *Engine) Score(playerID string) int {
+	return e.Scores[playerID]
+}
+
+// updateHands syncs the turn flags with the hands, a player with no cards
+// in hand and none left on the table is finished. At a co-op table players
+// only finish together.
+func (e *Engine) updateHands() {
+	for _, p := range e.Players {
+		if len(p.Hand) > 0 {
+			e.Turns.MarkHandRefilled(p.ID)
+			continue
+		}
+		e.Turns.MarkHandEmpty(p.ID)
+		if e.Coop == nil && !e.Cards.TableStack.HasPlayerCards(p.ID) {
+			e.markFinished(p)
+		}
+	}
+}
+
+func (e *Engine) markFinished(p *entity.Player) {
+	if e.isFinished(p.ID) {
+		return
+	}
+
+	e.Turns.MarkFinished(p.ID)
+	position := len(e.Turns.FinishedOrder())
+	e.runEffects(e.Cards.DeckEffects, trigger{
+		name:     entity.TriggerOnFinish,
+		player:   p,
+		position: position,
+	})
+	e.emit(entity.Event{Type: entity.EventFinish, PlayerID: p.ID, Position: position})
+	if e.IsOver() {
+		e.emit(entity.Event{Type: entity.EventMatchOver})
+	}
+}
+
+// checkStalemate ends the match when nobody left has a card to play, such as
+// an action card with no opponent left to target. The remaining players
+// finish by how few of their cards are on the table, a co-op table runs out
+// of cards together.
+func (e *Engine) checkStalemate() {
+	var stuck []*entity.Player
+	for _, p := range e.Players {
+		if e.isFinished(p.ID) {
+			continue
+		}
+		if e.canPlay(p) {
+			return
+		}
+		stuck = append(stuck, p)
+	}
+	if e.Coop != nil {
+		e.finishTable()
+		return
+	}
+
+	slices.SortStableFunc(stuck, func(a, b *entity.Player) int {
+		return len(e.Cards.TableStack.GetCardsByPlayer(a.ID)) - len(e.Cards.TableStack.GetCardsByPlayer(b.ID))
+	})
+	for _, p := range stuck {
+		e.markFinished(p)
+	}
+}
+
+// canPlay reports whether the player has a card with a legal target
+func (e *Engine) canPlay(p *entity.Player) bool {
+	for id := range p.Hand {
+		if len(e.LegalTargets(p.ID, id)) > 0 {
+			return true
+		}
+	}
+	return false
+}
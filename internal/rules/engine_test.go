@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+func TestCheckStalemate(t *testing.T) {
+	steal := onPlay(entity.Op{Type: entity.OpSteal, Target: entity.TargetOpponent})
+	reclaim := onPlay(entity.Op{Type: entity.OpReclaim, Target: entity.TargetTableCard})
+
+	tests := []struct {
+		name     string
+		hands    [][]entity.Effect // Action cards of each player, nil for an empty hand
+		onTable  []int             // Cards of each player on the table
+		finished []int             // Seats finished before the check
+		want     []int             // Seats finished after the check, in order
+	}{
+		{
+			name:    "someone can play",
+			hands:   [][]entity.Effect{{steal}, nil},
+			onTable: []int{0, 1},
+			want:    []int{},
+		},
+		{
+			name:     "no opponent left to target",
+			hands:    [][]entity.Effect{nil, {steal}},
+			onTable:  []int{0, 0},
+			finished: []int{0},
+			want:     []int{0, 1},
+		},
+		{
+			name:    "nothing left to reclaim",
+			hands:   [][]entity.Effect{{reclaim}, {reclaim}},
+			onTable: []int{0, 0},
+			want:    []int{0, 1},
+		},
+		{
+			name:    "fewest cards on the table first",
+			hands:   [][]entity.Effect{nil, nil, nil},
+			onTable: []int{3, 1, 2},
+			want:    []int{1, 2, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, make([]int, len(tt.hands))...)
+			for i, effects := range tt.hands {
+				for _, effect := range effects {
+					players[i].AddCard(testAction(effect))
+				}
+				for j := range tt.onTable[i] {
+					e.Cards.TableStack.AddCard(testIngredient(fmt.Sprint("T", i, j)), players[i].ID)
+				}
+			}
+			for _, i := range tt.finished {
+				e.Turns.MarkFinished(players[i].ID)
+			}
+
+			e.checkStalemate()
+
+			want := make([]string, 0, len(tt.want))
+			for _, i := range tt.want {
+				want = append(want, players[i].ID)
+			}
+			if got := e.Turns.FinishedOrder(); !slices.Equal(got, want) {
+				t.Errorf("finished %v, want %v", got, want)
+			}
+		})
+	}
+}
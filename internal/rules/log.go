@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// DefaultLogSize is how many lines a match log keeps
+const DefaultLogSize = 50
+
+// Log keeps a readable line for each of the last events of a match, shown
+// to spectators
+type Log struct {
+	Lines []string // Oldest first
+	Size  int
+}
+
+func NewLog(size int) *Log {
+	return &Log{Size: size}
+}
+
+// Reset forgets the lines of the previous match
+func (l *Log) Reset() {
+	l.Lines = nil
+}
+
+// Record adds the line for an event, player names are looked up in the
+// engine. Events nobody needs to read, such as a resolved play, are skipped.
+func (l *Log) Record(e *Engine, ev entity.Event) {
+	name := ev.PlayerID
+	if p := e.GetPlayer(ev.PlayerID); p != nil {
+		name = p.Name
+	}
+
+	var line string
+	switch ev.Type {
+	case entity.EventPass:
+		line = name + " passed"
+	case entity.EventCardPlayed:
+		line = name + " played " + ev.Card
+	case entity.EventDishMade:
+		line = name + " cooked " + ev.Card
+	case entity.EventSignal:
+		line = name + " signalled " + ev.Card
+	case entity.EventOrderPlaced:
+		line = ev.Card + " placed an order"
+	case entity.EventOrderServed:
+		line = fmt.Sprintf("%s served %s, tip %d", name, ev.Card, ev.Count)
+	case entity.EventOrderExpired:
+		line = ev.Card + " left without their order"
+	case entity.EventFinish:
+		line = fmt.Sprintf("%s finished #%d", name, ev.Position)
+	case entity.EventMatchOver:
+		line = "The match is over"
+	default:
+		return
+	}
+
+	l.Lines = append(l.Lines, line)
+	if over := len(l.Lines) - l.Size; l.Size > 0 && over > 0 {
+		l.Lines = l.Lines[over:]
+	}
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+// Customers who come to the restaurant, in turn. Every third one orders
+// for a table of several.
+var customers = []string{"Bà Lan", "Anh Minh", "Chị Hoa", "Ông Tư", "Cô Mai", "Chú Sáu", "Bé Na", "Anh Khoa"}
+
+// Orders is the restaurant ruleset. Customers come every few turns with an
+// order of dishes still in play and wait a number of turns for each dish.
+// Cooking a dish an order wants pays its tip to the cook as points, a
+// customer who leaves unserved costs the restaurant reputation. Once the
+// reputation is gone no new customer comes. A turn is a play or a pass.
+type Orders struct {
+	Every         int // Turns between two customers
+	Patience      int // Turns a customer waits for each dish ordered
+	MaxOpen       int // Customers waiting at once
+	MaxDishes     int // Dishes on one order
+	Tip           int // Points for each dish served
+	Penalty       int // Reputation lost when a customer leaves unserved
+	MaxReputation int
+
+	Turn       int
+	Reputation int
+	Open       []*entity.Order // Oldest first
+	Served     int             // Orders served in full
+	Expired    int
+	Tips       map[string]int // PlayerID -> points earned from tips
+	nextID     int
+}
+
+func NewOrders(every, patience, reputation int) *Orders {
+	return &Orders{
+		Every:         every,
+		Patience:      patience,
+		MaxOpen:       3,
+		MaxDishes:     2,
+		Tip:           2,
+		Penalty:       1,
+		MaxReputation: reputation,
+		Reputation:    reputation,
+		Tips:          make(map[string]int),
+	}
+}
+
+func (o *Orders) reset() {
+	o.Turn = 0
+	o.Reputation = o.MaxReputation
+	o.Open = nil
+	o.Served = 0
+	o.Expired = 0
+	o.Tips = make(map[string]int)
+	o.nextID = 0
+}
+
+// TurnsLeft returns how many more turns the customer of an order waits,
+// they leave at the end of the next one when it is 0
+func (o *Orders) TurnsLeft(order *entity.Order) int {
+	return order.Deadline - o.Turn
+}
+
+// serveOrder gives a dish to the oldest order wanting it and pays the tip to
+// the cook
+func (e *Engine) serveOrder(playerID string, recipe *entity.Card) {
+	o := e.Orders
+	if o == nil {
+		return
+	}
+	for i, order := range o.Open {
+		if !order.Serve(recipe.RecipeID) {
+			continue
+		}
+		e.Scores[playerID] += order.Tip
+		o.Tips[playerID] += order.Tip
+		e.emit(entity.Event{Type: entity.EventOrderServed, PlayerID: playerID, Card: recipe.Name, Count: order.Tip})
+		if order.Done() {
+			o.Open = append(o.Open[:i:i], o.Open[i+1:]...)
+			o.Served++
+		}
+		return
+	}
+}
+
+// tickOrders ends a turn of the restaurant: customers out of patience
+// leave, and a new one may come
+func (e *Engine) tickOrders() {
+	o := e.Orders
+	if o == nil || e.IsOver() {
+		return
+	}
+	o.Turn++
+
+	open := o.Open[:0]
+	for _, order := range o.Open {
+		if order.Deadline >= o.Turn {
+			open = append(open, order)
+			continue
+		}
+		o.Expired++
+		o.Reputation = max(o.Reputation-o.Penalty, 0)
+		e.emit(entity.Event{Type: entity.EventOrderExpired, Card: order.Customer})
+	}
+	o.Open = open
+
+	if (o.Turn-1)%o.Every == 0 {
+		e.openOrder()
+	}
+}
+
+// openOrder seats a new customer with an order of recipes in the hands or
+// on the table that no other order wants. Nobody comes when the restaurant
+// is full, has lost its reputation or has nothing left to cook.
+func (e *Engine) openOrder() {
+	o := e.Orders
+	if len(o.Open) >= o.MaxOpen || o.Reputation <= 0 {
+		return
+	}
+
+	var candidates []*entity.Card
+	add := func(c *entity.Card) {
+		if c.Type != entity.CardTypeRecipe {
+			return
+		}
+		for _, order := range o.Open {
+			if order.Wants(c.RecipeID) {
+				return
+			}
+		}
+		for _, other := range candidates {
+			if other.RecipeID == c.RecipeID {
+				return
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	for _, p := range e.Players {
+		for _, id := range p.OrderHand {
+			add(p.GetCard(id))
+		}
+	}
+	for _, c := range e.Cards.TableStack.GetAllCardsInOrder() {
+		add(c)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	o.nextID++
+	order := &entity.Order{
+		ID:       fmt.Sprintf("O%d", o.nextID),
+		Customer: customers[(o.nextID-1)%len(customers)],
+		Tip:      o.Tip,
+	}
+	n := 1
+	if o.nextID%3 == 0 {
+		n = o.MaxDishes
+	}
+	for range min(n, len(candidates)) {
+		c := e.Cards.RandomCard(candidates)
+		order.Dishes = append(order.Dishes, c)
+		candidates = slices.DeleteFunc(candidates, func(other *entity.Card) bool { return other == c })
+	}
+	order.Deadline = o.Turn + o.Patience*len(order.Dishes)
+	o.Open = append(o.Open, order)
+	e.emit(entity.Event{Type: entity.EventOrderPlaced, Card: order.Customer})
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+)
+
+func TestTickOrders(t *testing.T) {
+	tests := []struct {
+		name     string
+		every    int
+		patience int
+		recipes  int // Recipes in each hand
+		turns    int
+
+		open       int
+		expired    int
+		reputation int
+	}{
+		{name: "first customer", every: 2, patience: 3, recipes: 3, turns: 1, open: 1, reputation: 2},
+		{name: "between customers", every: 2, patience: 3, recipes: 3, turns: 2, open: 1, reputation: 2},
+		{name: "second customer", every: 2, patience: 3, recipes: 3, turns: 3, open: 2, reputation: 2},
+		{name: "first customer leaves", every: 2, patience: 3, recipes: 3, turns: 5, open: 2, expired: 1, reputation: 1},
+		{name: "no reputation left", every: 2, patience: 3, recipes: 3, turns: 7, open: 1, expired: 2},
+		{name: "everyone left", every: 2, patience: 3, recipes: 3, turns: 13, expired: 3},
+		{name: "restaurant full", every: 1, patience: 100, recipes: 3, turns: 5, open: 3, reputation: 2},
+		{name: "nothing to cook", every: 1, patience: 3, turns: 5, reputation: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, 1, 1)
+			for i, p := range players {
+				for j := range tt.recipes {
+					p.AddCard(testRecipe(fmt.Sprint("I_", i, j)))
+				}
+			}
+			e.Orders = NewOrders(tt.every, tt.patience, 2)
+			e.StartDealt(players)
+
+			for range tt.turns {
+				if err := e.Pass(e.Turns.Current().ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			o := e.Orders
+			if o.Turn != tt.turns {
+				t.Errorf("turn %d, want %d", o.Turn, tt.turns)
+			}
+			if len(o.Open) != tt.open || o.Expired != tt.expired || o.Reputation != tt.reputation {
+				t.Errorf("%d open, %d expired, reputation %d, want %d, %d, %d",
+					len(o.Open), o.Expired, o.Reputation, tt.open, tt.expired, tt.reputation)
+			}
+
+			wanted := make(map[string]bool)
+			for _, order := range o.Open {
+				dishes := 1
+				if order.ID == "O3" {
+					dishes = o.MaxDishes
+				}
+				if len(order.Dishes) != dishes {
+					t.Errorf("order %s wants %d dishes, want %d", order.ID, len(order.Dishes), dishes)
+				}
+				if order.Deadline > o.Turn+o.Patience*len(order.Dishes) {
+					t.Errorf("order %s waits until turn %d", order.ID, order.Deadline)
+				}
+				for _, d := range order.Dishes {
+					if wanted[d.RecipeID] {
+						t.Errorf("two orders want %s", d.RecipeID)
+					}
+					wanted[d.RecipeID] = true
+				}
+			}
+		})
+	}
+}
+
+func TestServeOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		orders [][]string // Recipes wanted by each open order, R_X is cooked
+		open   [][]string // Recipes still wanted afterwards
+		tip    int
+		served int
+	}{
+		{name: "served in full", orders: [][]string{{"R_X"}}, open: [][]string{}, tip: 2, served: 1},
+		{name: "oldest order first", orders: [][]string{{"R_Y"}, {"R_X"}, {"R_X"}}, open: [][]string{{"R_Y"}, {"R_X"}}, tip: 2, served: 1},
+		{name: "part of an order", orders: [][]string{{"R_Y", "R_X"}}, open: [][]string{{"R_Y"}}, tip: 2},
+		{name: "not wanted", orders: [][]string{{"R_Y"}}, open: [][]string{{"R_Y"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, players := newTable(t, 1, 1, 1)
+			e.Orders = NewOrders(100, 100, 3)
+			e.StartDealt(players)
+
+			cook := players[0]
+			x := testIngredient("X")
+			cook.AddCard(x)
+			recipe := testRecipe("X")
+			e.Cards.TableStack.AddCard(recipe, players[1].ID)
+			for i, wants := range tt.orders {
+				order := &entity.Order{ID: fmt.Sprint("O", i), Tip: e.Orders.Tip, Deadline: 100}
+				for _, id := range wants {
+					order.Dishes = append(order.Dishes, &entity.Card{RecipeID: id})
+				}
+				e.Orders.Open = append(e.Orders.Open, order)
+			}
+
+			if err := e.PlayCard(cook.ID, x.ID, entity.Target{}); err != nil {
+				t.Fatal(err)
+			}
+			if e.DishesMade != 1 {
+				t.Fatalf("%d dishes made, want 1", e.DishesMade)
+			}
+
+			if e.Score(cook.ID) != tt.tip || e.Orders.Tips[cook.ID] != tt.tip {
+				t.Errorf("cook got %d points and %d tips, want %d", e.Score(cook.ID), e.Orders.Tips[cook.ID], tt.tip)
+			}
+			if e.Orders.Served != tt.served {
+				t.Errorf("%d orders served, want %d", e.Orders.Served, tt.served)
+			}
+			var open [][]string
+			for _, order := range e.Orders.Open {
+				var wants []string
+				for _, d := range order.Dishes {
+					wants = append(wants, d.RecipeID)
+				}
+				open = append(open, wants)
+			}
+			if fmt.Sprint(open) != fmt.Sprint(tt.open) {
+				t.Errorf("open orders %v, want %v", open, tt.open)
+			}
+		})
+	}
+}
@@ -5,17 +5,19 @@ import (
 )
 
 type PlayerTurn struct {
-	ID        string
-	IsBot     bool
-	Passed    bool
-	Finished  bool
-	HandEmpty bool
+	ID        string `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	Passed    bool   `json:"passed"`
+	Finished  bool   `json:"finished"`
+	HandEmpty bool   `json:"hand_empty"`
 }
 
 type TurnManager struct {
 	players []*PlayerTurn
 	index   int
 	order   []string // finished order
+	skips   int      // players to skip on the next call to Next
+	shared  bool     // One player finishing finishes the whole table
 }
 
 func NewTurnManager() *TurnManager {
@@ -37,6 +39,7 @@ func (tm *TurnManager) Reset() {
 	tm.index = 0
 	tm.order = []string{}
 	tm.players = []*PlayerTurn{}
+	tm.skips = 0
 }
 
 func (tm *TurnManager) Current() *PlayerTurn {
@@ -55,6 +58,28 @@ func (tm *TurnManager) MarkHandEmpty(playerID string) {
 	}
 }
 
+// MarkHandRefilled undoes MarkHandEmpty when a player gets cards back
+func (tm *TurnManager) MarkHandRefilled(playerID string) {
+	for _, p := range tm.players {
+		if p.ID == playerID {
+			p.HandEmpty = false
+			break
+		}
+	}
+}
+
+// SetShared makes the players finish together, as a cooperative table
+// does. It lasts until it is set again, Reset keeps it.
+func (tm *TurnManager) SetShared(shared bool) {
+	tm.shared = shared
+}
+
+func (tm *TurnManager) Shared() bool {
+	return tm.shared
+}
+
+// MarkFinished finishes a player. At a shared table it finishes every
+// player, the given one first and the others in seating order.
 func (tm *TurnManager) MarkFinished(playerID string) {
 	for _, p := range tm.players {
 		if p.ID == playerID && !p.Finished {
@@ -63,6 +88,15 @@ func (tm *TurnManager) MarkFinished(playerID string) {
 			break
 		}
 	}
+	if !tm.shared {
+		return
+	}
+	for _, p := range tm.players {
+		if !p.Finished {
+			p.Finished = true
+			tm.order = append(tm.order, p.ID)
+		}
+	}
 }
 
 func (tm *TurnManager) FinishedOrder() []string {
@@ -71,6 +105,18 @@ func (tm *TurnManager) FinishedOrder() []string {
 
 // Next moves to the next player in turn
 func (tm *TurnManager) Next() {
+	tm.advance()
+	for ; tm.skips > 0; tm.skips-- {
+		tm.advance()
+	}
+}
+
+// SkipNext makes the next call to Next jump over one more player
+func (tm *TurnManager) SkipNext() {
+	tm.skips++
+}
+
+func (tm *TurnManager) advance() {
 	for i := 1; i <= len(tm.players); i++ {
 		idx := (tm.index + i) % len(tm.players)
 		p := tm.players[idx]
@@ -119,3 +165,37 @@ func (tm *TurnManager) GetPlayerByID(id string) *PlayerTurn {
 	}
 	return nil
 }
+
+// TurnState is a copy of the turn manager's state, used for saving a match
+type TurnState struct {
+	Players []PlayerTurn `json:"players"`
+	Index   int          `json:"index"`
+	Order   []string     `json:"order"`
+	Skips   int          `json:"skips"`
+	Shared  bool         `json:"shared,omitempty"`
+}
+
+func (tm *TurnManager) State() TurnState {
+	st := TurnState{
+		Players: make([]PlayerTurn, 0, len(tm.players)),
+		Index:   tm.index,
+		Order:   append([]string{}, tm.order...),
+		Skips:   tm.skips,
+		Shared:  tm.shared,
+	}
+	for _, p := range tm.players {
+		st.Players = append(st.Players, *p)
+	}
+	return st
+}
+
+func (tm *TurnManager) Restore(st TurnState) {
+	tm.Reset()
+	for _, p := range st.Players {
+		tm.players = append(tm.players, &p)
+	}
+	tm.index = st.Index
+	tm.order = append(tm.order, st.Order...)
+	tm.skips = st.Skips
+	tm.shared = st.Shared
+}
@@ -0,0 +1,208 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// Version of the save format written by this build. Bump it whenever the
+// format changes and add a migration from the previous version.
+const Version = 1
+
+// Key is the storage key of the match in progress
+const Key = "match.json"
+
+var ErrUnsupported = errors.New("unsupported save file")
+
+// File is a saved match
+type File struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+	Match   Match     `json:"match"`
+}
+
+type Match struct {
+	Deck        string                     `json:"deck"`
+	Players     []Player                   `json:"players"`
+	Table       []TableCard                `json:"table"` // Oldest to newest
+	DiscardPile []*entity.Card             `json:"discard_pile"`
+	Turns       rules.TurnState            `json:"turns"`
+	Scores      map[string]int             `json:"scores"`
+	Dishes      map[string][]string        `json:"dishes,omitempty"` // PlayerID -> recipe IDs
+	DishesMade  int                        `json:"dishes_made"`
+	Rand        rng.State                  `json:"rand"`
+	Bots        map[string]json.RawMessage `json:"bots,omitempty"`  // PlayerID -> bot memory
+	Hints       int                        `json:"hints,omitempty"` // Hints the player asked for
+
+	Achievements *achievement.MatchState `json:"achievements,omitempty"` // What the achievements know of the match
+}
+
+type Player struct {
+	ID    string         `json:"id"`
+	Name  string         `json:"name"`
+	IsBot bool           `json:"is_bot"`
+	Hand  []*entity.Card `json:"hand"` // In the order of Player.OrderHand
+}
+
+type TableCard struct {
+	Card     *entity.Card `json:"card"`
+	PlayerID string       `json:"player_id"`
+}
+
+// Capture takes a snapshot of the match run by the engine
+func Capture(e *rules.Engine, bots *ai.Manager) (*File, error) {
+	m := Match{
+		Deck:        e.Cards.Theme,
+		DiscardPile: append([]*entity.Card{}, e.Cards.DiscardPile...),
+		Turns:       e.Turns.State(),
+		Scores:      make(map[string]int),
+		Dishes:      make(map[string][]string),
+		DishesMade:  e.DishesMade,
+		Rand:        e.Cards.RandState(),
+		Bots:        make(map[string]json.RawMessage),
+	}
+
+	for _, p := range e.Players {
+		sp := Player{ID: p.ID, Name: p.Name, IsBot: p.IsBot()}
+		for _, id := range p.OrderHand {
+			sp.Hand = append(sp.Hand, p.GetCard(id))
+		}
+		m.Players = append(m.Players, sp)
+
+		if mem, ok := bots.GetBot(p.ID).(ai.Memorizer); ok {
+			data, err := mem.SaveMemory()
+			if err != nil {
+				return nil, fmt.Errorf("save memory of bot %s: %w", p.Name, err)
+			}
+			m.Bots[p.ID] = data
+		}
+	}
+
+	for _, entry := range e.Cards.TableStack.GetEntriesInOrder() {
+		m.Table = append(m.Table, TableCard{Card: entry.Card, PlayerID: entry.PlayerID})
+	}
+	for id, score := range e.Scores {
+		m.Scores[id] = score
+	}
+	for id, dishes := range e.Dishes {
+		m.Dishes[id] = append([]string{}, dishes...)
+	}
+
+	return &File{Version: Version, SavedAt: time.Now(), Match: m}, nil
+}
+
+// Restore puts the saved match into the engine. The deck is reloaded for its
+// deck wide effects and names, then its cards are replaced by the saved ones.
+// Bots must already be registered for the saved player IDs.
+func (f *File) Restore(e *rules.Engine, bots *ai.Manager) error {
+	m := f.Match
+	if err := e.Cards.LoadDeck(m.Deck); err != nil {
+		return err
+	}
+
+	players := make([]*entity.Player, 0, len(m.Players))
+	deck := []*entity.Card{}
+	for _, sp := range m.Players {
+		ttype := entity.TypePlayer
+		if sp.IsBot {
+			ttype = entity.TypeBot
+		}
+		p := &entity.Player{
+			Entity:    entity.Entity{ID: sp.ID, Name: sp.Name, EntityType: ttype},
+			Hand:      make(map[string]*entity.Card),
+			OrderHand: []string{},
+		}
+		for _, c := range sp.Hand {
+			p.AddCard(c)
+			deck = append(deck, c)
+		}
+		players = append(players, p)
+	}
+
+	e.Cards.TableStack = entity.NewTableStack()
+	for _, tc := range m.Table {
+		e.Cards.TableStack.AddCard(tc.Card, tc.PlayerID)
+		deck = append(deck, tc.Card)
+	}
+	e.Cards.DiscardPile = append([]*entity.Card{}, m.DiscardPile...)
+	e.Cards.Deck = append(deck, m.DiscardPile...)
+	e.Cards.SetRandState(m.Rand)
+
+	e.Players = players
+	e.Turns.Restore(m.Turns)
+	e.Scores = make(map[string]int)
+	for id, score := range m.Scores {
+		e.Scores[id] = score
+	}
+	e.Dishes = make(map[string][]string)
+	for id, dishes := range m.Dishes {
+		e.Dishes[id] = append([]string{}, dishes...)
+	}
+	e.DishesMade = m.DishesMade
+
+	for id, data := range m.Bots {
+		mem, ok := bots.GetBot(id).(ai.Memorizer)
+		if !ok {
+			continue
+		}
+		if err := mem.LoadMemory(data); err != nil {
+			return fmt.Errorf("load memory of bot %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func Encode(f *File) ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// migrations upgrade a raw save from the version in the key to the next one
+var migrations = map[int]func(raw map[string]json.RawMessage) error{}
+
+// Decode reads a save file, migrating older versions to the current one
+func Decode(data []byte) (*File, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	version := 0
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, fmt.Errorf("%w: bad version: %v", ErrUnsupported, err)
+		}
+	}
+
+	if version > Version {
+		return nil, fmt.Errorf("%w: it was made by a newer version of the game (save v%d, game v%d)", ErrUnsupported, version, Version)
+	}
+	for ; version < Version; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: save v%d is too old to be loaded by this version (v%d)", ErrUnsupported, version, Version)
+		}
+		if err := migrate(raw); err != nil {
+			return nil, fmt.Errorf("%w: migrate from v%d: %v", ErrUnsupported, version, err)
+		}
+		raw["version"], _ = json.Marshal(version + 1)
+	}
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if err := json.Unmarshal(migrated, &f); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	return &f, nil
+}
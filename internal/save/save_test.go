@@ -0,0 +1,216 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/thanhfphan/ebitengj2025/internal/achievement"
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		ok   bool
+	}{
+		{name: "current version", data: fmt.Sprintf(`{"version":%d,"match":{"deck":"default"}}`, Version), ok: true},
+		{name: "newer version", data: fmt.Sprintf(`{"version":%d,"match":{"deck":"default"}}`, Version+1)},
+		{name: "no migration", data: `{"match":{"deck":"default"}}`},
+		{name: "bad version", data: `{"version":"one"}`},
+		{name: "not json", data: `match`},
+		{name: "bad match", data: fmt.Sprintf(`{"version":%d,"match":[]}`, Version)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Decode([]byte(tt.data))
+			if !tt.ok {
+				if !errors.Is(err, ErrUnsupported) {
+					t.Errorf("Decode() error = %v, want ErrUnsupported", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Version != Version || f.Match.Deck != "default" {
+				t.Errorf("Decode() = %+v", f)
+			}
+		})
+	}
+}
+
+// table is a match between easy bots
+type table struct {
+	engine *rules.Engine
+	bots   *ai.Manager
+}
+
+func newTable(seed int64, players []*entity.Player) *table {
+	cards := card.NewManager()
+	cards.SetRandState(rng.State{Seed: seed})
+	tb := &table{
+		engine: rules.NewEngine(cards, rules.NewTurnManager()),
+		bots:   ai.NewManager(),
+	}
+	for i, p := range players {
+		bot := ai.NewEasyBot()
+		bot.Seed(seed + int64(i))
+		tb.bots.RegisterBot(p.ID, bot)
+	}
+	return tb
+}
+
+// play plays up to n turns, the way the simulator does
+func (tb *table) play(t *testing.T, n int) {
+	t.Helper()
+	e := tb.engine
+	g := &ai.EngineGame{Engine: e}
+	for ; n > 0 && !e.IsOver(); n-- {
+		current := e.Turns.Current()
+		err := errors.New("no cards in hand")
+		if len(e.GetPlayer(current.ID).Hand) > 0 {
+			err = tb.bots.GetBot(current.ID).PlayTurn(g, current.ID)
+		}
+		if err != nil {
+			_ = e.Pass(current.ID)
+		}
+	}
+}
+
+// outcome sums up how the match went
+func (tb *table) outcome() string {
+	e := tb.engine
+	data, _ := json.Marshal(map[string]any{
+		"finished": e.Turns.FinishedOrder(),
+		"scores":   e.Scores,
+		"dishes":   e.Dishes,
+	})
+	return string(data)
+}
+
+func TestCaptureRestore(t *testing.T) {
+	tests := []struct {
+		name  string
+		turns int // Played before saving
+	}{
+		{name: "fresh deal", turns: 0},
+		{name: "early", turns: 5},
+		{name: "midgame", turns: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := []*entity.Player{
+				entity.NewPlayer("A", entity.TypeBot),
+				entity.NewPlayer("B", entity.TypeBot),
+				entity.NewPlayer("C", entity.TypeBot),
+			}
+			original := newTable(7, players)
+			if err := original.engine.Cards.LoadDeck("default"); err != nil {
+				t.Fatal(err)
+			}
+			original.engine.Start(players)
+			original.play(t, tt.turns)
+
+			f, err := Capture(original.engine, original.bots)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := Encode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// A fresh table with other seeds only plays the same once restored
+			restored := newTable(99, players)
+			if err := decoded.Restore(restored.engine, restored.bots); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, p := range restored.engine.Players {
+				want := players[i]
+				if p.ID != want.ID || p.Name != want.Name || p.IsBot() != want.IsBot() {
+					t.Errorf("player %d = %s %s, want %s %s", i, p.ID, p.Name, want.ID, want.Name)
+				}
+				if !slices.Equal(p.OrderHand, want.OrderHand) {
+					t.Errorf("hand of %s = %v, want %v", p.Name, p.OrderHand, want.OrderHand)
+				}
+			}
+			if got, want := restored.engine.Turns.State(), original.engine.Turns.State(); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("turns = %+v, want %+v", got, want)
+			}
+
+			original.play(t, 1000)
+			restored.play(t, 1000)
+			if !original.engine.IsOver() {
+				t.Fatal("the match did not end")
+			}
+			if got, want := restored.outcome(), original.outcome(); got != want {
+				t.Errorf("restored match ended with %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestResumedMatchUnlocksAchievements(t *testing.T) {
+	players := []*entity.Player{
+		entity.NewPlayer("A", entity.TypePlayer),
+		entity.NewPlayer("B", entity.TypeBot),
+	}
+	original := newTable(3, players)
+	if err := original.engine.Cards.LoadDeck("default"); err != nil {
+		t.Fatal(err)
+	}
+	original.engine.Start(players)
+	original.play(t, 5)
+
+	f, err := Capture(original.engine, original.bots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Match.Achievements = &achievement.MatchState{PlayerID: players[0].ID, Deck: "default"}
+	data, err := Encode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The tracker last followed another match, such as a daily challenge
+	tracker := achievement.NewTracker([]achievement.Definition{
+		{ID: "played", Name: "Played", Goal: achievement.Goal{Type: achievement.GoalMatchesPlayed}},
+	})
+	tracker.StartMatch("someone else", "default")
+
+	restored := newTable(3, players)
+	if err := decoded.Restore(restored.engine, restored.bots); err != nil {
+		t.Fatal(err)
+	}
+	tracker.ResumeMatch(players[0].ID, restored.engine.Cards.Theme, decoded.Match.Achievements)
+	var unlocked []string
+	restored.engine.OnEvent = func(ev entity.Event) {
+		for _, d := range tracker.Handle(ev) {
+			unlocked = append(unlocked, d.ID)
+		}
+	}
+	restored.play(t, 1000)
+
+	if !slices.Equal(unlocked, []string{"played"}) {
+		t.Errorf("unlocked %v after the resumed match, want played", unlocked)
+	}
+}
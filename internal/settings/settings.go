@@ -0,0 +1,132 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/thanhfphan/ebitengj2025/internal/storage"
+)
+
+// Key is the storage key of the settings
+const Key = "settings.json"
+
+// Window modes
+const (
+	WindowModeWindowed   = "windowed"
+	WindowModeFullscreen = "fullscreen"
+)
+
+// Languages
+const (
+	LanguageEnglish    = "en"
+	LanguageVietnamese = "vi"
+)
+
+// Bot speeds
+const (
+	BotSpeedSlow   = "slow"
+	BotSpeedNormal = "normal"
+	BotSpeedFast   = "fast"
+)
+
+var (
+	WindowModes = []string{WindowModeWindowed, WindowModeFullscreen}
+	Languages   = []string{LanguageEnglish, LanguageVietnamese}
+	BotSpeeds   = []string{BotSpeedSlow, BotSpeedNormal, BotSpeedFast}
+)
+
+type Settings struct {
+	MasterVolume  float64       `json:"master_volume"`
+	MusicVolume   float64       `json:"music_volume"`
+	WindowMode    string        `json:"window_mode"`
+	Language      string        `json:"language"` // Not offered in the settings scene until the game is translated
+	BotSpeed      string        `json:"bot_speed"`
+	Hints         bool          `json:"hints"` // Off hides the hint button, for playing without help
+	Accessibility Accessibility `json:"accessibility"`
+}
+
+type Accessibility struct {
+	HighContrast bool `json:"high_contrast"` // Stronger card borders with colour blind safe highlights
+	LargeText    bool `json:"large_text"`    // Bigger score and result text
+}
+
+func Default() *Settings {
+	return &Settings{
+		MasterVolume: 1.0,
+		MusicVolume:  0.2,
+		WindowMode:   WindowModeWindowed,
+		Language:     LanguageEnglish,
+		BotSpeed:     BotSpeedNormal,
+		Hints:        true,
+	}
+}
+
+// Load reads the saved settings. Missing settings give the defaults, values
+// that are out of range or unknown are replaced by their default.
+func Load(store storage.Store) (*Settings, error) {
+	s := Default()
+	data, err := store.Load(Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return Default(), fmt.Errorf("read settings: %w", err)
+	}
+	s.normalize()
+	return s, nil
+}
+
+func (s *Settings) Save(store storage.Store) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Save(Key, data)
+}
+
+func (s *Settings) normalize() {
+	def := Default()
+	s.MasterVolume = clamp(s.MasterVolume)
+	s.MusicVolume = clamp(s.MusicVolume)
+	if !slices.Contains(WindowModes, s.WindowMode) {
+		s.WindowMode = def.WindowMode
+	}
+	if !slices.Contains(Languages, s.Language) {
+		s.Language = def.Language
+	}
+	if !slices.Contains(BotSpeeds, s.BotSpeed) {
+		s.BotSpeed = def.BotSpeed
+	}
+}
+
+// BotThinkTime returns how long bots wait before playing at the chosen speed
+func (s *Settings) BotThinkTime() (lo, hi time.Duration) {
+	switch s.BotSpeed {
+	case BotSpeedSlow:
+		return 2000 * time.Millisecond, 3000 * time.Millisecond
+	case BotSpeedFast:
+		return 400 * time.Millisecond, 700 * time.Millisecond
+	default:
+		return 1200 * time.Millisecond, 2000 * time.Millisecond
+	}
+}
+
+// Next returns the option following current in options, wrapping around
+func Next(options []string, current string) string {
+	for i, opt := range options {
+		if opt == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+func clamp(v float64) float64 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,110 @@
+// Package sim plays matches between bots without the game around them, for
+// measuring bots and tuning rules
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// DefaultMaxTurns stops a match that does not end, such as with a bot that
+// never plays
+const DefaultMaxTurns = 10000
+
+// Config describes one match
+type Config struct {
+	Deck     string
+	Ruleset  string
+	Seed     int64 // Seeds the deal, random effects and the bots that take a seed
+	MaxTurns int
+}
+
+// Seat is a player of a match, in turn order
+type Seat struct {
+	Name string
+	Bot  ai.Bot
+}
+
+// Result of a match, indexed like the seats
+type Result struct {
+	Positions []int // 1 for the first player to finish
+	Scores    []int
+	Dishes    []int
+	Turns     int
+}
+
+// Play runs a match to its end
+func Play(cfg Config, seats []Seat) (*Result, error) {
+	if len(seats) < 2 {
+		return nil, errors.New("at least two seats are needed")
+	}
+	if cfg.Deck == "" {
+		cfg.Deck = "default"
+	}
+	if cfg.Ruleset == "" {
+		cfg.Ruleset = card.RulesetStandard
+	}
+	if cfg.MaxTurns <= 0 {
+		cfg.MaxTurns = DefaultMaxTurns
+	}
+
+	cards := card.NewManager()
+	cards.SetRandState(rng.State{Seed: cfg.Seed})
+	if err := cards.LoadDeckRuleset(cfg.Deck, cfg.Ruleset); err != nil {
+		return nil, fmt.Errorf("load deck: %w", err)
+	}
+	e := rules.NewEngine(cards, rules.NewTurnManager())
+
+	players := make([]*entity.Player, len(seats))
+	bots := make(map[string]ai.Bot, len(seats))
+	for i, s := range seats {
+		players[i] = entity.NewPlayer(s.Name, entity.TypeBot)
+		bots[players[i].ID] = s.Bot
+		if seeder, ok := s.Bot.(ai.Seeder); ok {
+			seeder.Seed(cfg.Seed + int64(i))
+		}
+	}
+	e.Start(players)
+
+	game := &ai.EngineGame{Engine: e}
+	turns := 0
+	for ; !e.IsOver(); turns++ {
+		if turns >= cfg.MaxTurns {
+			return nil, fmt.Errorf("the match did not end after %d turns", turns)
+		}
+		current := e.Turns.Current()
+		if current == nil {
+			return nil, errors.New("nobody is left to play")
+		}
+
+		// Same as the server: a bot that cannot or does not play passes
+		err := errors.New("no cards in hand")
+		if len(e.GetPlayer(current.ID).Hand) > 0 {
+			err = bots[current.ID].PlayTurn(game, current.ID)
+		}
+		if err != nil {
+			_ = e.Pass(current.ID)
+		}
+	}
+
+	res := &Result{
+		Positions: make([]int, len(seats)),
+		Scores:    make([]int, len(seats)),
+		Dishes:    make([]int, len(seats)),
+		Turns:     turns,
+	}
+	order := e.Turns.FinishedOrder()
+	for i, p := range players {
+		res.Positions[i] = slices.Index(order, p.ID) + 1
+		res.Scores[i] = e.Score(p.ID)
+		res.Dishes[i] = len(e.Dishes[p.ID])
+	}
+	return res, nil
+}
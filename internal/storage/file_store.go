@@ -0,0 +1,60 @@
+//go:build !js
+
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var _ Store = (*FileStore)(nil)
+
+// FileStore keeps every key as a file in one folder
+type FileStore struct {
+	dir string
+}
+
+// New returns the store for this platform
+func New() Store {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return NewFileStore(filepath.Join(dir, AppName))
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) Load(key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Save writes to a temporary file first so a crash never leaves half a file
+func (s *FileStore) Save(key string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *FileStore) Delete(key string) error {
+	err := os.Remove(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
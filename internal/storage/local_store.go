@@ -0,0 +1,60 @@
+//go:build js
+
+package storage
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+var _ Store = (*LocalStore)(nil)
+
+// LocalStore keeps every key in the browser's localStorage
+type LocalStore struct {
+	prefix string
+}
+
+// New returns the store for this platform
+func New() Store {
+	return &LocalStore{prefix: AppName + "/"}
+}
+
+func (s *LocalStore) storage() js.Value {
+	return js.Global().Get("localStorage")
+}
+
+func (s *LocalStore) Load(key string) ([]byte, error) {
+	ls := s.storage()
+	if !ls.Truthy() {
+		return nil, ErrNotFound
+	}
+	v := ls.Call("getItem", s.prefix+key)
+	if v.IsNull() || v.IsUndefined() {
+		return nil, ErrNotFound
+	}
+	return []byte(v.String()), nil
+}
+
+func (s *LocalStore) Save(key string, data []byte) (err error) {
+	ls := s.storage()
+	if !ls.Truthy() {
+		return nil
+	}
+	// setItem throws when the quota is exceeded or storage is disabled
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("storage: %v", r)
+		}
+	}()
+	ls.Call("setItem", s.prefix+key, string(data))
+	return nil
+}
+
+func (s *LocalStore) Delete(key string) error {
+	ls := s.storage()
+	if !ls.Truthy() {
+		return nil
+	}
+	ls.Call("removeItem", s.prefix+key)
+	return nil
+}
@@ -0,0 +1,16 @@
+package storage
+
+import "errors"
+
+// AppName names the folder or key prefix the game data is kept under
+const AppName = "food-cards"
+
+var ErrNotFound = errors.New("storage: not found")
+
+// Store keeps small named blobs across sessions. Desktop builds write files
+// to the user config folder, the web build uses the browser's localStorage.
+type Store interface {
+	Load(key string) ([]byte, error)
+	Save(key string, data []byte) error
+	Delete(key string) error
+}
@@ -0,0 +1,41 @@
+package tournament
+
+import "math"
+
+// Ratings start at DefaultRating and move by at most DefaultK per match
+const (
+	DefaultRating = 1500.0
+	DefaultK      = 24.0
+)
+
+// expected is the chance of a player rated a to beat one rated b
+func expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// eloDeltas rates a match of several players as a duel between every pair
+// of them, the one who finished first wins. The K factor is shared between
+// the duels so a bigger table does not move ratings more.
+func eloDeltas(ratings []float64, positions []int, k float64) []float64 {
+	deltas := make([]float64, len(ratings))
+	if len(ratings) < 2 {
+		return deltas
+	}
+
+	perDuel := k / float64(len(ratings)-1)
+	for i := range ratings {
+		for j := i + 1; j < len(ratings); j++ {
+			score := 0.5
+			switch {
+			case positions[i] < positions[j]:
+				score = 1
+			case positions[i] > positions[j]:
+				score = 0
+			}
+			d := perDuel * (score - expected(ratings[i], ratings[j]))
+			deltas[i] += d
+			deltas[j] -= d
+		}
+	}
+	return deltas
+}
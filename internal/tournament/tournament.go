@@ -0,0 +1,219 @@
+// Package tournament pits bots against each other over many seeded matches
+// and rates them
+package tournament
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/sim"
+)
+
+// Formats
+const (
+	RoundRobin = "round-robin" // Every group of TableSize entrants plays
+	Swiss      = "swiss"       // Entrants with close ratings meet each round
+)
+
+// Config of a tournament. Every table plays each seed once per seat
+// rotation, so every entrant plays every deal from every seat.
+type Config struct {
+	Format    string
+	TableSize int // Players per match, 2 to 6
+	Rounds    int // Swiss only
+	Seeds     int // Deals per table
+	Seed      int64
+	Deck      string
+	Ruleset   string
+	K         float64 // Elo K factor
+}
+
+func (c *Config) normalize(entrants int) error {
+	if c.Format == "" {
+		c.Format = RoundRobin
+	}
+	if c.TableSize == 0 {
+		c.TableSize = min(entrants, 4)
+	}
+	if c.Rounds == 0 {
+		c.Rounds = 5
+	}
+	if c.Seeds == 0 {
+		c.Seeds = 10
+	}
+	if c.K == 0 {
+		c.K = DefaultK
+	}
+
+	var errs []error
+	if c.Format != RoundRobin && c.Format != Swiss {
+		errs = append(errs, fmt.Errorf("unknown format %q", c.Format))
+	}
+	if c.TableSize < 2 || c.TableSize > 6 {
+		errs = append(errs, fmt.Errorf("table size must be between 2 and 6, got %d", c.TableSize))
+	}
+	if entrants < c.TableSize {
+		errs = append(errs, fmt.Errorf("%d entrants cannot fill a table of %d", entrants, c.TableSize))
+	}
+	if c.Seeds < 1 || c.Rounds < 1 {
+		errs = append(errs, errors.New("seeds and rounds must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// Entrant is a bot taking part
+type Entrant struct {
+	Name string
+	Bot  ai.Bot
+}
+
+// Standing is an entrant's line of the leaderboard
+type Standing struct {
+	Name      string  `json:"name"`
+	Rating    float64 `json:"rating"`
+	Matches   int     `json:"matches"`
+	Wins      int     `json:"wins"`
+	Positions int     `json:"-"`
+	AvgPos    float64 `json:"avg_position"`
+	Dishes    int     `json:"dishes"`
+}
+
+// Run plays the tournament and returns the leaderboard, best rated first.
+// progress, when set, is called after each match.
+func Run(cfg Config, entrants []Entrant, progress func(played int)) ([]Standing, error) {
+	if err := cfg.normalize(len(entrants)); err != nil {
+		return nil, err
+	}
+
+	standings := make([]*Standing, len(entrants))
+	for i, e := range entrants {
+		standings[i] = &Standing{Name: e.Name, Rating: DefaultRating}
+	}
+
+	played := 0
+	playTable := func(table []int) error {
+		for s := range cfg.Seeds {
+			for shift := range table {
+				// Rotate the seats so every entrant plays every deal from every seat
+				seats := make([]sim.Seat, len(table))
+				order := make([]int, len(table))
+				for i := range table {
+					order[i] = table[(i+shift)%len(table)]
+					seats[i] = sim.Seat{Name: entrants[order[i]].Name, Bot: entrants[order[i]].Bot}
+				}
+
+				res, err := sim.Play(sim.Config{
+					Deck:    cfg.Deck,
+					Ruleset: cfg.Ruleset,
+					Seed:    cfg.Seed + int64(s),
+				}, seats)
+				if err != nil {
+					return fmt.Errorf("seed %d: %w", cfg.Seed+int64(s), err)
+				}
+				record(standings, order, res, cfg.K)
+
+				played++
+				if progress != nil {
+					progress(played)
+				}
+			}
+		}
+		return nil
+	}
+
+	switch cfg.Format {
+	case RoundRobin:
+		for _, table := range combinations(len(entrants), cfg.TableSize) {
+			if err := playTable(table); err != nil {
+				return nil, err
+			}
+		}
+	case Swiss:
+		for range cfg.Rounds {
+			for _, table := range swissTables(standings, cfg.TableSize) {
+				if err := playTable(table); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	board := make([]Standing, len(standings))
+	for i, s := range standings {
+		if s.Matches > 0 {
+			s.AvgPos = float64(s.Positions) / float64(s.Matches)
+		}
+		board[i] = *s
+	}
+	slices.SortStableFunc(board, func(a, b Standing) int {
+		return cmp.Compare(b.Rating, a.Rating)
+	})
+	return board, nil
+}
+
+// record adds the result of a match to the standings of its seats
+func record(standings []*Standing, order []int, res *sim.Result, k float64) {
+	ratings := make([]float64, len(order))
+	for i, idx := range order {
+		ratings[i] = standings[idx].Rating
+	}
+	deltas := eloDeltas(ratings, res.Positions, k)
+
+	for i, idx := range order {
+		s := standings[idx]
+		s.Rating += deltas[i]
+		s.Matches++
+		s.Positions += res.Positions[i]
+		s.Dishes += res.Dishes[i]
+		if res.Positions[i] == 1 {
+			s.Wins++
+		}
+	}
+}
+
+// combinations returns every group of k indexes out of n, in order
+func combinations(n, k int) [][]int {
+	var all [][]int
+	var pick func(start int, cur []int)
+	pick = func(start int, cur []int) {
+		if len(cur) == k {
+			all = append(all, slices.Clone(cur))
+			return
+		}
+		for i := start; i <= n-(k-len(cur)); i++ {
+			pick(i+1, append(cur, i))
+		}
+	}
+	pick(0, nil)
+	return all
+}
+
+// swissTables seats entrants of close ratings together. Leftovers that
+// cannot fill a table join the last one while it has room, otherwise they
+// sit the round out.
+func swissTables(standings []*Standing, size int) [][]int {
+	order := make([]int, len(standings))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(standings[b].Rating, standings[a].Rating)
+	})
+
+	var tables [][]int
+	for len(order) >= size {
+		tables = append(tables, slices.Clone(order[:size]))
+		order = order[size:]
+	}
+	for _, idx := range order {
+		last := len(tables) - 1
+		if len(tables[last]) >= 6 {
+			break
+		}
+		tables[last] = append(tables[last], idx)
+	}
+	return tables
+}
@@ -0,0 +1,87 @@
+package tournament
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestEloDeltas(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratings   []float64
+		positions []int
+		want      []float64
+	}{
+		{name: "alone", ratings: []float64{1500}, positions: []int{1}, want: []float64{0}},
+		{name: "even duel", ratings: []float64{1500, 1500}, positions: []int{1, 2}, want: []float64{12, -12}},
+		{name: "tie", ratings: []float64{1500, 1500}, positions: []int{1, 1}, want: []float64{0, 0}},
+		{name: "favourite wins", ratings: []float64{1900, 1500}, positions: []int{1, 2}, want: []float64{2.18, -2.18}},
+		{name: "upset", ratings: []float64{1900, 1500}, positions: []int{2, 1}, want: []float64{-21.82, 21.82}},
+		{name: "even table of three", ratings: []float64{1500, 1500, 1500}, positions: []int{2, 1, 3}, want: []float64{0, 12, -12}},
+		{name: "even table of four", ratings: []float64{1500, 1500, 1500, 1500}, positions: []int{1, 2, 3, 4}, want: []float64{12, 4, -4, -12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eloDeltas(tt.ratings, tt.positions, DefaultK)
+			sum := 0.0
+			for i := range got {
+				sum += got[i]
+				if math.Abs(got[i]-tt.want[i]) > 0.01 {
+					t.Errorf("eloDeltas() = %.2f, want %.2f", got, tt.want)
+					break
+				}
+			}
+			if math.Abs(sum) > 1e-9 {
+				t.Errorf("deltas add up to %f, want 0", sum)
+			}
+		})
+	}
+}
+
+func TestSwissTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings []float64
+		size    int
+		want    string
+	}{
+		{name: "by rating", ratings: []float64{1500, 1600, 1400, 1550}, size: 2, want: "[[1 3] [0 2]]"},
+		{name: "ties keep the entry order", ratings: []float64{1500, 1500, 1500, 1500}, size: 2, want: "[[0 1] [2 3]]"},
+		{name: "leftover joins the last table", ratings: []float64{1500, 1600, 1400, 1550, 1300}, size: 2, want: "[[1 3] [0 2 4]]"},
+		{name: "leftovers fill the last table up to six", ratings: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, size: 5, want: "[[8 7 6 5 4 3]]"},
+		{name: "full table leaves leftovers out", ratings: []float64{1, 2, 3, 4, 5, 6, 7}, size: 6, want: "[[6 5 4 3 2 1]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := make([]*Standing, len(tt.ratings))
+			for i, r := range tt.ratings {
+				standings[i] = &Standing{Name: fmt.Sprint(i), Rating: r}
+			}
+			if got := fmt.Sprint(swissTables(standings, tt.size)); got != tt.want {
+				t.Errorf("swissTables() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		n, k int
+		want string
+	}{
+		{n: 2, k: 2, want: "[[0 1]]"},
+		{n: 3, k: 2, want: "[[0 1] [0 2] [1 2]]"},
+		{n: 4, k: 3, want: "[[0 1 2] [0 1 3] [0 2 3] [1 2 3]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d of %d", tt.k, tt.n), func(t *testing.T) {
+			if got := fmt.Sprint(combinations(tt.n, tt.k)); got != tt.want {
+				t.Errorf("combinations() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Package tune searches the weights of the heuristic bot with a genetic
+// algorithm, rating candidates by headless matches against a fixed pool of
+// opponents
+package tune
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/thanhfphan/ebitengj2025/internal/ai"
+	"github.com/thanhfphan/ebitengj2025/internal/sim"
+)
+
+// Config of a search
+type Config struct {
+	Population  int
+	Generations int
+	Matches     int      // Played by each candidate every generation
+	TableSize   int      // Players per match, the candidate included
+	Opponents   []string // Registered bots the candidates play against
+	Elite       int      // Best candidates kept unchanged each generation
+	Sigma       float64  // Standard deviation of mutations
+	Seed        int64    // Seeds the search and the deals
+	Deck        string
+	Ruleset     string
+	Workers     int // Matches played in parallel
+}
+
+func (c *Config) normalize() error {
+	if c.Population == 0 {
+		c.Population = 16
+	}
+	if c.Generations == 0 {
+		c.Generations = 20
+	}
+	if c.Matches == 0 {
+		c.Matches = 40
+	}
+	if c.TableSize == 0 {
+		c.TableSize = 4
+	}
+	if len(c.Opponents) == 0 {
+		c.Opponents = []string{"easy", "heuristic"}
+	}
+	if c.Elite == 0 {
+		c.Elite = 2
+	}
+	if c.Sigma == 0 {
+		c.Sigma = 0.5
+	}
+	if c.Workers == 0 {
+		c.Workers = runtime.NumCPU()
+	}
+
+	var errs []error
+	if c.TableSize < 2 || c.TableSize > 6 {
+		errs = append(errs, fmt.Errorf("table size must be between 2 and 6, got %d", c.TableSize))
+	}
+	if c.Population < 2 || c.Elite >= c.Population {
+		errs = append(errs, fmt.Errorf("population of %d cannot keep %d elites", c.Population, c.Elite))
+	}
+	if c.Generations < 1 || c.Matches < 1 || c.Workers < 1 {
+		errs = append(errs, errors.New("generations, matches and workers must be positive"))
+	}
+	for _, name := range c.Opponents {
+		if _, err := ai.New(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Generation is reported once its candidates are rated
+type Generation struct {
+	Index       int
+	Best        ai.Weights
+	BestFitness float64
+	MeanFitness float64
+}
+
+type candidate struct {
+	weights ai.Weights
+	fitness float64
+}
+
+// Run evolves weights starting around start and returns the best of the
+// last generation with its fitness, the share of opponents it finished
+// ahead of. Every candidate of a generation plays the same deals, the deals
+// change each generation so luck does not carry a candidate along.
+func Run(cfg Config, start ai.Weights, progress func(Generation)) (ai.Weights, float64, error) {
+	if err := cfg.normalize(); err != nil {
+		return ai.Weights{}, 0, err
+	}
+	r := mrand.New(mrand.NewSource(cfg.Seed))
+
+	pop := make([]candidate, cfg.Population)
+	pop[0].weights = start
+	for i := 1; i < len(pop); i++ {
+		pop[i].weights = mutate(r, start, cfg.Sigma)
+	}
+
+	for gen := range cfg.Generations {
+		seed := cfg.Seed + int64(gen*cfg.Matches)
+		if err := rate(cfg, pop, seed); err != nil {
+			return ai.Weights{}, 0, err
+		}
+		slices.SortStableFunc(pop, func(a, b candidate) int { return cmp.Compare(b.fitness, a.fitness) })
+
+		if progress != nil {
+			mean := 0.0
+			for _, c := range pop {
+				mean += c.fitness
+			}
+			progress(Generation{Index: gen, Best: pop[0].weights, BestFitness: pop[0].fitness, MeanFitness: mean / float64(len(pop))})
+		}
+		if gen == cfg.Generations-1 {
+			break
+		}
+
+		next := make([]candidate, 0, len(pop))
+		next = append(next, pop[:cfg.Elite]...)
+		for len(next) < len(pop) {
+			child := crossover(r, pick(r, pop), pick(r, pop))
+			next = append(next, candidate{weights: mutate(r, child, cfg.Sigma)})
+		}
+		pop = next
+	}
+	return pop[0].weights, pop[0].fitness, nil
+}
+
+// rate plays the matches of every candidate, in parallel
+func rate(cfg Config, pop []candidate, seed int64) error {
+	type job struct{ cand, match int }
+	jobs := make(chan job)
+	scores := make([][]float64, len(pop))
+	for i := range scores {
+		scores[i] = make([]float64, cfg.Matches)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for range cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				s, err := play(cfg, pop[j.cand].weights, j.match, seed+int64(j.match))
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					continue
+				}
+				scores[j.cand][j.match] = s
+			}
+		}()
+	}
+	for c := range pop {
+		for m := range cfg.Matches {
+			jobs <- job{c, m}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for i, s := range scores {
+		total := 0.0
+		for _, v := range s {
+			total += v
+		}
+		pop[i].fitness = total / float64(len(s))
+	}
+	return nil
+}
+
+// play seats the candidate against opponents from the pool, both rotating
+// with the match number, and returns the share of them it finished ahead of
+func play(cfg Config, w ai.Weights, match int, seed int64) (float64, error) {
+	seats := make([]sim.Seat, cfg.TableSize)
+	at := match % cfg.TableSize
+	for i := range seats {
+		if i == at {
+			seats[i] = sim.Seat{Name: "candidate", Bot: ai.NewHeuristicBot(w)}
+			continue
+		}
+		name := cfg.Opponents[(match+i)%len(cfg.Opponents)]
+		bot, err := ai.New(name)
+		if err != nil {
+			return 0, err
+		}
+		seats[i] = sim.Seat{Name: name, Bot: bot}
+	}
+	defer func() {
+		for _, s := range seats {
+			if c, ok := s.Bot.(io.Closer); ok {
+				_ = c.Close()
+			}
+		}
+	}()
+
+	res, err := sim.Play(sim.Config{Deck: cfg.Deck, Ruleset: cfg.Ruleset, Seed: seed}, seats)
+	if err != nil {
+		return 0, fmt.Errorf("match %d: %w", match, err)
+	}
+	return float64(cfg.TableSize-res.Positions[at]) / float64(cfg.TableSize-1), nil
+}
+
+// pick returns the fitter of three random candidates
+func pick(r *mrand.Rand, pop []candidate) ai.Weights {
+	best := pop[r.Intn(len(pop))]
+	for range 2 {
+		if c := pop[r.Intn(len(pop))]; c.fitness > best.fitness {
+			best = c
+		}
+	}
+	return best.weights
+}
+
+// crossover takes each weight from either parent
+func crossover(r *mrand.Rand, a, b ai.Weights) ai.Weights {
+	child := a
+	from := b.Fields()
+	for i, f := range child.Fields() {
+		if r.Intn(2) == 0 {
+			*f = *from[i]
+		}
+	}
+	return child
+}
+
+// mutate adds gaussian noise to every weight
+func mutate(r *mrand.Rand, w ai.Weights, sigma float64) ai.Weights {
+	for _, f := range w.Fields() {
+		*f += r.NormFloat64() * sigma
+	}
+	return w
+}
@@ -0,0 +1,239 @@
+// Package tutorial reads the scripted lessons of the tutorial. A script
+// deals fixed hands and walks the player through steps, each allowing a
+// single action.
+package tutorial
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/thanhfphan/ebitengj2025/assets/configs"
+	"github.com/thanhfphan/ebitengj2025/internal/card"
+	"github.com/thanhfphan/ebitengj2025/internal/entity"
+	"github.com/thanhfphan/ebitengj2025/internal/rng"
+	"github.com/thanhfphan/ebitengj2025/internal/rules"
+)
+
+// Step actions
+const (
+	ActionNext     = "next"     // The player reads the text and presses Next
+	ActionSelect   = "select"   // The player selects Card in their hand
+	ActionPlay     = "play"     // The player plays Card
+	ActionPass     = "pass"     // The player passes
+	ActionOpponent = "opponent" // Player plays Card, or passes when it is empty
+	ActionFinish   = "finish"   // The player has finished, Next ends the tutorial
+)
+
+// Script is one lesson
+type Script struct {
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Deck    string           `json:"deck"`
+	Ruleset string           `json:"ruleset,omitempty"`
+	Seed    int64            `json:"seed,omitempty"` // Seeds random effects
+	Players []string         `json:"players"`        // The human first, the script plays the others
+	Hands   [][]string       `json:"hands"`          // Card names, indexed like Players
+	Table   []card.Placement `json:"table,omitempty"`
+	Steps   []Step           `json:"steps"`
+}
+
+// Step is shown until the player, or the opponent of the step, does its
+// action
+type Step struct {
+	Action string `json:"action"`
+	Text   string `json:"text"`
+	Card   string `json:"card,omitempty"`
+	Player int    `json:"player,omitempty"` // Index in Players of an opponent step
+}
+
+// List returns every script, ordered by file name
+func List() ([]*Script, error) {
+	files, err := fs.Glob(configs.TutorialsFS, "tutorials/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []*Script
+	for _, name := range files {
+		s, err := read(name)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, s)
+	}
+	return scripts, nil
+}
+
+// Load returns the script with the given ID
+func Load(id string) (*Script, error) {
+	scripts, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range scripts {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("no tutorial %q", id)
+}
+
+func read(name string) (*Script, error) {
+	data, err := configs.TutorialsFS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Script{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	if err := s.Rehearse(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	return s, nil
+}
+
+// Validate checks a script statically and reports every problem found
+func (s *Script) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if s.ID == "" {
+		fail("tutorial %q has no id", s.Name)
+	}
+	if len(s.Players) < 2 {
+		fail("tutorial %s needs at least two players", s.ID)
+	}
+	if len(s.Hands) != len(s.Players) {
+		fail("tutorial %s has %d hands for %d players", s.ID, len(s.Hands), len(s.Players))
+	}
+	if len(s.Steps) == 0 || s.Steps[len(s.Steps)-1].Action != ActionFinish {
+		fail("tutorial %s must end with a %s step", s.ID, ActionFinish)
+	}
+
+	for i, st := range s.Steps {
+		if strings.TrimSpace(st.Text) == "" {
+			fail("tutorial %s: step %d has no text", s.ID, i+1)
+		}
+		switch st.Action {
+		case ActionNext, ActionPass, ActionFinish:
+		case ActionSelect, ActionPlay:
+			if st.Card == "" {
+				fail("tutorial %s: step %d needs a card", s.ID, i+1)
+			}
+		case ActionOpponent:
+			if st.Player < 1 || st.Player >= len(s.Players) {
+				fail("tutorial %s: step %d has no opponent %d", s.ID, i+1, st.Player)
+			}
+		default:
+			fail("tutorial %s: step %d has unknown action %q", s.ID, i+1, st.Action)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Deal loads the deck of the script on the engine and starts the match with
+// the scripted hands and table. The first player returned is the human.
+func (s *Script) Deal(e *rules.Engine) ([]*entity.Player, error) {
+	e.Cards.SetRandState(rng.State{Seed: s.Seed})
+	ruleset := s.Ruleset
+	if ruleset == "" {
+		ruleset = card.RulesetStandard
+	}
+	if err := e.Cards.LoadDeckRuleset(s.Deck, ruleset); err != nil {
+		return nil, err
+	}
+
+	players := make([]*entity.Player, len(s.Players))
+	for i, name := range s.Players {
+		kind := entity.TypeBot
+		if i == 0 {
+			kind = entity.TypePlayer
+		}
+		players[i] = entity.NewPlayer(name, kind)
+	}
+	if err := e.Cards.DealScripted(players, s.Hands, s.Table); err != nil {
+		return nil, err
+	}
+	e.StartDealt(players)
+	return players, nil
+}
+
+// CardNamed returns the first card of the hand with the given name
+func CardNamed(p *entity.Player, name string) *entity.Card {
+	for _, id := range p.OrderHand {
+		if c := p.GetCard(id); c != nil && c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Expect checks that the step can be done now, it returns the player doing
+// it and the card they play if any
+func (st Step) Expect(e *rules.Engine, players []*entity.Player) (*entity.Player, *entity.Card, error) {
+	p := players[0]
+	if st.Action == ActionOpponent {
+		p = players[st.Player]
+	}
+
+	turn := e.Turns.GetPlayerByID(p.ID)
+	switch st.Action {
+	case ActionNext:
+		return p, nil, nil
+	case ActionFinish:
+		if turn == nil || !turn.Finished {
+			return nil, nil, fmt.Errorf("%s has not finished", p.Name)
+		}
+		return p, nil, nil
+	}
+
+	if current := e.Turns.Current(); current == nil || current.ID != p.ID || current.Finished {
+		return nil, nil, fmt.Errorf("it is not the turn of %s", p.Name)
+	}
+	if st.Card == "" {
+		return p, nil, nil
+	}
+	c := CardNamed(p, st.Card)
+	if c == nil {
+		return nil, nil, fmt.Errorf("%s does not hold %s", p.Name, st.Card)
+	}
+	return p, c, nil
+}
+
+// Rehearse plays the script on an engine of its own, so a script that
+// cannot be followed to its end is found when it is loaded
+func (s *Script) Rehearse() error {
+	e := rules.NewEngine(card.NewManager(), rules.NewTurnManager())
+	players, err := s.Deal(e)
+	if err != nil {
+		return err
+	}
+
+	for i, st := range s.Steps {
+		p, c, err := st.Expect(e, players)
+		if err == nil {
+			switch {
+			case st.Action != ActionPlay && st.Action != ActionPass && st.Action != ActionOpponent:
+			case c != nil:
+				err = e.PlayCard(p.ID, c.ID, entity.Target{})
+			default:
+				err = e.Pass(p.ID)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("tutorial %s: step %d: %w", s.ID, i+1, err)
+		}
+	}
+	return nil
+}
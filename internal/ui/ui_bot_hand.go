@@ -6,6 +6,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/thanhfphan/ebitengj2025/internal/view"
 	"golang.org/x/image/font"
 )
@@ -17,6 +18,10 @@ type UIBotHand struct {
 	Width, Height int
 	CardCount     int
 	CardUI        *UIImage
+	OnClick       func() // Set while the hand can be picked as a target
+	Highlighted   bool
+	Revealed      []string  // Names of the cards, listed next to the hand when set
+	RevealFont    font.Face // Font of the revealed names
 
 	visible bool
 	zIndex  int
@@ -55,6 +60,11 @@ func (h *UIBotHand) Draw(screen *ebiten.Image) {
 
 	screen.DrawImage(cardImg, op)
 
+	if h.Highlighted {
+		highlight := color.RGBA{0xFF, 0xE0, 0x7A, 0xFF}
+		vector.StrokeRect(screen, float32(h.X), float32(h.Y), float32(h.Width), float32(h.Height), 3, highlight, false)
+	}
+
 	if h.CardCount > 0 {
 		countText := fmt.Sprintf("%d", h.CardCount)
 		face := h.font
@@ -68,6 +78,37 @@ func (h *UIBotHand) Draw(screen *ebiten.Image) {
 
 		text.Draw(screen, countText, h.font, textX, textY, color.White)
 	}
+
+	if len(h.Revealed) > 0 && h.RevealFont != nil {
+		h.drawRevealed(screen)
+	}
+}
+
+// drawRevealed lists the cards of the hand beside it, on whichever side
+// fits on the screen
+func (h *UIBotHand) drawRevealed(screen *ebiten.Image) {
+	const pad = 6
+	metrics := h.RevealFont.Metrics()
+	lineH := (metrics.Ascent + metrics.Descent).Ceil() + 2
+
+	w := 0
+	for _, name := range h.Revealed {
+		w = max(w, font.MeasureString(h.RevealFont, name).Ceil())
+	}
+	w += 2 * pad
+	boxH := lineH*len(h.Revealed) + 2*pad
+
+	sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+	x := h.X + h.Width + pad
+	if x+w > sw {
+		x = h.X - pad - w
+	}
+	y := min(h.Y, sh-boxH)
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(boxH), color.RGBA{0, 0, 0, 180}, false)
+	for i, name := range h.Revealed {
+		text.Draw(screen, name, h.RevealFont, x+pad, y+pad+i*lineH+metrics.Ascent.Ceil(), color.White)
+	}
 }
 
 func (h *UIBotHand) UpdateCards(cards []view.Card, cardBackImage *ebiten.Image) {
@@ -80,18 +121,21 @@ func (h *UIBotHand) UpdateCards(cards []view.Card, cardBackImage *ebiten.Image)
 }
 
 func (h *UIBotHand) Contains(x, y int) bool {
-	// Bot hands don't need to be interactive
-	return false
+	// Bot hands are only interactive while picking a target
+	return h.visible && h.OnClick != nil &&
+		x >= h.X && x < h.X+h.Width && y >= h.Y && y < h.Y+h.Height
 }
 
 func (h *UIBotHand) HandleMouseDown(x, y int) bool {
-	// Bot hands don't need to be interactive
-	return false
+	return h.Contains(x, y)
 }
 
 func (h *UIBotHand) HandleMouseUp(x, y int) bool {
-	// Bot hands don't need to be interactive
-	return false
+	if !h.Contains(x, y) {
+		return false
+	}
+	h.OnClick()
+	return true
 }
 
 func (h *UIBotHand) IsVisible() bool             { return h.visible }
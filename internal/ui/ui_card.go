@@ -3,6 +3,7 @@ package ui
 import (
 	"image/color"
 	"math"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
@@ -13,6 +14,13 @@ import (
 
 var _ Element = (*UICard)(nil)
 
+// highContrast draws cards with thicker borders in colour blind safe colours
+var highContrast bool
+
+func SetHighContrast(v bool) {
+	highContrast = v
+}
+
 type UICard struct {
 	ID             string
 	X, Y           int
@@ -22,14 +30,17 @@ type UICard struct {
 	HighlightColor color.RGBA
 	CanMakeDish    bool
 
-	CardType         string // "ingredient" or "recipe"
+	CardType         string // "ingredient", "recipe", "wildcard" or "action"
 	Name             string
 	Icon             string
-	Requirements     []string
-	RequirementNames map[string]string // Map of requirement ID to name
+	Description      string
+	Tags             []string
+	Requirements     []view.Requirement
+	RequirementNames map[string]string // Map of ingredient ID to name
 
-	HighlightedRequirements map[string]bool // Requirements that are available on the table
-	IsNeededForRecipe       bool            // If this ingredient is needed for a recipe on the table
+	HighlightedRequirements []bool // Requirements that are available on the table
+	IsNeededForRecipe       bool   // If this ingredient is needed for a recipe on the table
+	Hinted                  bool   // Suggested by a hint
 
 	// Fonts
 	TitleFont    font.Face
@@ -60,7 +71,7 @@ func NewUICard(id string, w, h int) *UICard {
 		SelectedColor:           color.RGBA{R: 0x00, G: 0xFF, B: 0x00, A: 0xFF}, // Green
 		HighlightColor:          color.RGBA{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}, // Red
 		CardType:                "ingredient",
-		HighlightedRequirements: make(map[string]bool),
+		HighlightedRequirements: []bool{},
 		RequirementNames:        make(map[string]string),
 	}
 }
@@ -71,15 +82,29 @@ func (u *UICard) Draw(screen *ebiten.Image) {
 	}
 
 	bgColor := color.RGBA{0xFA, 0xF8, 0xF0, 0xFF} // Ingredient: #FAF8F0
-	if u.CardType == "recipe" {
+	switch u.CardType {
+	case "recipe":
 		bgColor = color.RGBA{0xFF, 0xF5, 0xCC, 0xFF} // Recipe: #FFF5CC
+	case "wildcard":
+		bgColor = color.RGBA{0xE6, 0xF4, 0xDC, 0xFF} // Wildcard: #E6F4DC
+	case "action":
+		bgColor = color.RGBA{0xFB, 0xE3, 0xDF, 0xFF} // Action: #FBE3DF
 	}
 
-	borderColor := u.BorderColor
+	borderColor, selectedColor, highlightColor := u.BorderColor, u.SelectedColor, u.HighlightColor
+	hintColor := color.RGBA{0x00, 0xA6, 0xD6, 0xFF} // Sky blue #00A6D6
+	if highContrast {
+		borderColor = color.RGBA{0x22, 0x22, 0x22, 0xFF}    // #222222
+		selectedColor = color.RGBA{0x00, 0x72, 0xB2, 0xFF}  // Blue #0072B2
+		highlightColor = color.RGBA{0xE6, 0x9F, 0x00, 0xFF} // Orange #E69F00
+		hintColor = color.RGBA{0xCC, 0x79, 0xA7, 0xFF}      // Pink #CC79A7
+	}
 	if u.selected {
-		borderColor = u.SelectedColor
+		borderColor = selectedColor
+	} else if u.Hinted {
+		borderColor = hintColor
 	} else if u.IsNeededForRecipe || u.CanMakeDish {
-		borderColor = u.HighlightColor
+		borderColor = highlightColor
 	}
 	// else if u.hovering {
 	// }
@@ -92,6 +117,12 @@ func (u *UICard) Draw(screen *ebiten.Image) {
 	w, h := float32(u.Width), float32(u.Height)
 	radius := float32(6)
 	borderWidth := float32(2)
+	if highContrast {
+		borderWidth = 4
+	}
+	if u.Hinted && !u.selected {
+		borderWidth += 2
+	}
 
 	vector.DrawFilledRect(screen, x+radius, y, w-radius*2, h, bgColor, false)
 	vector.DrawFilledRect(screen, x, y+radius, w, h-radius*2, bgColor, false)
@@ -121,6 +152,9 @@ func (u *UICard) Draw(screen *ebiten.Image) {
 		if u.Name != "" {
 			text.Draw(screen, u.Name, u.BodyFont, u.X+padding, u.Y+50, textColor)
 		}
+		for i, tag := range u.Tags {
+			text.Draw(screen, "#"+tag, u.BodyFont, u.X+padding, u.Y+70+i*15, highlightText)
+		}
 	} else if u.CardType == "recipe" {
 		titleY := u.Y + 20
 		title := u.Icon + " " + u.Name
@@ -131,20 +165,46 @@ func (u *UICard) Draw(screen *ebiten.Image) {
 		text.Draw(screen, "Require:", u.SubtitleFont, u.X+padding, titleY+20, textColor)
 
 		reqY := titleY + 35
-		for i, reqID := range u.Requirements {
-			reqName := u.RequirementNames[reqID]
-			if reqName == "" {
-				reqName = reqID
-			}
+		for i, req := range u.Requirements {
 			col := textColor
-			if u.HighlightedRequirements[reqID] {
+			if i < len(u.HighlightedRequirements) && u.HighlightedRequirements[i] {
 				col = highlightText
 			}
-			text.Draw(screen, "- "+reqName, u.BodyFont, u.X+padding, reqY+i*15, col)
+			text.Draw(screen, "- "+u.requirementName(req), u.BodyFont, u.X+padding, reqY+i*15, col)
+		}
+	} else if u.CardType == "wildcard" || u.CardType == "action" {
+		titleY := u.Y + 20
+		text.Draw(screen, u.Name, u.SubtitleFont, u.X+padding, titleY, titleColor)
+
+		vector.DrawFilledRect(screen, float32(u.X+padding), float32(titleY+5), float32(u.Width-padding*2), 1, titleColor, false)
+
+		for i, line := range wrapText(u.Description, u.BodyFont, u.Width-padding*2) {
+			text.Draw(screen, line, u.BodyFont, u.X+padding, titleY+25+i*13, textColor)
 		}
 	}
 }
 
+// wrapText splits s into lines that fit within width when drawn with face
+func wrapText(s string, face font.Face, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
 func drawArc(screen *ebiten.Image, cx, cy, r, start, end, width float32, col color.Color) {
 	const segments = 10
 	thetaStep := (end - start) / segments
@@ -162,12 +222,24 @@ func drawArc(screen *ebiten.Image, cx, cy, r, start, end, width float32, col col
 	}
 }
 
+func (u *UICard) requirementName(req view.Requirement) string {
+	if req.Tag != "" {
+		return "any " + req.Tag
+	}
+	if name := u.RequirementNames[req.IngredientID]; name != "" {
+		return name
+	}
+	return req.IngredientID
+}
+
 // SetCardData sets the card data based on the view.Card
 func (u *UICard) SetCardData(card view.Card, titleFont, subtitleFont, bodyFont font.Face) {
 	u.CardType = card.Type
 	u.Name = card.Name
 	u.Icon = card.Icon
-	u.Requirements = card.RequiredIngredientIDs
+	u.Description = card.Description
+	u.Tags = card.Tags
+	u.Requirements = card.Requirements
 	u.TitleFont = titleFont
 	u.SubtitleFont = subtitleFont
 	u.BodyFont = bodyFont
@@ -178,21 +250,14 @@ func (u *UICard) SetRequirementNames(names map[string]string) {
 	u.RequirementNames = names
 }
 
-func (u *UICard) UpdateHightlightingHandRecipes(tableStack view.TableStack) {
+// UpdateHighlightedRequirements highlights the requirements of a recipe that
+// are already covered by ingredients on the table
+func (u *UICard) UpdateHighlightedRequirements(card view.Card) {
 	if u.CardType != "recipe" {
 		return
 	}
 
-	// Clear previous highlighting
-	for _, reqID := range u.Requirements {
-		u.HighlightedRequirements[reqID] = false
-	}
-
-	for _, reqID := range u.Requirements {
-		if _, has := tableStack.MapIngredientsByID[reqID]; has {
-			u.HighlightedRequirements[reqID] = true
-		}
-	}
+	u.HighlightedRequirements = card.FilledRequirements
 }
 
 func (u *UICard) Update() {
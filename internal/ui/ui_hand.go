@@ -13,6 +13,8 @@ type UIHand struct {
 	Width, Height  int
 	Cards          []*UICard
 	selectedCard   *UICard
+	hintedID       string // Card suggested by a hint, drawn with its own border
+	selectable     func(cardID string) bool
 	onPlayCard     func(cardID string)
 	onCardSelected func(cardID string) // New callback for card selection
 	visible        bool
@@ -65,6 +67,9 @@ func (h *UIHand) HandleMouseDown(x, y int) bool {
 	for i := len(h.Cards) - 1; i >= 0; i-- {
 		card := h.Cards[i]
 		if card.Contains(x, y) {
+			if h.selectable != nil && !h.selectable(card.ID) {
+				return true // Swallow the click, the card stays as it is
+			}
 			if h.selectedCard != nil && h.selectedCard != card {
 				// Deselect previous card
 				h.selectedCard.Y = h.Y
@@ -129,6 +134,19 @@ func (h *UIHand) GetSelectedCardID() string {
 	return h.selectedCard.ID
 }
 
+// SetHinted marks the card suggested by a hint, an empty ID clears it
+func (h *UIHand) SetHinted(cardID string) {
+	h.hintedID = cardID
+	for _, card := range h.Cards {
+		card.Hinted = card.ID == cardID
+	}
+}
+
+// SetSelectable limits which cards can be selected, nil allows every card
+func (h *UIHand) SetSelectable(selectable func(cardID string) bool) {
+	h.selectable = selectable
+}
+
 func (h *UIHand) SetOnPlayCard(callback func(cardID string)) {
 	h.onPlayCard = callback
 }
@@ -143,7 +161,7 @@ func (h *UIHand) PlaySelected() bool {
 	return false
 }
 
-func (h *UIHand) UpdateCards(cards []view.Card, tableStack view.TableStack, fonts map[string]font.Face, ingredientNames map[string]string) {
+func (h *UIHand) UpdateCards(cards []view.Card, fonts map[string]font.Face, ingredientNames map[string]string) {
 	if len(cards) == 0 {
 		h.Cards = []*UICard{}
 		h.selectedCard = nil
@@ -164,12 +182,12 @@ func (h *UIHand) UpdateCards(cards []view.Card, tableStack view.TableStack, font
 
 		if existing, ok := existingCards[card.ID]; ok {
 			uiCard = existing
-			uiCard.UpdateHightlightingHandRecipes(tableStack)
+			uiCard.UpdateHighlightedRequirements(card)
 		} else {
 			uiCard = NewUICard(card.ID, cardWidth, cardHeight)
 			uiCard.SetCardData(card, fonts["title"], fonts["subtitle"], fonts["body"])
 			uiCard.SetRequirementNames(ingredientNames)
-			uiCard.UpdateHightlightingHandRecipes(tableStack)
+			uiCard.UpdateHighlightedRequirements(card)
 		}
 
 		overlap := 30
@@ -181,6 +199,7 @@ func (h *UIHand) UpdateCards(cards []view.Card, tableStack view.TableStack, font
 		if uiCard != h.selectedCard {
 			uiCard.Y = h.Y
 		}
+		uiCard.Hinted = card.ID == h.hintedID
 
 		newCards = append(newCards, uiCard)
 	}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"image/color"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
@@ -16,6 +17,7 @@ type UILabel struct {
 	Font       font.Face
 	TextColor  color.Color
 	HoverColor color.Color
+	Wrap       int // Width to wrap the text at, 0 keeps each line whole
 
 	EnableHover bool
 	HoverScale  float64
@@ -68,14 +70,27 @@ func (l *UILabel) Draw(screen *ebiten.Image) {
 		textColor = l.HoverColor
 	}
 
+	s := l.Text
+	if l.Wrap > 0 {
+		var lines []string
+		for _, paragraph := range strings.Split(l.Text, "\n") {
+			wrapped := wrapText(paragraph, l.Font, l.Wrap)
+			if len(wrapped) == 0 {
+				wrapped = []string{""} // Keep blank lines
+			}
+			lines = append(lines, wrapped...)
+		}
+		s = strings.Join(lines, "\n")
+	}
+
 	x := l.X
 	if l.centered {
-		bounds := text.BoundString(l.Font, l.Text)
+		bounds := text.BoundString(l.Font, s)
 		width := bounds.Max.X - bounds.Min.X
 		x = l.X - width/2
 	}
 
-	text.Draw(screen, l.Text, l.Font, x, l.Y, textColor)
+	text.Draw(screen, s, l.Font, x, l.Y, textColor)
 }
 
 func (l *UILabel) AlignCenter() {
@@ -4,7 +4,6 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
-	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/thanhfphan/ebitengj2025/internal/view"
@@ -22,6 +21,9 @@ type UITableCards struct {
 	BackgroundColor color.RGBA
 	BackgroundImage *ebiten.Image
 
+	// OnCardClicked is set while a card on the table can be picked as a target
+	OnCardClicked func(cardID string)
+
 	needsResolveOverlaps bool
 
 	visible bool
@@ -118,6 +120,16 @@ func (u *UITableCards) HandleMouseDown(x, y int) bool {
 		return false
 	}
 
+	if u.OnCardClicked != nil {
+		for i := len(u.Cards) - 1; i >= 0; i-- {
+			if u.Cards[i].Contains(x, y) {
+				u.OnCardClicked(u.Cards[i].ID)
+				return true
+			}
+		}
+		return false
+	}
+
 	for _, card := range u.Cards {
 		if card.HandleMouseDown(x, y) {
 			return true
@@ -152,41 +164,17 @@ func (u *UITableCards) ResetCanMakeDish() {
 	}
 }
 
-func (u *UITableCards) UpdateCanMakeDish(ingredientID string, tableStack view.TableStack) {
-	if ingredientID == "" {
+// HighlightCanMakeDish marks the recipe that the selected card would complete
+func (u *UITableCards) HighlightCanMakeDish(recipeID string) {
+	if recipeID == "" {
 		return
 	}
 
-	for i := 0; i < len(tableStack.StackRecipes); i++ {
-		recipeID := tableStack.StackRecipes[i]
-		recipe := tableStack.MapRecipes[recipeID]
-
-		if !slices.Contains(recipe.RequiredIngredientIDs, ingredientID) {
-			continue
-		}
-		// already have this ingredient
-		if recipe.CurrentIngredientCount[ingredientID] {
-			continue
-		}
-		count := 0
-		for _, has := range recipe.CurrentIngredientCount {
-			if has {
-				count++
-			}
-		}
-		// not enough cards to make this recipe(need 1 more)
-		if len(recipe.RequiredIngredientIDs) != count+1 {
-			continue
-		}
-
-		for _, card := range u.Cards {
-			if card.ID == recipeID {
-				card.CanMakeDish = true
-			}
+	for _, card := range u.Cards {
+		if card.ID == recipeID {
+			card.CanMakeDish = true
 		}
-		break // Only highlight the topmost recipe
 	}
-
 }
 
 // UpdateFromTableStack updates the UI cards based on the view.TableStack
@@ -200,7 +188,7 @@ func (u *UITableCards) UpdateFromTableStack(tableStack view.TableStack, fonts ma
 		for _, c := range u.Cards {
 			if c.ID == recipe.ID {
 				found = true
-				c.UpdateHightlightingHandRecipes(tableStack)
+				c.UpdateHighlightedRequirements(recipe)
 				break
 			}
 		}
@@ -210,7 +198,7 @@ func (u *UITableCards) UpdateFromTableStack(tableStack view.TableStack, fonts ma
 			card.SetDraggable(true)
 			card.SetCardData(recipe, fonts["title"], fonts["subtitle"], fonts["body"])
 			card.SetRequirementNames(ingredientNames)
-			card.UpdateHightlightingHandRecipes(tableStack)
+			card.UpdateHighlightedRequirements(recipe)
 
 			// Place new cards in a more distributed way
 			angle := rand.Float64() * 2 * math.Pi
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"image/color"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+var _ Element = (*UITextInput)(nil)
+
+// UITextInput is a single line text field. It takes the keyboard after a
+// click on it and lets go of it after a click anywhere else.
+type UITextInput struct {
+	X, Y          int
+	Width, Height int
+	Text          string
+	Placeholder   string // Shown greyed out while the text is empty
+	MaxLength     int    // In runes, 0 means no limit
+	Font          font.Face
+	Focused       bool
+
+	runes   []rune // Reused buffer for ebiten.AppendInputChars
+	ticks   int    // Drives the caret blink
+	visible bool
+	zIndex  int
+}
+
+func NewUITextInput(x, y, width, height int, font font.Face) *UITextInput {
+	return &UITextInput{
+		X:       x,
+		Y:       y,
+		Width:   width,
+		Height:  height,
+		Font:    font,
+		visible: true,
+	}
+}
+
+func (t *UITextInput) Update() {
+	if !t.visible {
+		t.Focused = false
+		return
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		t.Focused = t.Contains(ebiten.CursorPosition())
+	}
+	if !t.Focused {
+		return
+	}
+	t.ticks++
+
+	t.runes = ebiten.AppendInputChars(t.runes[:0])
+	for _, r := range t.runes {
+		if t.MaxLength > 0 && utf8.RuneCountInString(t.Text) >= t.MaxLength {
+			break
+		}
+		t.Text += string(r)
+	}
+
+	if repeatingKeyPressed(ebiten.KeyBackspace) && t.Text != "" {
+		_, size := utf8.DecodeLastRuneInString(t.Text)
+		t.Text = t.Text[:len(t.Text)-size]
+	}
+}
+
+// repeatingKeyPressed is true on the first frame a key is down, then
+// repeatedly while it is held
+func repeatingKeyPressed(key ebiten.Key) bool {
+	const (
+		delay    = 30
+		interval = 3
+	)
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	return d >= delay && (d-delay)%interval == 0
+}
+
+func (t *UITextInput) Draw(screen *ebiten.Image) {
+	if !t.visible {
+		return
+	}
+
+	border := color.RGBA{0x36, 0x55, 0x34, 0xFF} // #365534
+	if t.Focused {
+		border = color.RGBA{0xFF, 0xE7, 0x4D, 0xFF} // #FFE74D
+	}
+	vector.DrawFilledRect(screen, float32(t.X), float32(t.Y), float32(t.Width), float32(t.Height), color.RGBA{0xF3, 0xE2, 0xC3, 0xFF}, false)
+	vector.StrokeRect(screen, float32(t.X), float32(t.Y), float32(t.Width), float32(t.Height), 2, border, false)
+
+	padding := 10
+	metrics := t.Font.Metrics()
+	baseline := t.Y + (t.Height+metrics.Ascent.Ceil()-metrics.Descent.Ceil())/2
+
+	if t.Text == "" && !t.Focused {
+		text.Draw(screen, t.Placeholder, t.Font, t.X+padding, baseline, color.RGBA{0x8A, 0x8A, 0x7A, 0xFF})
+		return
+	}
+	text.Draw(screen, t.Text, t.Font, t.X+padding, baseline, color.RGBA{0x36, 0x55, 0x34, 0xFF})
+
+	// Blinking caret after the text
+	if t.Focused && t.ticks/30%2 == 0 {
+		caretX := t.X + padding + font.MeasureString(t.Font, t.Text).Ceil() + 2
+		vector.DrawFilledRect(screen, float32(caretX), float32(t.Y+8), 2, float32(t.Height-16), border, false)
+	}
+}
+
+func (t *UITextInput) Contains(x, y int) bool {
+	return t.visible && x >= t.X && x < t.X+t.Width && y >= t.Y && y < t.Y+t.Height
+}
+
+func (t *UITextInput) HandleMouseDown(x, y int) bool { return t.Contains(x, y) }
+func (t *UITextInput) HandleMouseUp(x, y int) bool   { return t.Contains(x, y) }
+func (t *UITextInput) IsVisible() bool               { return t.visible }
+func (t *UITextInput) SetVisible(v bool)             { t.visible = v }
+func (t *UITextInput) GetZIndex() int                { return t.zIndex }
+func (t *UITextInput) SetZIndex(z int)               { t.zIndex = z }
+func (t *UITextInput) IsStatic() bool                { return true }
+func (t *UITextInput) SetDraggable(draggable bool)   {}
+func (t *UITextInput) SetPosition(x, y int) {
+	t.X = x
+	t.Y = y
+}
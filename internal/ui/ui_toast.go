@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+var _ Element = (*UIToast)(nil)
+
+// UIToast shows short notifications one after another in a corner of the
+// screen. It does not take input.
+type UIToast struct {
+	X, Y          int
+	Width, Height int
+	TitleFont     font.Face
+	BodyFont      font.Face
+	Duration      int // Frames a notification stays on screen
+
+	queue   []toastMessage
+	elapsed int
+	visible bool
+	zIndex  int
+}
+
+type toastMessage struct {
+	title string
+	body  string
+}
+
+func NewUIToast(x, y, w, h int, titleFont, bodyFont font.Face) *UIToast {
+	return &UIToast{
+		X:         x,
+		Y:         y,
+		Width:     w,
+		Height:    h,
+		TitleFont: titleFont,
+		BodyFont:  bodyFont,
+		Duration:  180, // 3 seconds at 60 TPS
+		visible:   true,
+	}
+}
+
+// Show queues a notification
+func (t *UIToast) Show(title, body string) {
+	t.queue = append(t.queue, toastMessage{title: title, body: body})
+}
+
+func (t *UIToast) Update() {
+	if len(t.queue) == 0 {
+		return
+	}
+	t.elapsed++
+	if t.elapsed >= t.Duration {
+		t.queue = t.queue[1:]
+		t.elapsed = 0
+	}
+}
+
+func (t *UIToast) Draw(screen *ebiten.Image) {
+	if !t.visible || len(t.queue) == 0 {
+		return
+	}
+	msg := t.queue[0]
+
+	// Fade in and out over a few frames
+	alpha := 1.0
+	fade := 15
+	if t.elapsed < fade {
+		alpha = float64(t.elapsed) / float64(fade)
+	} else if t.Duration-t.elapsed < fade {
+		alpha = float64(t.Duration-t.elapsed) / float64(fade)
+	}
+	a := func(c color.RGBA) color.RGBA {
+		f := alpha
+		return color.RGBA{uint8(float64(c.R) * f), uint8(float64(c.G) * f), uint8(float64(c.B) * f), uint8(float64(c.A) * f)}
+	}
+
+	bg := color.RGBA{0x36, 0x55, 0x34, 0xE6}     // #365534
+	border := color.RGBA{0xFF, 0xE7, 0x4D, 0xFF} // #FFE74D
+	vector.DrawFilledRect(screen, float32(t.X), float32(t.Y), float32(t.Width), float32(t.Height), a(bg), false)
+	vector.StrokeRect(screen, float32(t.X), float32(t.Y), float32(t.Width), float32(t.Height), 2, a(border), false)
+
+	padding := 12
+	text.Draw(screen, msg.title, t.TitleFont, t.X+padding, t.Y+padding+18, a(border))
+	text.Draw(screen, msg.body, t.BodyFont, t.X+padding, t.Y+padding+44, a(color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}))
+}
+
+func (t *UIToast) Contains(x, y int) bool        { return false }
+func (t *UIToast) HandleMouseDown(x, y int) bool { return false }
+func (t *UIToast) HandleMouseUp(x, y int) bool   { return false }
+func (t *UIToast) IsVisible() bool               { return t.visible }
+func (t *UIToast) SetVisible(v bool)             { t.visible = v }
+func (t *UIToast) GetZIndex() int                { return t.zIndex }
+func (t *UIToast) SetZIndex(z int)               { t.zIndex = z }
+func (t *UIToast) IsStatic() bool                { return true }
+func (t *UIToast) SetDraggable(draggable bool)   {}
+func (t *UIToast) SetPosition(x, y int) {
+	t.X = x
+	t.Y = y
+}
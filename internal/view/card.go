@@ -8,20 +8,29 @@ import (
 type Card struct {
 	ID    string
 	Image *ebiten.Image
-	Type  string // "ingredient" or "recipe"
+	Type  string // "ingredient", "recipe", "wildcard" or "action"
 	Name  string
 	Icon  string
 
-	IngredientID string // For ingredient cards
+	Description string // For wildcard and action cards
 
-	RequiredIngredientIDs  []string        // For recipe cards
-	CurrentIngredientCount map[string]bool // For recipe cards
+	IngredientID string   // For ingredient cards
+	Tags         []string // For ingredient cards
+
+	Requirements       []Requirement // For recipe cards
+	FilledRequirements []bool        // For recipe cards, parallel to Requirements
+}
+
+// Requirement is one slot of a recipe, either an exact ingredient or any
+// ingredient with the tag
+type Requirement struct {
+	IngredientID string
+	Tag          string
 }
 
 // TableStack represents a collection of cards on the table
 type TableStack struct {
-	MapRecipes         map[string]Card
-	MapIngredients     map[string]Card
-	MapIngredientsByID map[string]bool
-	StackRecipes       []string // Newest(last put on table) to oldest
+	MapRecipes     map[string]Card
+	MapIngredients map[string]Card
+	StackRecipes   []string // Newest(last put on table) to oldest
 }